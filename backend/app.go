@@ -3,9 +3,14 @@ package main
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/johnwongx/webook/backend/internal/events"
+	"github.com/johnwongx/webook/backend/internal/job"
+	"github.com/johnwongx/webook/backend/internal/service"
 )
 
 type App struct {
 	server    *gin.Engine
 	consumers []events.Consumer
+	jobs      []*job.TickerScheduler
+	// readerSvc 命令行重放读者侧时使用
+	readerSvc service.ArticleReaderService
 }
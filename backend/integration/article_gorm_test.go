@@ -31,7 +31,7 @@ func (s *ArticleGORMHandlerTestSuite) SetupSuite() {
 		})
 		ctx.Next()
 	})
-	hdl := startup.InitArticleHandler(article.NewGORMArticleDAO(s.db))
+	hdl := startup.InitArticleHandler(article.NewGORMArticleDAO(s.db, startup.InitLog()))
 	hdl.RegisterRutes(s.s)
 }
 
@@ -0,0 +1,14 @@
+package startup
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/johnwongx/webook/backend/pkg/blobstore"
+)
+
+// InitBlobStore 测试用本地存储，放在临时目录下
+func InitBlobStore() blobstore.BlobStore {
+	root := filepath.Join(os.TempDir(), "webook-blob")
+	return blobstore.NewLocalStore(root, "http://localhost:8080/blob/", []byte("integration-test"))
+}
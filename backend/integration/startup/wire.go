@@ -5,45 +5,135 @@ package startup
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
+	events "github.com/johnwongx/webook/backend/internal/events/article"
 	"github.com/johnwongx/webook/backend/internal/repository"
 	"github.com/johnwongx/webook/backend/internal/repository/cache"
 	"github.com/johnwongx/webook/backend/internal/repository/dao"
 	"github.com/johnwongx/webook/backend/internal/repository/dao/article"
+	"github.com/johnwongx/webook/backend/internal/repository/search"
 	"github.com/johnwongx/webook/backend/internal/service"
 	"github.com/johnwongx/webook/backend/internal/web"
 	myjwt "github.com/johnwongx/webook/backend/internal/web/jwt"
 	"github.com/johnwongx/webook/backend/ioc"
 )
 
-var thirdProvider = wire.NewSet(InitRedis, InitTestDB, InitLog)
+var thirdProvider = wire.NewSet(InitRedis, InitTestDB, InitLog, InitBlobStore)
 var userSvcProvider = wire.NewSet(
 	dao.NewUserDAO,
 	cache.NewRedisUserCache,
 	repository.NewUserRepository,
 	service.NewUserService,
 )
+var producerProvider = wire.NewSet(
+	InitKafka,
+	ioc.NewSyncProducer,
+	events.NewKafkaProducer,
+)
+
+// articleSvcProvider 文章服务及其依赖，不包括 ArticleDAO
 var articleSvcProvider = wire.NewSet(
-	article.NewGORMArticleDAO,
+	ioc.InitReaderArticleDAO,
+	ioc.InitSensitiveMatcher,
+	article.NewGORMArticleScheduleDAO,
+	dao.NewGORMInteractiveDAO,
+	dao.NewGORMFollowDAO,
+	dao.NewGORMFeedDAO,
+	dao.NewGORMSeriesDAO,
+	dao.NewGORMUploadDAO,
+
+	cache.NewRedisArticleCache,
+	cache.NewRedisArticleTagCache,
+	cache.NewRedisArticleAuthorCache,
+	cache.NewRedisSyndicationCache,
+	cache.NewRedisSitemapCache,
+	cache.NewRedisInteractiveCache,
+	search.NewMemoryArticleIndex,
+
 	repository.NewArticleRepository,
-	service.NewArticleService)
+	repository.NewArticleScheduleRepository,
+	repository.NewInteractiveRepository,
+	repository.NewFollowRepository,
+	repository.NewFeedRepository,
+	repository.NewSeriesRepository,
+	repository.NewUploadRepository,
+
+	service.NewArticleService,
+	service.NewInteractiveService,
+	service.NewFeedService,
+	service.NewSeriesService,
+	service.NewUploadService,
+)
 
 func InitWebServer() *gin.Engine {
 	wire.Build(
 		thirdProvider,
 		userSvcProvider,
+		producerProvider,
 		articleSvcProvider,
+		article.NewGORMArticleDAO,
+		ioc.InitSite,
+		dao.NewGORMCommentDAO,
+		dao.NewGORMCollectionDAO,
+		dao.NewGORMExportDAO,
+		dao.NewGORMImportDAO,
+		dao.NewGORMReadingHistoryDAO,
 		cache.NewRedisCodeCache,
+		cache.NewRedisRankingCache,
+		cache.NewLocalRankingCache,
+		cache.NewRedisCommentCache,
 		repository.NewCodeRepository,
+		repository.NewArticleSearchRepository,
+		repository.NewCachedRankingRepository,
+		repository.NewCommentRepository,
+		repository.NewCollectionRepository,
+		repository.NewExportRepository,
+		repository.NewImportRepository,
+		repository.NewCachedSyndicationRepository,
+		repository.NewSitemapRepository,
+		repository.NewReadingHistoryRepository,
+		ioc.InitArticleMigrationDAO,
+		cache.NewRedisArticleMigrationCache,
+		repository.NewArticleMigrationRepository,
+		repository.NewArticleConsistencyRepository,
+		repository.NewReaderArticleRepository,
 
 		//测试用使用内存
 		ioc.InitLocalSms,
 		ioc.NewWechatHandlerConfig,
 		service.NewCodeService,
 		InitPhantomWechatService,
+		service.NewSearchService,
+		service.NewBatchRankingService,
+		service.NewFollowService,
+		service.NewCommentService,
+		service.NewCollectionService,
+		service.NewExportService,
+		service.NewImportService,
+		service.NewSyndicationService,
+		service.NewSitemapService,
+		service.NewReadingHistoryService,
+		service.NewArticleMigrationService,
+		service.NewArticleConsistencyService,
+		service.NewArticleReaderService,
 
 		web.NewUserHandler,
 		web.NewWechatHandler,
 		web.NewArticleHandler,
+		web.NewSearchHandler,
+		web.NewAuthorHandler,
+		web.NewRankingHandler,
+		web.NewFollowHandler,
+		web.NewCommentHandler,
+		web.NewCollectionHandler,
+		web.NewSeriesHandler,
+		web.NewUploadHandler,
+		web.NewExportHandler,
+		web.NewImportHandler,
+		web.NewSyndicationHandler,
+		web.NewSitemapHandler,
+		web.NewReadingHistoryHandler,
+		web.NewAdminHandler,
+		ioc.InitAdminSet,
 		myjwt.NewRedisJwtHandler,
 
 		ioc.InitRedisRateLimit,
@@ -56,8 +146,9 @@ func InitWebServer() *gin.Engine {
 
 func InitArticleHandler(dao article.ArticleDAO) *web.ArticleHandler {
 	wire.Build(thirdProvider,
-		repository.NewArticleRepository,
-		service.NewArticleService,
+		userSvcProvider,
+		producerProvider,
+		articleSvcProvider,
 		web.NewArticleHandler)
 	return new(web.ArticleHandler)
 }
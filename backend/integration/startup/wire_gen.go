@@ -9,10 +9,12 @@ package startup
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
+	article2 "github.com/johnwongx/webook/backend/internal/events/article"
 	"github.com/johnwongx/webook/backend/internal/repository"
 	"github.com/johnwongx/webook/backend/internal/repository/cache"
 	"github.com/johnwongx/webook/backend/internal/repository/dao"
 	"github.com/johnwongx/webook/backend/internal/repository/dao/article"
+	"github.com/johnwongx/webook/backend/internal/repository/search"
 	"github.com/johnwongx/webook/backend/internal/service"
 	"github.com/johnwongx/webook/backend/internal/web"
 	"github.com/johnwongx/webook/backend/internal/web/jwt"
@@ -40,26 +42,145 @@ func InitWebServer() *gin.Engine {
 	wechatService := InitPhantomWechatService(logger)
 	wechatHandlerConfig := ioc.NewWechatHandlerConfig()
 	oAuth2WechatHandler := web.NewWechatHandler(wechatService, userService, wechatHandlerConfig)
-	articleDAO := article.NewGORMArticleDAO(gormDB)
-	articleRepository := repository.NewArticleRepository(articleDAO)
-	articleService := service.NewArticleService(articleRepository, logger)
-	articleHandler := web.NewArticleHandler(articleService, logger)
-	engine := ioc.InitWebServer(v, userHandler, oAuth2WechatHandler, articleHandler)
+	articleDAO := article.NewGORMArticleDAO(gormDB, logger)
+	readerArticleDAO := ioc.InitReaderArticleDAO(gormDB, logger)
+	articleCache := cache.NewRedisArticleCache(cmdable)
+	articleTagCache := cache.NewRedisArticleTagCache(cmdable)
+	articleAuthorCache := cache.NewRedisArticleAuthorCache(cmdable)
+	syndicationCache := cache.NewRedisSyndicationCache(cmdable)
+	sitemapCache := cache.NewRedisSitemapCache(cmdable)
+	articleIndex := search.NewMemoryArticleIndex()
+	articleRepository := repository.NewArticleRepository(articleDAO, readerArticleDAO, userRepository, articleCache, articleTagCache, articleAuthorCache, syndicationCache, sitemapCache, articleIndex, logger)
+	articleScheduleDAO := article.NewGORMArticleScheduleDAO(gormDB)
+	articleScheduleRepository := repository.NewArticleScheduleRepository(articleScheduleDAO)
+	interactiveDAO := dao.NewGORMInteractiveDAO(gormDB, logger)
+	interactiveCache := cache.NewRedisInteractiveCache(cmdable)
+	interactiveRepository := repository.NewInteractiveRepository(interactiveDAO, interactiveCache, logger)
+	followDAO := dao.NewGORMFollowDAO(gormDB)
+	followRepository := repository.NewFollowRepository(followDAO)
+	feedDAO := dao.NewGORMFeedDAO(gormDB)
+	feedRepository := repository.NewFeedRepository(feedDAO)
+	feedService := service.NewFeedService(followRepository, feedRepository, articleRepository, userRepository, logger)
+	uploadDAO := dao.NewGORMUploadDAO(gormDB)
+	blobStore := InitBlobStore()
+	uploadRepository := repository.NewUploadRepository(uploadDAO, blobStore)
+	uploadService := service.NewUploadService(uploadRepository, logger)
+	matcher := ioc.InitSensitiveMatcher(logger)
+	articleService := service.NewArticleService(articleRepository, articleScheduleRepository, interactiveRepository, feedService, uploadService, matcher, logger)
+	interactiveService := service.NewInteractiveService(interactiveRepository)
+	seriesDAO := dao.NewGORMSeriesDAO(gormDB)
+	seriesRepository := repository.NewSeriesRepository(seriesDAO)
+	seriesService := service.NewSeriesService(seriesRepository, articleRepository, userRepository, logger)
+	client := InitKafka()
+	syncProducer := ioc.NewSyncProducer(client)
+	producer := article2.NewKafkaProducer(syncProducer)
+	articleHandler := web.NewArticleHandler(articleService, interactiveService, seriesService, logger, producer)
+	articleSearchRepository := repository.NewArticleSearchRepository(articleDAO, userRepository, articleIndex, logger)
+	searchService := service.NewSearchService(articleSearchRepository)
+	searchHandler := web.NewSearchHandler(searchService, logger)
+	authorHandler := web.NewAuthorHandler(articleService, userService, interactiveService, logger)
+	rankingCache := cache.NewRedisRankingCache(cmdable)
+	localRankingCache := cache.NewLocalRankingCache()
+	rankingRepository := repository.NewCachedRankingRepository(rankingCache, localRankingCache, logger)
+	rankingService := service.NewBatchRankingService(articleRepository, interactiveRepository, userRepository, rankingRepository, logger)
+	rankingHandler := web.NewRankingHandler(rankingService, logger)
+	followService := service.NewFollowService(followRepository, userService, feedService, logger)
+	followHandler := web.NewFollowHandler(followService, feedService, logger)
+	commentDAO := dao.NewGORMCommentDAO(gormDB)
+	commentCache := cache.NewRedisCommentCache(cmdable)
+	commentRepository := repository.NewCommentRepository(commentDAO, commentCache, userRepository, logger)
+	commentService := service.NewCommentService(commentRepository, articleRepository, interactiveRepository, logger)
+	commentHandler := web.NewCommentHandler(commentService, interactiveService, logger)
+	collectionDAO := dao.NewGORMCollectionDAO(gormDB)
+	collectionRepository := repository.NewCollectionRepository(collectionDAO, interactiveCache, logger)
+	collectionService := service.NewCollectionService(collectionRepository, interactiveRepository, articleRepository, logger)
+	collectionHandler := web.NewCollectionHandler(collectionService, logger)
+	seriesHandler := web.NewSeriesHandler(seriesService, logger)
+	uploadHandler := web.NewUploadHandler(uploadService, logger)
+	doubleWriteDAO := ioc.InitArticleMigrationDAO(gormDB, logger)
+	articleMigrationCache := cache.NewRedisArticleMigrationCache(cmdable)
+	articleMigrationRepository := repository.NewArticleMigrationRepository(doubleWriteDAO, articleMigrationCache)
+	articleMigrationService := service.NewArticleMigrationService(articleMigrationRepository, logger)
+	articleConsistencyRepository := repository.NewArticleConsistencyRepository(blobStore)
+	articleConsistencyService := service.NewArticleConsistencyService(articleRepository, articleConsistencyRepository, logger)
+	readerArticleRepository := repository.NewReaderArticleRepository(readerArticleDAO, articleDAO)
+	articleReaderService := service.NewArticleReaderService(readerArticleRepository, logger)
+	adminSet := ioc.InitAdminSet(logger)
+	adminHandler := web.NewAdminHandler(articleService, articleMigrationService, articleConsistencyService, articleReaderService, adminSet, logger)
+	exportDAO := dao.NewGORMExportDAO(gormDB)
+	exportRepository := repository.NewExportRepository(exportDAO, blobStore)
+	exportService := service.NewExportService(exportRepository, articleRepository, logger)
+	exportHandler := web.NewExportHandler(exportService, logger)
+	importDAO := dao.NewGORMImportDAO(gormDB)
+	importRepository := repository.NewImportRepository(importDAO)
+	importService := service.NewImportService(articleService, importRepository, logger)
+	importHandler := web.NewImportHandler(importService, logger)
+	syndicationRepository := repository.NewCachedSyndicationRepository(syndicationCache)
+	site := ioc.InitSite()
+	syndicationService := service.NewSyndicationService(syndicationRepository, articleRepository, userRepository, site, logger)
+	syndicationHandler := web.NewSyndicationHandler(syndicationService, logger)
+	sitemapRepository := repository.NewSitemapRepository(blobStore, sitemapCache)
+	sitemapService := service.NewSitemapService(sitemapRepository, articleRepository, site, logger)
+	sitemapHandler := web.NewSitemapHandler(sitemapService, logger)
+	readingHistoryDAO := dao.NewGORMReadingHistoryDAO(gormDB)
+	readingHistoryRepository := repository.NewReadingHistoryRepository(readingHistoryDAO)
+	readingHistoryService := service.NewReadingHistoryService(readingHistoryRepository, articleRepository, logger)
+	readingHistoryHandler := web.NewReadingHistoryHandler(readingHistoryService, logger)
+	engine := ioc.InitWebServer(v, userHandler, oAuth2WechatHandler, articleHandler, searchHandler, authorHandler, rankingHandler, followHandler, commentHandler, collectionHandler, seriesHandler, uploadHandler, adminHandler, exportHandler, importHandler, syndicationHandler, sitemapHandler, readingHistoryHandler, blobStore)
 	return engine
 }
 
 func InitArticleHandler(dao2 article.ArticleDAO) *web.ArticleHandler {
-	articleRepository := repository.NewArticleRepository(dao2)
+	gormDB := InitTestDB()
 	logger := InitLog()
-	articleService := service.NewArticleService(articleRepository, logger)
-	articleHandler := web.NewArticleHandler(articleService, logger)
+	readerArticleDAO := ioc.InitReaderArticleDAO(gormDB, logger)
+	userDAO := dao.NewUserDAO(gormDB)
+	cmdable := InitRedis()
+	userCache := cache.NewRedisUserCache(cmdable)
+	userRepository := repository.NewUserRepository(userDAO, userCache)
+	articleCache := cache.NewRedisArticleCache(cmdable)
+	articleTagCache := cache.NewRedisArticleTagCache(cmdable)
+	articleAuthorCache := cache.NewRedisArticleAuthorCache(cmdable)
+	syndicationCache := cache.NewRedisSyndicationCache(cmdable)
+	sitemapCache := cache.NewRedisSitemapCache(cmdable)
+	articleIndex := search.NewMemoryArticleIndex()
+	articleRepository := repository.NewArticleRepository(dao2, readerArticleDAO, userRepository, articleCache, articleTagCache, articleAuthorCache, syndicationCache, sitemapCache, articleIndex, logger)
+	articleScheduleDAO := article.NewGORMArticleScheduleDAO(gormDB)
+	articleScheduleRepository := repository.NewArticleScheduleRepository(articleScheduleDAO)
+	interactiveDAO := dao.NewGORMInteractiveDAO(gormDB, logger)
+	interactiveCache := cache.NewRedisInteractiveCache(cmdable)
+	interactiveRepository := repository.NewInteractiveRepository(interactiveDAO, interactiveCache, logger)
+	followDAO := dao.NewGORMFollowDAO(gormDB)
+	followRepository := repository.NewFollowRepository(followDAO)
+	feedDAO := dao.NewGORMFeedDAO(gormDB)
+	feedRepository := repository.NewFeedRepository(feedDAO)
+	feedService := service.NewFeedService(followRepository, feedRepository, articleRepository, userRepository, logger)
+	uploadDAO := dao.NewGORMUploadDAO(gormDB)
+	blobStore := InitBlobStore()
+	uploadRepository := repository.NewUploadRepository(uploadDAO, blobStore)
+	uploadService := service.NewUploadService(uploadRepository, logger)
+	matcher := ioc.InitSensitiveMatcher(logger)
+	articleService := service.NewArticleService(articleRepository, articleScheduleRepository, interactiveRepository, feedService, uploadService, matcher, logger)
+	interactiveService := service.NewInteractiveService(interactiveRepository)
+	seriesDAO := dao.NewGORMSeriesDAO(gormDB)
+	seriesRepository := repository.NewSeriesRepository(seriesDAO)
+	seriesService := service.NewSeriesService(seriesRepository, articleRepository, userRepository, logger)
+	client := InitKafka()
+	syncProducer := ioc.NewSyncProducer(client)
+	producer := article2.NewKafkaProducer(syncProducer)
+	articleHandler := web.NewArticleHandler(articleService, interactiveService, seriesService, logger, producer)
 	return articleHandler
 }
 
 // wire.go:
 
-var thirdProvider = wire.NewSet(InitRedis, InitTestDB, InitLog)
+var thirdProvider = wire.NewSet(InitRedis, InitTestDB, InitLog, InitBlobStore)
 
 var userSvcProvider = wire.NewSet(dao.NewUserDAO, cache.NewRedisUserCache, repository.NewUserRepository, service.NewUserService)
 
-var articleSvcProvider = wire.NewSet(article.NewGORMArticleDAO, repository.NewArticleRepository, service.NewArticleService)
+var producerProvider = wire.NewSet(
+	InitKafka, ioc.NewSyncProducer, article2.NewKafkaProducer,
+)
+
+// articleSvcProvider 文章服务及其依赖，不包括 ArticleDAO
+var articleSvcProvider = wire.NewSet(ioc.InitReaderArticleDAO, ioc.InitSensitiveMatcher, article.NewGORMArticleScheduleDAO, dao.NewGORMInteractiveDAO, dao.NewGORMFollowDAO, dao.NewGORMFeedDAO, dao.NewGORMSeriesDAO, dao.NewGORMUploadDAO, cache.NewRedisArticleCache, cache.NewRedisArticleTagCache, cache.NewRedisArticleAuthorCache, cache.NewRedisSyndicationCache, cache.NewRedisSitemapCache, cache.NewRedisInteractiveCache, search.NewMemoryArticleIndex, repository.NewArticleRepository, repository.NewArticleScheduleRepository, repository.NewInteractiveRepository, repository.NewFollowRepository, repository.NewFeedRepository, repository.NewSeriesRepository, repository.NewUploadRepository, service.NewArticleService, service.NewInteractiveService, service.NewFeedService, service.NewSeriesService, service.NewUploadService)
@@ -1,6 +1,8 @@
 package domain
 
-import "time"
+import (
+	"time"
+)
 
 type ArticleStatus uint8
 
@@ -9,31 +11,122 @@ const (
 	ArticleStatusUnpublished
 	ArticleStatusPublished
 	ArticleStatusPrivate
+	// ArticleStatusScheduled 已设置定时发表，等待到点上线
+	ArticleStatusScheduled
+	// ArticleStatusDeleted 在回收站中，超过保留期限后彻底删除
+	ArticleStatusDeleted
+	// ArticleStatusPendingReview 发表时命中敏感词，等待管理员审核
+	ArticleStatusPendingReview
 )
 
 func (a ArticleStatus) ToUint8() uint8 {
 	return uint8(a)
 }
 
+// Synced 处于该状态的草稿和线上库的内容一致
+func (a ArticleStatus) Synced() bool {
+	return a == ArticleStatusPublished || a == ArticleStatusPrivate
+}
+
 type Article struct {
 	Id      int64
 	Title   string
 	Content string
 	Author  Author
 	Status  ArticleStatus
-	Ctime   time.Time
-	Utime   time.Time
+	// Version 乐观锁版本号，每次修改草稿加一
+	Version int64
+	// Tags 为 nil 时表示不修改标签
+	Tags []string
+	// Category 为空时表示不修改分类
+	Category string
+	// Rendered 发表时由 Content 渲染得到，只有线上库的文章有
+	Rendered RenderedContent
+	Ctime    time.Time
+	Utime    time.Time
+	// Dtime 移入回收站的时间，没有删除时为零值
+	Dtime time.Time
 }
 
+// Abstract 优先使用发表时生成的摘要，草稿和上线渲染之前发表的文章直接截取原文
 func (a *Article) Abstract() string {
-	cs := []rune(a.Content)
-	if len(cs) < 100 {
-		return a.Content
+	if a.Rendered.Abstract != "" {
+		return a.Rendered.Abstract
+	}
+	return AbstractOf(a.Content)
+}
+
+// AbstractOf 取前 100 个字符作为摘要
+func AbstractOf(text string) string {
+	const abstractLen = 100
+	cs := []rune(text)
+	if len(cs) <= abstractLen {
+		return text
 	}
-	return string(a.Content[:100])
+	return string(cs[:abstractLen])
+}
+
+// RenderedContent Markdown 渲染并过滤后的结果
+type RenderedContent struct {
+	HTML       string
+	TOC        []TOCItem
+	FirstImage string
+	// Text 纯文本，用于搜索
+	Text string
+	// Abstract 由 Text 截取的摘要，和文章存在一起，列表不需要再查渲染结果
+	Abstract string
+}
+
+type TOCItem struct {
+	Level  int
+	Title  string
+	Anchor string
+}
+
+// TagCount 标签以及该标签下已发表文章的数量
+type TagCount struct {
+	Tag   string
+	Count int64
 }
 
 type Author struct {
 	Id   int64
 	Name string
 }
+
+// ArticleRevision 每次保存或发表时追加的历史版本，写入后不再修改
+type ArticleRevision struct {
+	Id        int64
+	ArticleId int64
+	Title     string
+	Content   string
+	Author    Author
+	Status    ArticleStatus
+	Ctime     time.Time
+}
+
+type DiffOp uint8
+
+const (
+	DiffOpEqual DiffOp = iota
+	DiffOpInsert
+	DiffOpDelete
+)
+
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// ArticleRevisionDiff 两个历史版本之间的逐行差异
+type ArticleRevisionDiff struct {
+	From  ArticleRevision
+	To    ArticleRevision
+	Lines []DiffLine
+}
+
+// ArticleReview 等待审核的文章以及命中的敏感词
+type ArticleReview struct {
+	Article Article
+	Words   []string
+}
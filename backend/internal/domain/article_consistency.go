@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// ArticleDrift 线上库和制作库不一致的类型
+type ArticleDrift string
+
+const (
+	// ArticleDriftOrphan 制作库中已经没有这篇文章，线上库还有
+	ArticleDriftOrphan ArticleDrift = "orphan"
+	// ArticleDriftMissing 制作库已发表或仅自己可见，线上库没有
+	ArticleDriftMissing ArticleDrift = "missing"
+	// ArticleDriftDeleted 一边在回收站中，另一边不在
+	ArticleDriftDeleted ArticleDrift = "deleted"
+	ArticleDriftStatus  ArticleDrift = "status"
+	ArticleDriftTitle   ArticleDrift = "title"
+	ArticleDriftContent ArticleDrift = "content"
+	// ArticleDriftObjectMissing 已发表的文章在对象存储中没有内容
+	ArticleDriftObjectMissing ArticleDrift = "object_missing"
+	// ArticleDriftObjectStale 已撤回或删除的文章在对象存储中还有内容
+	ArticleDriftObjectStale ArticleDrift = "object_stale"
+)
+
+// ArticleDrifts 全部不一致的类型
+var ArticleDrifts = []ArticleDrift{
+	ArticleDriftOrphan, ArticleDriftMissing, ArticleDriftDeleted, ArticleDriftStatus,
+	ArticleDriftTitle, ArticleDriftContent, ArticleDriftObjectMissing, ArticleDriftObjectStale,
+}
+
+// ArticlePair 同一篇文章在制作库和线上库中的数据
+type ArticlePair struct {
+	Id int64
+	// Draft 制作库，为 nil 表示制作库中已经没有这篇文章
+	Draft *Article
+	// Pub 线上库，为 nil 表示没有发表过
+	Pub *Article
+	// PubContentStored 线上库是否保存了内容，内容放在对象存储中时撤回后就不再保存
+	PubContentStored bool
+	ObjectMissing    bool
+	ObjectStale      bool
+}
+
+// Drifts 以制作库为准检查线上库，一致时返回空。
+// 草稿正在修改或审核时线上库保持上一次发表的内容，只检查删除状态和对象存储
+func (p ArticlePair) Drifts() []ArticleDrift {
+	var res []ArticleDrift
+	draft, pub := p.Draft, p.Pub
+	switch {
+	case draft == nil:
+		if pub != nil {
+			res = append(res, ArticleDriftOrphan)
+		}
+	case pub == nil:
+		if draft.Dtime.IsZero() && draft.Status.Synced() {
+			res = append(res, ArticleDriftMissing)
+		}
+	case draft.Dtime.IsZero() != pub.Dtime.IsZero():
+		res = append(res, ArticleDriftDeleted)
+	case draft.Dtime.IsZero() && draft.Status.Synced():
+		if draft.Status != pub.Status {
+			res = append(res, ArticleDriftStatus)
+		}
+		if draft.Title != pub.Title {
+			res = append(res, ArticleDriftTitle)
+		}
+		if p.PubContentStored && ContentHash(draft.Content) != ContentHash(pub.Content) {
+			res = append(res, ArticleDriftContent)
+		}
+	}
+	if p.ObjectMissing {
+		res = append(res, ArticleDriftObjectMissing)
+	}
+	if p.ObjectStale {
+		res = append(res, ArticleDriftObjectStale)
+	}
+	return res
+}
+
+// Repairable 草稿正在修改时，已发表的内容丢了无法从制作库恢复，只能等作者重新发表
+func (p ArticlePair) Repairable() bool {
+	draft := p.Draft
+	if p.ObjectMissing && draft != nil && draft.Dtime.IsZero() && !draft.Status.Synced() {
+		return false
+	}
+	return true
+}
+
+// RepairedPubStatus 以制作库为准修复后线上库的状态，线上库不需要这篇文章时为 ArticleStatusDeleted
+func (p ArticlePair) RepairedPubStatus() ArticleStatus {
+	draft := p.Draft
+	switch {
+	case draft == nil || !draft.Dtime.IsZero():
+		return ArticleStatusDeleted
+	case draft.Status.Synced():
+		return draft.Status
+	case p.Pub == nil:
+		return ArticleStatusDeleted
+	case !p.Pub.Dtime.IsZero():
+		// 从回收站恢复的文章线上库是仅自己可见
+		return ArticleStatusPrivate
+	default:
+		return p.Pub.Status
+	}
+}
+
+// ContentHash 文章内容的 SHA-256，十六进制
+func ContentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// ArticleDriftRecord 一篇不一致的文章
+type ArticleDriftRecord struct {
+	ArticleId   int64
+	AuthorId    int64
+	Drifts      []ArticleDrift
+	DraftStatus ArticleStatus
+	PubStatus   ArticleStatus
+	// DraftHash、PubHash 两边内容的 SHA-256，没有内容时为空
+	DraftHash string
+	PubHash   string
+	// Repaired 已经以制作库为准修复
+	Repaired bool
+	// Error 没有修复的原因
+	Error string
+}
+
+// ArticleConsistencyReport 一次一致性检查的结果
+type ArticleConsistencyReport struct {
+	Start  time.Time
+	End    time.Time
+	Repair bool
+	// Scanned 检查的文章数
+	Scanned int
+	// Counts 各类不一致的文章数，一篇文章可能同时有多种
+	Counts   map[ArticleDrift]int
+	Drifted  int
+	Repaired int
+	// Records 不一致的文章，超过上限时只保留前面的
+	Records   []ArticleDriftRecord
+	Truncated bool
+	// Error 检查中途失败的原因
+	Error string
+}
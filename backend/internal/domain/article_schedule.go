@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+type ArticleScheduleAction uint8
+
+const (
+	ArticleScheduleActionUnknown ArticleScheduleAction = iota
+	// ArticleScheduleActionPublish 定时发表
+	ArticleScheduleActionPublish
+	// ArticleScheduleActionWithdraw 定时撤回
+	ArticleScheduleActionWithdraw
+)
+
+func (a ArticleScheduleAction) ToUint8() uint8 {
+	return uint8(a)
+}
+
+type ArticleScheduleStatus uint8
+
+const (
+	ArticleScheduleStatusUnknown ArticleScheduleStatus = iota
+	ArticleScheduleStatusPending
+	ArticleScheduleStatusRunning
+	ArticleScheduleStatusDone
+	ArticleScheduleStatusCancelled
+	// ArticleScheduleStatusFailed 重试多次仍然失败，不再执行
+	ArticleScheduleStatusFailed
+)
+
+func (a ArticleScheduleStatus) ToUint8() uint8 {
+	return uint8(a)
+}
+
+// ArticleSchedule 文章的定时发表/撤回任务
+type ArticleSchedule struct {
+	Id        int64
+	ArticleId int64
+	Author    Author
+	Action    ArticleScheduleAction
+	Status    ArticleScheduleStatus
+	ExecuteAt time.Time
+	// Retries 执行失败后重试过的次数
+	Retries int
+	Ctime   time.Time
+	Utime   time.Time
+}
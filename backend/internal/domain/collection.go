@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// Collection 用户的收藏夹
+type Collection struct {
+	Id   int64
+	Uid  int64
+	Name string
+
+	Ctime time.Time
+	Utime time.Time
+}
+
+// CollectionItem 收藏夹里的一条收藏，Cid 为 0 表示没有放进任何收藏夹
+type CollectionItem struct {
+	Cid   int64
+	Biz   string
+	BizId int64
+	// Article 收藏的是文章时填充标题、摘要等信息
+	Article Article
+	Ctime   time.Time
+}
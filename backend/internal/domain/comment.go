@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// Comment 评论，RootId 为 0 的是直接评论文章的根评论，其余都是回复
+type Comment struct {
+	Id    int64
+	Biz   string
+	BizId int64
+	// Commentator 评论的人，只有 Id 和 Name
+	Commentator Author
+	Content     string
+	// RootId 所在的根评论
+	RootId int64
+	// ParentId 回复的那条评论，根评论为 0
+	ParentId int64
+	// Children 根评论下内联返回的前几条回复
+	Children []Comment
+	Ctime    time.Time
+	Utime    time.Time
+}
+
+func (c Comment) IsRoot() bool {
+	return c.RootId == 0
+}
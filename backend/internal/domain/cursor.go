@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("非法的分页游标")
+
+// ArticleCursor 按 (utime, id) 倒序分页的游标，指向上一页的最后一篇文章
+// 零值表示从第一页开始
+type ArticleCursor struct {
+	Utime time.Time
+	Id    int64
+}
+
+func NewArticleCursor(art Article) ArticleCursor {
+	return ArticleCursor{
+		Utime: art.Utime,
+		Id:    art.Id,
+	}
+}
+
+func (c ArticleCursor) IsZero() bool {
+	return c.Id == 0
+}
+
+// Before art 排在游标之后，也就是属于下一页
+func (c ArticleCursor) Before(art Article) bool {
+	if c.IsZero() {
+		return true
+	}
+	return NewArticleCursor(art).Less(c)
+}
+
+// Less c 按 (utime, id) 倒序排在 o 之后
+func (c ArticleCursor) Less(o ArticleCursor) bool {
+	ct, ot := c.Utime.UnixMilli(), o.Utime.UnixMilli()
+	return ct < ot || (ct == ot && c.Id < o.Id)
+}
+
+// Encode 编码成对前端不透明的字符串，零值编码为空字符串
+func (c ArticleCursor) Encode() string {
+	if c.IsZero() {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(
+		[]byte(fmt.Sprintf("%d_%d", c.Utime.UnixMilli(), c.Id)))
+}
+
+// ParseArticleCursor 解析 Encode 的结果，空字符串得到零值
+func ParseArticleCursor(s string) (ArticleCursor, error) {
+	if s == "" {
+		return ArticleCursor{}, nil
+	}
+	bs, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return ArticleCursor{}, ErrInvalidCursor
+	}
+	var utime, id int64
+	_, err = fmt.Sscanf(string(bs), "%d_%d", &utime, &id)
+	if err != nil || id <= 0 {
+		return ArticleCursor{}, ErrInvalidCursor
+	}
+	return ArticleCursor{
+		Utime: time.UnixMilli(utime),
+		Id:    id,
+	}, nil
+}
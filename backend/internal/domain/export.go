@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+type ArticleExportStatus uint8
+
+const (
+	ArticleExportStatusUnknown ArticleExportStatus = iota
+	ArticleExportStatusPending
+	ArticleExportStatusRunning
+	ArticleExportStatusDone
+	ArticleExportStatusFailed
+)
+
+func (s ArticleExportStatus) ToUint8() uint8 {
+	return uint8(s)
+}
+
+// ArticleExport 把作者的全部文章导出为 ZIP 的异步任务
+type ArticleExport struct {
+	Id     int64
+	Uid    int64
+	Status ArticleExportStatus
+	// Total 需要导出的文章数，开始执行后才知道
+	Total int
+	Done  int
+	// Key 导出完成后归档文件在对象存储中的 key
+	Key   string
+	Ctime time.Time
+	Utime time.Time
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// FollowRelation Follower 关注了 Followee
+type FollowRelation struct {
+	Follower int64
+	Followee int64
+	Ctime    time.Time
+}
+
+// FollowStatics 用户的关注数和粉丝数
+type FollowStatics struct {
+	Uid int64
+	// Followers 粉丝数
+	Followers int64
+	// Followees 关注了多少人
+	Followees int64
+}
+
+// FeedItem 关注流中的一条，Ctime 是进入关注流的时间，也是排序和翻页的依据
+type FeedItem struct {
+	Article Article
+	Ctime   time.Time
+}
+
+// Cursor 关注流按 (Ctime, 文章 id) 倒序分页
+func (f FeedItem) Cursor() ArticleCursor {
+	return ArticleCursor{
+		Utime: f.Ctime,
+		Id:    f.Article.Id,
+	}
+}
@@ -0,0 +1,34 @@
+package domain
+
+// ImportFile 上传的文件，可以是 Markdown 或者包含 Markdown 的 ZIP
+type ImportFile struct {
+	Name string
+	Data []byte
+}
+
+type ArticleImportStatus uint8
+
+const (
+	ArticleImportStatusUnknown ArticleImportStatus = iota
+	// ArticleImportStatusDraft 导入为草稿
+	ArticleImportStatusDraft
+	ArticleImportStatusPublished
+	// ArticleImportStatusPendingReview 发表时命中敏感词，等待审核
+	ArticleImportStatusPendingReview
+	// ArticleImportStatusDuplicate 之前已经导入过相同的文件，没有重复创建
+	ArticleImportStatusDuplicate
+	ArticleImportStatusFailed
+)
+
+func (s ArticleImportStatus) ToUint8() uint8 {
+	return uint8(s)
+}
+
+// ArticleImportResult 单个 Markdown 文件的导入结果，ZIP 里的文件名带上 ZIP 的名字
+type ArticleImportResult struct {
+	Name      string
+	ArticleId int64
+	Status    ArticleImportStatus
+	// Reason 失败的原因，可以直接展示给用户
+	Reason string
+}
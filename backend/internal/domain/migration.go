@@ -0,0 +1,44 @@
+package domain
+
+// ArticleMigrationStage 文章存储在线迁移的阶段，只能逐级前进或回退
+type ArticleMigrationStage uint8
+
+const (
+	// ArticleMigrationStageSrcOnly 只读写源存储
+	ArticleMigrationStageSrcOnly ArticleMigrationStage = iota
+	// ArticleMigrationStageSrcFirst 双写，以源存储为准
+	ArticleMigrationStageSrcFirst
+	// ArticleMigrationStageDstFirst 双写，以目标存储为准
+	ArticleMigrationStageDstFirst
+	// ArticleMigrationStageDstOnly 只读写目标存储
+	ArticleMigrationStageDstOnly
+)
+
+var articleMigrationStageNames = []string{"src_only", "src_first", "dst_first", "dst_only"}
+
+func (s ArticleMigrationStage) String() string {
+	if int(s) < len(articleMigrationStageNames) {
+		return articleMigrationStageNames[s]
+	}
+	return "unknown"
+}
+
+// ParseArticleMigrationStage 名字不合法时 ok 为 false
+func ParseArticleMigrationStage(name string) (ArticleMigrationStage, bool) {
+	for i, n := range articleMigrationStageNames {
+		if n == name {
+			return ArticleMigrationStage(i), true
+		}
+	}
+	return 0, false
+}
+
+// DoubleWrite 是否同时写两边
+func (s ArticleMigrationStage) DoubleWrite() bool {
+	return s == ArticleMigrationStageSrcFirst || s == ArticleMigrationStageDstFirst
+}
+
+// SrcPrimary 是否以源存储为准
+func (s ArticleMigrationStage) SrcPrimary() bool {
+	return s <= ArticleMigrationStageSrcFirst
+}
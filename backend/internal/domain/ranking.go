@@ -0,0 +1,9 @@
+package domain
+
+// HotArticle 热榜上的一篇文章
+type HotArticle struct {
+	// Article 只有 Id、Title、Author、Ctime、Utime，不带内容
+	Article Article
+	Intr    Interactive
+	Score   float64
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// ReadingFinishedProgress 阅读进度达到这个百分比就算读完了
+const ReadingFinishedProgress = 95
+
+// ReadingHistory 用户读过的一篇文章，每人每篇只有一条
+type ReadingHistory struct {
+	Uid int64
+	Aid int64
+	// Progress 阅读进度的百分比，0 表示没有上报过
+	Progress int
+	// Article 填充标题、摘要等信息，已经下线的文章只有 id
+	Article Article
+	// Ctime 第一次阅读的时间
+	Ctime time.Time
+	// Utime 最近一次阅读的时间
+	Utime time.Time
+}
+
+func (h ReadingHistory) Finished() bool {
+	return h.Progress >= ReadingFinishedProgress
+}
@@ -0,0 +1,16 @@
+package domain
+
+// ArticleSearchHit 一条搜索结果，高亮内容中命中的词用 <em></em> 包裹，其余部分已做 HTML 转义
+type ArticleSearchHit struct {
+	// Article 不包含正文
+	Article          Article
+	Score            float64
+	TitleHighlight   string
+	ContentHighlight string
+}
+
+type ArticleSearchResult struct {
+	// Total 命中的文章总数，用于分页
+	Total int
+	Hits  []ArticleSearchHit
+}
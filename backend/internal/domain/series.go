@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// Series 作者把多篇文章按顺序组织成的专栏
+type Series struct {
+	Id          int64
+	Author      Author
+	Title       string
+	Description string
+	// Articles 按专栏里的顺序排列
+	Articles []Article
+	Ctime    time.Time
+	Utime    time.Time
+}
+
+// SeriesNav 文章在专栏中的位置以及前后篇，Prev、Next 的 Id 为 0 表示没有
+type SeriesNav struct {
+	Series Series
+	// Position 从 1 开始
+	Position int
+	Total    int
+	Prev     Article
+	Next     Article
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "strconv"
+
+// Site 站点信息，用于生成对外的绝对地址
+type Site struct {
+	// URL 前端地址，不带末尾的 /
+	URL         string
+	Title       string
+	Description string
+}
+
+func (s Site) ArticleURL(id int64) string {
+	return s.URL + "/articles/" + strconv.FormatInt(id, 10)
+}
+
+func (s Site) AuthorURL(uid int64) string {
+	return s.URL + "/authors/" + strconv.FormatInt(uid, 10)
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+type SyndicationFormat uint8
+
+const (
+	SyndicationFormatRSS SyndicationFormat = iota
+	SyndicationFormatAtom
+)
+
+func (f SyndicationFormat) ContentType() string {
+	if f == SyndicationFormatAtom {
+		return "application/atom+xml; charset=utf-8"
+	}
+	return "application/rss+xml; charset=utf-8"
+}
+
+// SyndicationFeed 生成好的订阅源
+type SyndicationFeed struct {
+	Format SyndicationFormat
+	Body   []byte
+	ETag   string
+	// LastModified 订阅源中最晚的文章更新时间
+	LastModified time.Time
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// Upload 作者上传的图片，相同内容只保存一份
+type Upload struct {
+	Id  int64
+	Uid int64
+	// Hash 原始文件的 sha256，同时作为访问地址的一部分，保证地址稳定
+	Hash        string
+	ContentType string
+	// Size 去掉元数据后原图的大小
+	Size   int64
+	Width  int
+	Height int
+	Ctime  time.Time
+}
+
+// UploadVariant 同一张图片不同尺寸的版本
+type UploadVariant string
+
+const (
+	// UploadVariantOriginal 原尺寸，已去掉 EXIF 等元数据
+	UploadVariantOriginal UploadVariant = "original"
+	// UploadVariantWeb 正文中展示用
+	UploadVariantWeb UploadVariant = "web"
+	// UploadVariantThumb 列表、封面等缩略图
+	UploadVariantThumb UploadVariant = "thumb"
+)
+
+func (v UploadVariant) Valid() bool {
+	switch v {
+	case UploadVariantOriginal, UploadVariantWeb, UploadVariantThumb:
+		return true
+	default:
+		return false
+	}
+}
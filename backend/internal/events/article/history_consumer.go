@@ -0,0 +1,70 @@
+package article
+
+import (
+	"context"
+	"github.com/IBM/sarama"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/service"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/johnwongx/webook/backend/pkg/saramax"
+	"time"
+)
+
+// HistoryConsumer 把阅读事件记到用户的阅读记录里
+type HistoryConsumer struct {
+	client sarama.Client
+	svc    service.ReadingHistoryService
+	l      logger.Logger
+}
+
+func NewHistoryConsumer(client sarama.Client, svc service.ReadingHistoryService, l logger.Logger) *HistoryConsumer {
+	return &HistoryConsumer{
+		client: client,
+		svc:    svc,
+		l:      l,
+	}
+}
+
+func (h *HistoryConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("reading_history", h.client)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		err := cg.Consume(context.Background(),
+			[]string{ReadEventTopic},
+			saramax.NewBatchConsumerHandler[ReadEvent](h.Consume, h.l))
+		if err != nil {
+			h.l.Error("消费循环退出异常", logger.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (h *HistoryConsumer) Consume(msg []*sarama.ConsumerMessage, evt []ReadEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	now := time.Now()
+	hs := make([]domain.ReadingHistory, 0, len(evt))
+	for _, e := range evt {
+		if e.Biz != "article" {
+			continue
+		}
+		t := now
+		if e.Time > 0 {
+			t = time.UnixMilli(e.Time)
+		}
+		hs = append(hs, domain.ReadingHistory{
+			Uid:   e.Uid,
+			Aid:   e.Aid,
+			Ctime: t,
+			Utime: t,
+		})
+	}
+	err := h.svc.Record(ctx, hs)
+	if err != nil {
+		h.l.Error("批量记录阅读记录失败", logger.Error(err))
+	}
+	return nil
+}
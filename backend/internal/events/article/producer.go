@@ -38,4 +38,6 @@ type ReadEvent struct {
 	Uid int64
 	Aid int64
 	Biz string
+	// Time 阅读的时间，毫秒数。旧消息里没有
+	Time int64
 }
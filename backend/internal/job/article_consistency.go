@@ -0,0 +1,62 @@
+package job
+
+import (
+	"context"
+	"github.com/johnwongx/webook/backend/internal/service"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/johnwongx/webook/backend/pkg/redislock"
+	"time"
+)
+
+// ArticleConsistencyJob 定期检查线上库和制作库是否一致，同一时间只有一个实例在检查
+type ArticleConsistencyJob struct {
+	svc service.ArticleConsistencyService
+	// repair 是否自动以制作库为准修复
+	repair bool
+	client *redislock.Client
+	key    string
+	l      logger.Logger
+}
+
+func NewArticleConsistencyJob(svc service.ArticleConsistencyService, repair bool,
+	client *redislock.Client, l logger.Logger) *ArticleConsistencyJob {
+	return &ArticleConsistencyJob{
+		svc:    svc,
+		repair: repair,
+		client: client,
+		key:    "job:article_consistency",
+		l:      l,
+	}
+}
+
+func (a *ArticleConsistencyJob) Name() string {
+	return "article_consistency"
+}
+
+func (a *ArticleConsistencyJob) Run(ctx context.Context) error {
+	expiration := time.Minute
+	if ddl, ok := ctx.Deadline(); ok {
+		expiration = time.Until(ddl)
+	}
+	lock, err := a.client.TryLock(ctx, a.key, expiration)
+	if err == redislock.ErrLockHeld {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		uctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		er := lock.Unlock(uctx)
+		if er != nil {
+			a.l.Error("释放一致性检查任务锁失败", logger.Error(er))
+		}
+	}()
+	_, err = a.svc.Check(ctx, a.repair)
+	if err == service.ErrArticleConsistencyRunning {
+		// 管理员手动触发的检查还在运行
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,25 @@
+package job
+
+import (
+	"context"
+	"github.com/johnwongx/webook/backend/internal/service"
+)
+
+// ArticleExportJob 执行作者提交的导出任务
+type ArticleExportJob struct {
+	svc service.ExportService
+}
+
+func NewArticleExportJob(svc service.ExportService) *ArticleExportJob {
+	return &ArticleExportJob{
+		svc: svc,
+	}
+}
+
+func (a *ArticleExportJob) Name() string {
+	return "article_export"
+}
+
+func (a *ArticleExportJob) Run(ctx context.Context) error {
+	return a.svc.RunPending(ctx)
+}
@@ -0,0 +1,73 @@
+package job
+
+import (
+	"context"
+	"github.com/johnwongx/webook/backend/internal/service"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/johnwongx/webook/backend/pkg/redislock"
+	"time"
+)
+
+// ArticleMigrationStageJob 每个实例都要运行，同步管理员切换的迁移阶段
+type ArticleMigrationStageJob struct {
+	svc service.ArticleMigrationService
+}
+
+func NewArticleMigrationStageJob(svc service.ArticleMigrationService) *ArticleMigrationStageJob {
+	return &ArticleMigrationStageJob{
+		svc: svc,
+	}
+}
+
+func (a *ArticleMigrationStageJob) Name() string {
+	return "article_migration_stage"
+}
+
+func (a *ArticleMigrationStageJob) Run(ctx context.Context) error {
+	return a.svc.SyncStage(ctx)
+}
+
+// ArticleMigrationVerifyJob 双写期间增量校验两边的数据，同一时间只有一个实例在校验
+type ArticleMigrationVerifyJob struct {
+	svc    service.ArticleMigrationService
+	client *redislock.Client
+	key    string
+	l      logger.Logger
+}
+
+func NewArticleMigrationVerifyJob(svc service.ArticleMigrationService, client *redislock.Client,
+	l logger.Logger) *ArticleMigrationVerifyJob {
+	return &ArticleMigrationVerifyJob{
+		svc:    svc,
+		client: client,
+		key:    "job:article_migration_verify",
+		l:      l,
+	}
+}
+
+func (a *ArticleMigrationVerifyJob) Name() string {
+	return "article_migration_verify"
+}
+
+func (a *ArticleMigrationVerifyJob) Run(ctx context.Context) error {
+	expiration := time.Minute
+	if ddl, ok := ctx.Deadline(); ok {
+		expiration = time.Until(ddl)
+	}
+	lock, err := a.client.TryLock(ctx, a.key, expiration)
+	if err == redislock.ErrLockHeld {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		uctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		er := lock.Unlock(uctx)
+		if er != nil {
+			a.l.Error("释放文章迁移校验任务锁失败", logger.Error(er))
+		}
+	}()
+	return a.svc.VerifyIncremental(ctx)
+}
@@ -0,0 +1,25 @@
+package job
+
+import (
+	"context"
+	"github.com/johnwongx/webook/backend/internal/service"
+)
+
+// ArticlePurgeJob 彻底删除回收站里超过保留时间的文章
+type ArticlePurgeJob struct {
+	svc service.ArticleService
+}
+
+func NewArticlePurgeJob(svc service.ArticleService) *ArticlePurgeJob {
+	return &ArticlePurgeJob{
+		svc: svc,
+	}
+}
+
+func (a *ArticlePurgeJob) Name() string {
+	return "article_purge"
+}
+
+func (a *ArticlePurgeJob) Run(ctx context.Context) error {
+	return a.svc.PurgeExpired(ctx)
+}
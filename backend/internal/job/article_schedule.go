@@ -0,0 +1,25 @@
+package job
+
+import (
+	"context"
+	"github.com/johnwongx/webook/backend/internal/service"
+)
+
+// ArticleScheduleJob 执行到期的定时发表、定时撤回
+type ArticleScheduleJob struct {
+	svc service.ArticleService
+}
+
+func NewArticleScheduleJob(svc service.ArticleService) *ArticleScheduleJob {
+	return &ArticleScheduleJob{
+		svc: svc,
+	}
+}
+
+func (a *ArticleScheduleJob) Name() string {
+	return "article_schedule"
+}
+
+func (a *ArticleScheduleJob) Run(ctx context.Context) error {
+	return a.svc.ExecuteDueSchedules(ctx)
+}
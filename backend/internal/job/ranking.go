@@ -0,0 +1,66 @@
+package job
+
+import (
+	"context"
+	"github.com/johnwongx/webook/backend/internal/service"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/johnwongx/webook/backend/pkg/redislock"
+	"time"
+)
+
+// RankingJob 周期计算热榜，多实例部署时靠分布式锁保证同一时间只有一个实例在算
+type RankingJob struct {
+	svc    service.RankingService
+	client *redislock.Client
+	key    string
+	// expiration 锁的过期时间，计算期间每隔三分之一续约一次
+	expiration time.Duration
+	l          logger.Logger
+}
+
+func NewRankingJob(svc service.RankingService, client *redislock.Client, l logger.Logger) *RankingJob {
+	return &RankingJob{
+		svc:        svc,
+		client:     client,
+		key:        "job:ranking",
+		expiration: time.Second * 30,
+		l:          l,
+	}
+}
+
+func (r *RankingJob) Name() string {
+	return "ranking"
+}
+
+func (r *RankingJob) Run(ctx context.Context) error {
+	// 锁的过期时间很短，计算期间不断续约，实例崩溃时锁很快就能释放
+	lock, err := r.client.TryLock(ctx, r.key, r.expiration)
+	if err == redislock.ErrLockHeld {
+		// 其他实例正在计算
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// ctx 可能已经超时，释放锁使用新的 context
+		uctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		er := lock.Unlock(uctx)
+		if er != nil {
+			r.l.Error("释放热榜任务锁失败", logger.Error(er))
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		er := lock.AutoRefresh(ctx, r.expiration/3)
+		if er != nil {
+			// 锁已经不是自己的，其他实例可能也在计算，放弃这一轮
+			r.l.Error("热榜任务锁续约失败", logger.Error(er))
+			cancel()
+		}
+	}()
+	return r.svc.TopN(ctx)
+}
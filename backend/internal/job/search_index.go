@@ -0,0 +1,26 @@
+package job
+
+import (
+	"context"
+	"github.com/johnwongx/webook/backend/internal/service"
+)
+
+// SearchIndexJob 定期重建进程内的搜索索引。
+// 发表、撤回只会更新处理该请求的实例的索引，多实例部署时靠它让其他实例追上
+type SearchIndexJob struct {
+	svc service.SearchService
+}
+
+func NewSearchIndexJob(svc service.SearchService) *SearchIndexJob {
+	return &SearchIndexJob{
+		svc: svc,
+	}
+}
+
+func (s *SearchIndexJob) Name() string {
+	return "article_search_index"
+}
+
+func (s *SearchIndexJob) Run(ctx context.Context) error {
+	return s.svc.RebuildArticleIndex(ctx)
+}
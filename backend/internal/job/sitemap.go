@@ -0,0 +1,25 @@
+package job
+
+import (
+	"context"
+	"github.com/johnwongx/webook/backend/internal/service"
+)
+
+// SitemapJob 有文章发表或下线时重新生成 sitemap，长时间没有变化时也定期生成
+type SitemapJob struct {
+	svc service.SitemapService
+}
+
+func NewSitemapJob(svc service.SitemapService) *SitemapJob {
+	return &SitemapJob{
+		svc: svc,
+	}
+}
+
+func (s *SitemapJob) Name() string {
+	return "sitemap"
+}
+
+func (s *SitemapJob) Run(ctx context.Context) error {
+	return s.svc.Refresh(ctx)
+}
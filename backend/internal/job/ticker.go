@@ -0,0 +1,73 @@
+package job
+
+import (
+	"context"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"time"
+)
+
+// TickerScheduler 按固定间隔驱动一个 Job 运行
+type TickerScheduler struct {
+	job      Job
+	interval time.Duration
+	timeout  time.Duration
+	// runOnStart 启动时先运行一次，不等第一个间隔
+	runOnStart bool
+	l          logger.Logger
+	stop       chan struct{}
+}
+
+func NewTickerScheduler(j Job, interval time.Duration, l logger.Logger) *TickerScheduler {
+	return &TickerScheduler{
+		job:      j,
+		interval: interval,
+		timeout:  interval,
+		l:        l,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Timeout 单次运行的超时时间，默认和间隔相同
+func (t *TickerScheduler) Timeout(timeout time.Duration) *TickerScheduler {
+	t.timeout = timeout
+	return t
+}
+
+// RunOnStart 启动时立即运行一次
+func (t *TickerScheduler) RunOnStart() *TickerScheduler {
+	t.runOnStart = true
+	return t
+}
+
+func (t *TickerScheduler) Start() error {
+	go func() {
+		if t.runOnStart {
+			t.runOnce()
+		}
+		ticker := time.NewTicker(t.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-t.stop:
+				return
+			case <-ticker.C:
+				t.runOnce()
+			}
+		}
+	}()
+	return nil
+}
+
+func (t *TickerScheduler) Stop() {
+	close(t.stop)
+}
+
+func (t *TickerScheduler) runOnce() {
+	ctx, cancel := context.WithTimeout(context.Background(), t.timeout)
+	defer cancel()
+	err := t.job.Run(ctx)
+	if err != nil {
+		t.l.Error("运行任务失败",
+			logger.String("job", t.job.Name()), logger.Error(err))
+	}
+}
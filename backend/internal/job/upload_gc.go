@@ -0,0 +1,25 @@
+package job
+
+import (
+	"context"
+	"github.com/johnwongx/webook/backend/internal/service"
+)
+
+// UploadGCJob 回收没有被任何文章引用的图片
+type UploadGCJob struct {
+	svc service.UploadService
+}
+
+func NewUploadGCJob(svc service.UploadService) *UploadGCJob {
+	return &UploadGCJob{
+		svc: svc,
+	}
+}
+
+func (u *UploadGCJob) Name() string {
+	return "upload_gc"
+}
+
+func (u *UploadGCJob) Run(ctx context.Context) error {
+	return u.svc.CollectGarbage(ctx)
+}
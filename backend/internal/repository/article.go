@@ -2,38 +2,103 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/johnwongx/webook/backend/internal/domain"
 	"github.com/johnwongx/webook/backend/internal/repository/cache"
 	"github.com/johnwongx/webook/backend/internal/repository/dao/article"
+	"github.com/johnwongx/webook/backend/internal/repository/search"
 	"github.com/johnwongx/webook/backend/pkg/logger"
 	"time"
 )
 
+var (
+	ErrArticleVersionConflict = article.ErrVersionConflict
+	ErrArticleNotFound        = article.ErrArticleNotFound
+)
+
 type ArticleRepository interface {
 	Create(ctx context.Context, art domain.Article) (int64, error)
 	Update(ctx context.Context, art domain.Article) error
 	Sync(ctx context.Context, art domain.Article) (int64, error)
 	SyncStatus(ctx context.Context, id, usrId int64, status domain.ArticleStatus) error
 
-	List(ctx context.Context, id int64, offset, limit int) ([]domain.Article, error)
+	// List 作者的文章，按 (utime, id) 倒序返回游标之后的 limit 篇
+	List(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
 	GetById(ctx context.Context, id, uid int64) (domain.Article, error)
 	GetPubById(ctx context.Context, id int64) (domain.Article, error)
+
+	// Delete 移入回收站
+	Delete(ctx context.Context, id, uid int64) error
+	// Restore 恢复 since 之后删除的文章，恢复后是未发表的草稿
+	Restore(ctx context.Context, id, uid int64, since time.Time) error
+	// ListDeleted 回收站里 since 之后删除的文章，按删除时间倒序
+	ListDeleted(ctx context.Context, uid int64, since time.Time, offset, limit int) ([]domain.Article, error)
+	// ListExpired before 之前删除的文章，只有 id 和作者
+	ListExpired(ctx context.Context, before time.Time, limit int) ([]domain.Article, error)
+	// Purge 彻底删除文章
+	Purge(ctx context.Context, ids []int64) error
+
+	// ListByStatus 所有作者处于 status 的草稿，先提交的排在前面
+	ListByStatus(ctx context.Context, status domain.ArticleStatus, offset, limit int) ([]domain.Article, error)
+	// GetByStatus 不限作者，草稿不处于 status 时返回 ErrArticleNotFound
+	GetByStatus(ctx context.Context, id int64, status domain.ArticleStatus) (domain.Article, error)
+	// TransitStatus 草稿从 from 改为 to，只修改制作库，不处于 from 时返回 ErrArticleNotFound
+	TransitStatus(ctx context.Context, id, uid int64, from, to domain.ArticleStatus) error
+
+	ListRevisions(ctx context.Context, id, uid int64, offset, limit int) ([]domain.ArticleRevision, error)
+	GetRevision(ctx context.Context, id, rid, uid int64) (domain.ArticleRevision, error)
+
+	// ListPub 按 id 升序遍历线上库，返回 id 大于 startId 的 limit 篇，不区分状态
+	ListPub(ctx context.Context, startId int64, limit int) ([]domain.Article, error)
+	// ListPubByAuthors 多个作者已发表的文章，按 (utime, id) 倒序返回游标之后的 limit 篇
+	ListPubByAuthors(ctx context.Context, uids []int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
+	// ListPubByAuthor 作者已发表的文章，按 (utime, id) 倒序返回游标之后的 limit 篇
+	ListPubByAuthor(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
+	// ListPubByTag 标签下已发表的文章，按更新时间倒序
+	ListPubByTag(ctx context.Context, tag string, offset, limit int) ([]domain.Article, error)
+	// ListLatestPub 全站最近更新的已发表文章，按更新时间倒序
+	ListLatestPub(ctx context.Context, limit int) ([]domain.Article, error)
+	// ScanPub 按 id 升序遍历已发表的文章，只有 id 和更新时间
+	ScanPub(ctx context.Context, startId int64, limit int) ([]domain.Article, error)
+	TagCounts(ctx context.Context) ([]domain.TagCount, error)
+
+	// ScanPairs 按 id 升序成对遍历制作库和线上库中 id 大于 startId 的文章，包括回收站中的
+	ScanPairs(ctx context.Context, startId int64, limit int) ([]domain.ArticlePair, error)
+	// RepairPub 以制作库为准修复线上库，rendered 为制作库内容的渲染结果。
+	// 检查之后作者又修改过草稿时返回 ErrArticleVersionConflict
+	RepairPub(ctx context.Context, pair domain.ArticlePair, rendered domain.RenderedContent) error
 }
 
+// articleRepository 写入都走作者侧，作者侧包括制作库和线上库，是文章数据的权威来源；
+// 线上库变化后投影到读者侧，读者看文章详情只查读者侧
 type articleRepository struct {
-	artDao   article.ArticleDAO
-	userRepo UserRepository
-	cache    cache.ArticleCache
-	log      logger.Logger
+	artDao      article.ArticleDAO
+	reader      article.ReaderArticleDAO
+	userRepo    UserRepository
+	cache       cache.ArticleCache
+	tagCache    cache.ArticleTagCache
+	authorCache cache.ArticleAuthorCache
+	synCache    cache.SyndicationCache
+	siteCache   cache.SitemapCache
+	index       search.ArticleIndex
+	log         logger.Logger
 }
 
-func NewArticleRepository(d article.ArticleDAO, uRepo UserRepository, c cache.ArticleCache, l logger.Logger) ArticleRepository {
+func NewArticleRepository(d article.ArticleDAO, reader article.ReaderArticleDAO, uRepo UserRepository,
+	c cache.ArticleCache, tc cache.ArticleTagCache, ac cache.ArticleAuthorCache, sc cache.SyndicationCache,
+	smc cache.SitemapCache, index search.ArticleIndex, l logger.Logger) ArticleRepository {
 	return &articleRepository{
-		artDao:   d,
-		userRepo: uRepo,
-		cache:    c,
-		log:      l,
+		artDao:      d,
+		reader:      reader,
+		userRepo:    uRepo,
+		cache:       c,
+		tagCache:    tc,
+		authorCache: ac,
+		synCache:    sc,
+		siteCache:   smc,
+		index:       index,
+		log:         l,
 	}
 }
 
@@ -43,6 +108,7 @@ func (a *articleRepository) Create(ctx context.Context, art domain.Article) (int
 		return 0, err
 	}
 	art.Id = id
+	art.Version = 1
 	uid := art.Author.Id
 	err = a.cache.DeleteFirstPage(ctx, uid)
 	if err != nil && err != cache.ErrKeyNotExisted {
@@ -61,20 +127,41 @@ func (a *articleRepository) Update(ctx context.Context, art domain.Article) erro
 		return err
 	}
 	a.clearCache(ctx, art.Id, art.Author.Id)
-	go func() {
-		a.saveArticleCache(ctx, art)
-	}()
+	// 不知道更新后的版本号，就不预先缓存，避免缓存里的版本号过期
+	if art.Version > 0 {
+		art.Version++
+		go func() {
+			a.saveArticleCache(ctx, art)
+		}()
+	}
 	return nil
 }
 
 func (a *articleRepository) Sync(ctx context.Context, art domain.Article) (int64, error) {
+	// 文章可能从旧标签中移除，旧标签的缓存也要清理
+	var oldTags []string
+	if art.Id > 0 {
+		oldTags = a.pubTags(ctx, art.Id)
+	}
 	id, err := a.artDao.Sync(ctx, a.toEntity(art))
 	if err != nil {
 		return 0, err
 	}
+	a.project(ctx, id)
 	a.clearCache(ctx, id, art.Author.Id)
+	a.clearTagCache(ctx, append(oldTags, a.pubTags(ctx, id)...))
+	a.pubChanged(ctx, art.Author.Id)
+	cacheDraft := art.Id == 0 || art.Version > 0
+	if art.Id == 0 {
+		art.Id = id
+		art.Version = 1
+	} else if art.Version > 0 {
+		art.Version++
+	}
 	go func() {
-		a.saveArticleCache(ctx, art)
+		if cacheDraft {
+			a.saveArticleCache(ctx, art)
+		}
 
 		user, err := a.userRepo.FindById(ctx, art.Author.Id)
 		if err != nil {
@@ -83,6 +170,13 @@ func (a *articleRepository) Sync(ctx context.Context, art domain.Article) (int64
 		}
 		art.Author.Name = user.NickName
 		a.savePublishedArticleCache(ctx, art)
+
+		art.Utime = time.Now()
+		err = a.index.Upsert(ctx, toSearchDoc(art))
+		if err != nil {
+			a.log.Error("更新搜索索引失败",
+				logger.Int64("id", art.Id), logger.Error(err))
+		}
 	}()
 	return id, err
 }
@@ -92,26 +186,54 @@ func (a *articleRepository) SyncStatus(ctx context.Context, id, usrId int64, sta
 	if err != nil {
 		return err
 	}
+	a.project(ctx, id)
 	a.clearCache(ctx, id, usrId)
+	a.clearTagCache(ctx, a.pubTags(ctx, id))
+	a.pubChanged(ctx, usrId)
+	a.syncIndex(ctx, id, status)
 	return err
 }
 
-func (a *articleRepository) List(ctx context.Context, id int64, offset, limit int) ([]domain.Article, error) {
-	if offset+limit <= 100 {
-		arts, err := a.cache.GetFirstPage(ctx, id)
+// syncIndex 只有已发表的文章可以被搜索到
+func (a *articleRepository) syncIndex(ctx context.Context, id int64, status domain.ArticleStatus) {
+	var err error
+	if status == domain.ArticleStatusPublished {
+		var art domain.Article
+		art, err = a.GetPubById(ctx, id)
+		if err == nil {
+			err = a.index.Upsert(ctx, toSearchDoc(art))
+		}
+	} else {
+		err = a.index.Delete(ctx, id)
+	}
+	if err != nil {
+		a.log.Error("更新搜索索引失败",
+			logger.Int64("id", id), logger.Error(err))
+	}
+}
+
+func (a *articleRepository) List(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	if limit <= cache.ArticleFirstPageSize {
+		arts, err := a.cache.GetPage(ctx, uid, cursor, limit)
 		if err == nil {
 			go func() {
 				a.preCache(ctx, arts)
 			}()
-			return arts[offset:limit], nil
+			return arts, nil
 		}
 		if err != cache.ErrKeyNotExisted {
-			a.log.Error("Get author article cache form cache failed", logger.Error(err))
+			a.log.Error("获取作者文章列表缓存失败",
+				logger.Int64("author", uid), logger.Error(err))
 		}
 	}
 
-	// 慢路径
-	res, err := a.artDao.GetByAuthor(ctx, id, offset, limit)
+	// 慢路径，第一页时多查一些，用来刷新列表缓存
+	fill := cursor.IsZero() && limit <= cache.ArticleFirstPageSize
+	qLimit := limit
+	if fill {
+		qLimit = cache.ArticleFirstPageSize
+	}
+	res, err := a.artDao.GetByAuthor(ctx, uid, cursor.Utime.UnixMilli(), cursor.Id, qLimit)
 	if err != nil {
 		return []domain.Article{}, err
 	}
@@ -119,15 +241,22 @@ func (a *articleRepository) List(ctx context.Context, id int64, offset, limit in
 	data := slice.Map[article.Article, domain.Article](res, func(idx int, src article.Article) domain.Article {
 		return a.toDomain(src)
 	})
-	go func() {
-		if offset == 0 && limit >= 100 {
-			err = a.cache.SetFirstPage(ctx, id, data[:100])
+	if fill {
+		// 缓存只保留摘要，不能修改返回给调用方的数据
+		cp := make([]domain.Article, len(data))
+		copy(cp, data)
+		go func() {
+			err := a.cache.SetFirstPage(ctx, uid, cp)
 			if err != nil {
-				a.log.Error("refresh first page article failed",
-					logger.Int64("author", id), logger.Error(err))
+				a.log.Error("刷新作者文章列表缓存失败",
+					logger.Int64("author", uid), logger.Error(err))
 			}
+		}()
+		if len(data) > limit {
+			data = data[:limit]
 		}
-
+	}
+	go func() {
 		a.preCache(ctx, data)
 	}()
 	return data, nil
@@ -158,18 +287,18 @@ func (a *articleRepository) GetPubById(ctx context.Context, id int64) (domain.Ar
 		return art, nil
 	}
 
-	// 获取文章数据
-	pArt, err := a.artDao.FindPubById(ctx, id)
+	// 只查读者侧，作者侧的数据由投影同步过来
+	rArt, err := a.reader.FindById(ctx, id)
 	if err != nil {
 		return domain.Article{}, err
 	}
 	// 获取作者数据
-	user, err := a.userRepo.FindById(ctx, pArt.AuthorId)
+	user, err := a.userRepo.FindById(ctx, rArt.AuthorId)
 	if err != nil {
 		return domain.Article{}, err
 	}
 
-	art = a.toDomain(article.Article(pArt))
+	art = a.toDomain(article.Article(rArt.Publish()))
 	art.Author.Name = user.NickName
 
 	// 缓存数据
@@ -179,6 +308,305 @@ func (a *articleRepository) GetPubById(ctx context.Context, id int64) (domain.Ar
 	return art, nil
 }
 
+func (a *articleRepository) Delete(ctx context.Context, id, uid int64) error {
+	// 删除后线上库的标签查不到了，先记下来
+	tags := a.pubTags(ctx, id)
+	err := a.artDao.Delete(ctx, id, uid)
+	if err != nil {
+		return err
+	}
+	a.project(ctx, id)
+	a.clearCache(ctx, id, uid)
+	a.clearTagCache(ctx, tags)
+	a.pubChanged(ctx, uid)
+	a.syncIndex(ctx, id, domain.ArticleStatusDeleted)
+	return nil
+}
+
+func (a *articleRepository) Restore(ctx context.Context, id, uid int64, since time.Time) error {
+	err := a.artDao.Restore(ctx, id, uid, since.UnixMilli())
+	if err != nil {
+		return err
+	}
+	a.project(ctx, id)
+	a.clearCache(ctx, id, uid)
+	return nil
+}
+
+func (a *articleRepository) ListDeleted(ctx context.Context, uid int64, since time.Time, offset, limit int) ([]domain.Article, error) {
+	res, err := a.artDao.ListDeleted(ctx, uid, since.UnixMilli(), offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[article.Article, domain.Article](res, func(idx int, src article.Article) domain.Article {
+		return a.toDomain(src)
+	}), nil
+}
+
+func (a *articleRepository) ListExpired(ctx context.Context, before time.Time, limit int) ([]domain.Article, error) {
+	res, err := a.artDao.ListExpired(ctx, before.UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[article.Article, domain.Article](res, func(idx int, src article.Article) domain.Article {
+		return a.toDomain(src)
+	}), nil
+}
+
+func (a *articleRepository) Purge(ctx context.Context, ids []int64) error {
+	err := a.artDao.Purge(ctx, ids)
+	if err != nil {
+		return err
+	}
+	// 删除时已经从读者侧移除，这里只是兜底
+	return a.reader.Delete(ctx, ids)
+}
+
+func (a *articleRepository) ListByStatus(ctx context.Context, status domain.ArticleStatus, offset, limit int) ([]domain.Article, error) {
+	res, err := a.artDao.ListByStatus(ctx, status.ToUint8(), offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[article.Article, domain.Article](res, func(idx int, src article.Article) domain.Article {
+		return a.toDomain(src)
+	}), nil
+}
+
+func (a *articleRepository) GetByStatus(ctx context.Context, id int64, status domain.ArticleStatus) (domain.Article, error) {
+	res, err := a.artDao.FindByStatus(ctx, id, status.ToUint8())
+	if err != nil {
+		return domain.Article{}, err
+	}
+	return a.toDomain(res), nil
+}
+
+func (a *articleRepository) TransitStatus(ctx context.Context, id, uid int64, from, to domain.ArticleStatus) error {
+	err := a.artDao.TransitStatus(ctx, id, from.ToUint8(), to.ToUint8())
+	if err != nil {
+		return err
+	}
+	a.clearCache(ctx, id, uid)
+	return nil
+}
+
+func (a *articleRepository) ListRevisions(ctx context.Context, id, uid int64, offset, limit int) ([]domain.ArticleRevision, error) {
+	revs, err := a.artDao.ListRevisions(ctx, id, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[article.ArticleRevision, domain.ArticleRevision](revs,
+		func(idx int, src article.ArticleRevision) domain.ArticleRevision {
+			return a.revisionToDomain(src)
+		}), nil
+}
+
+func (a *articleRepository) GetRevision(ctx context.Context, id, rid, uid int64) (domain.ArticleRevision, error) {
+	rev, err := a.artDao.FindRevision(ctx, rid, id, uid)
+	if err != nil {
+		return domain.ArticleRevision{}, err
+	}
+	return a.revisionToDomain(rev), nil
+}
+
+func (a *articleRepository) ListPub(ctx context.Context, startId int64, limit int) ([]domain.Article, error) {
+	res, err := a.artDao.ListPub(ctx, startId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[article.PublishArticle, domain.Article](res, func(idx int, src article.PublishArticle) domain.Article {
+		return a.toDomain(article.Article(src))
+	}), nil
+}
+
+func (a *articleRepository) ListPubByAuthors(ctx context.Context, uids []int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	if len(uids) == 0 {
+		return []domain.Article{}, nil
+	}
+	res, err := a.artDao.ListPubByAuthors(ctx, uids, cursor.Utime.UnixMilli(), cursor.Id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[article.PublishArticle, domain.Article](res, func(idx int, src article.PublishArticle) domain.Article {
+		return a.toDomain(article.Article(src))
+	}), nil
+}
+
+func (a *articleRepository) ListPubByAuthor(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	if limit <= cache.AuthorArticleFirstPageSize {
+		arts, err := a.authorCache.GetPage(ctx, uid, cursor, limit)
+		if err == nil {
+			return arts, nil
+		}
+		if err != cache.ErrKeyNotExisted {
+			a.log.Error("获取作者已发表文章列表缓存失败",
+				logger.Int64("author", uid), logger.Error(err))
+		}
+	}
+
+	// 第一页整页查出来，方便缓存
+	fill := cursor.IsZero() && limit <= cache.AuthorArticleFirstPageSize
+	qLimit := limit
+	if fill {
+		qLimit = cache.AuthorArticleFirstPageSize
+	}
+	res, err := a.artDao.ListPubByAuthor(ctx, uid, cursor.Utime.UnixMilli(), cursor.Id, qLimit)
+	if err != nil {
+		return nil, err
+	}
+	data := slice.Map[article.PublishArticle, domain.Article](res, func(idx int, src article.PublishArticle) domain.Article {
+		return a.toDomain(article.Article(src))
+	})
+	if fill {
+		// 缓存只保留摘要，不能修改返回给调用方的数据
+		cp := make([]domain.Article, len(data))
+		copy(cp, data)
+		go func() {
+			err := a.authorCache.SetFirstPage(ctx, uid, cp)
+			if err != nil {
+				a.log.Error("缓存作者已发表文章列表失败",
+					logger.Int64("author", uid), logger.Error(err))
+			}
+		}()
+		if len(data) > limit {
+			data = data[:limit]
+		}
+	}
+	return data, nil
+}
+
+func (a *articleRepository) ListPubByTag(ctx context.Context, tag string, offset, limit int) ([]domain.Article, error) {
+	const firstPageSize = 100
+	if offset+limit <= firstPageSize {
+		arts, err := a.tagCache.GetFirstPage(ctx, tag)
+		if err == nil {
+			return a.page(arts, offset, limit), nil
+		}
+		if err != cache.ErrKeyNotExisted {
+			a.log.Error("获取标签文章列表缓存失败",
+				logger.String("tag", tag), logger.Error(err))
+		}
+	}
+
+	// 第一页整页查出来，方便缓存
+	qOffset, qLimit := offset, limit
+	if offset+limit <= firstPageSize {
+		qOffset, qLimit = 0, firstPageSize
+	}
+	res, err := a.artDao.ListPubByTag(ctx, tag, qOffset, qLimit)
+	if err != nil {
+		return nil, err
+	}
+	data := slice.Map[article.PublishArticle, domain.Article](res, func(idx int, src article.PublishArticle) domain.Article {
+		return a.toDomain(article.Article(src))
+	})
+	if qOffset == 0 && qLimit == firstPageSize {
+		// 缓存只保留摘要，不能修改返回给调用方的数据
+		cp := make([]domain.Article, len(data))
+		copy(cp, data)
+		go func() {
+			err := a.tagCache.SetFirstPage(ctx, tag, cp)
+			if err != nil {
+				a.log.Error("缓存标签文章列表失败",
+					logger.String("tag", tag), logger.Error(err))
+			}
+		}()
+		data = a.page(data, offset, limit)
+	}
+	return data, nil
+}
+
+func (a *articleRepository) ListLatestPub(ctx context.Context, limit int) ([]domain.Article, error) {
+	res, err := a.artDao.ListLatestPub(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[article.PublishArticle, domain.Article](res, func(idx int, src article.PublishArticle) domain.Article {
+		return a.toDomain(article.Article(src))
+	}), nil
+}
+
+func (a *articleRepository) ScanPub(ctx context.Context, startId int64, limit int) ([]domain.Article, error) {
+	res, err := a.artDao.ScanPub(ctx, startId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[article.PublishArticle, domain.Article](res, func(idx int, src article.PublishArticle) domain.Article {
+		return a.toDomain(article.Article(src))
+	}), nil
+}
+
+func (a *articleRepository) ScanPairs(ctx context.Context, startId int64, limit int) ([]domain.ArticlePair, error) {
+	pairs, err := a.artDao.ScanPairs(ctx, startId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[article.ArticlePair, domain.ArticlePair](pairs, func(idx int, src article.ArticlePair) domain.ArticlePair {
+		res := domain.ArticlePair{
+			Id:               src.Id,
+			PubContentStored: src.PubContentStored,
+			ObjectMissing:    src.ObjectMissing,
+			ObjectStale:      src.ObjectStale,
+		}
+		if src.Draft != nil {
+			draft := a.toDomain(*src.Draft)
+			res.Draft = &draft
+		}
+		if src.Pub != nil {
+			pub := a.toDomain(article.Article(*src.Pub))
+			res.Pub = &pub
+		}
+		return res
+	}), nil
+}
+
+func (a *articleRepository) RepairPub(ctx context.Context, pair domain.ArticlePair, rendered domain.RenderedContent) error {
+	var (
+		version int64
+		uid     int64
+	)
+	if pair.Draft != nil {
+		version = pair.Draft.Version
+		uid = pair.Draft.Author.Id
+	} else if pair.Pub != nil {
+		uid = pair.Pub.Author.Id
+	}
+	tags := a.pubTags(ctx, pair.Id)
+	err := a.artDao.RepairPub(ctx, pair.Id, version, a.toRender(pair.Id, rendered))
+	if err != nil {
+		return err
+	}
+	a.project(ctx, pair.Id)
+	a.clearCache(ctx, pair.Id, uid)
+	a.clearTagCache(ctx, append(tags, a.pubTags(ctx, pair.Id)...))
+	a.pubChanged(ctx, uid)
+	a.syncIndex(ctx, pair.Id, pair.RepairedPubStatus())
+	return nil
+}
+
+func (a *articleRepository) TagCounts(ctx context.Context) ([]domain.TagCount, error) {
+	cnts, err := a.tagCache.GetTagCounts(ctx)
+	if err == nil {
+		return cnts, nil
+	}
+	if err != cache.ErrKeyNotExisted {
+		a.log.Error("获取标签统计缓存失败", logger.Error(err))
+	}
+	res, err := a.artDao.TagCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cnts = slice.Map[article.TagCount, domain.TagCount](res, func(idx int, src article.TagCount) domain.TagCount {
+		return domain.TagCount{Tag: src.Name, Count: src.Cnt}
+	})
+	go func() {
+		err := a.tagCache.SetTagCounts(ctx, cnts)
+		if err != nil {
+			a.log.Error("缓存标签统计失败", logger.Error(err))
+		}
+	}()
+	return cnts, nil
+}
+
 func (a *articleRepository) preCache(ctx context.Context, arts []domain.Article) {
 	if len(arts) > 0 && a.needCache(arts[0]) {
 		err := a.cache.Set(context.Background(), arts[0])
@@ -189,26 +617,88 @@ func (a *articleRepository) preCache(ctx context.Context, arts []domain.Article)
 }
 
 func (a *articleRepository) toDomain(src article.Article) domain.Article {
-	return domain.Article{
+	rendered := domain.RenderedContent{Abstract: src.Abstract}
+	if src.Render != nil {
+		rendered = domain.RenderedContent{
+			HTML:       src.Render.HTML,
+			FirstImage: src.Render.FirstImage,
+			Text:       src.Render.Text,
+			Abstract:   src.Abstract,
+		}
+		if src.Render.TOC != "" {
+			err := json.Unmarshal([]byte(src.Render.TOC), &rendered.TOC)
+			if err != nil {
+				a.log.Error("解析文章目录失败",
+					logger.Int64("id", src.Id), logger.Error(err))
+			}
+		}
+	}
+	res := domain.Article{
 		Id:      src.Id,
 		Title:   src.Title,
 		Content: src.Content,
 		Author: domain.Author{
 			Id: src.AuthorId,
 		},
+		Status:   domain.ArticleStatus(src.Status),
+		Version:  src.Version,
+		Tags:     src.Tags,
+		Category: src.Category,
+		Rendered: rendered,
+		Ctime:    time.UnixMilli(src.Ctime),
+		Utime:    time.UnixMilli(src.Utime),
+	}
+	if src.Dtime > 0 {
+		res.Dtime = time.UnixMilli(src.Dtime)
+	}
+	return res
+}
+
+func (a *articleRepository) revisionToDomain(src article.ArticleRevision) domain.ArticleRevision {
+	return domain.ArticleRevision{
+		Id:        src.Id,
+		ArticleId: src.ArticleId,
+		Title:     src.Title,
+		Content:   src.Content,
+		Author: domain.Author{
+			Id: src.AuthorId,
+		},
 		Status: domain.ArticleStatus(src.Status),
 		Ctime:  time.UnixMilli(src.Ctime),
-		Utime:  time.UnixMilli(src.Utime),
 	}
 }
 
 func (a *articleRepository) toEntity(art domain.Article) article.Article {
-	return article.Article{
+	res := article.Article{
 		Id:       art.Id,
 		Title:    art.Title,
 		Content:  art.Content,
 		AuthorId: art.Author.Id,
 		Status:   art.Status.ToUint8(),
+		Version:  art.Version,
+		Tags:     art.Tags,
+		Category: art.Category,
+		Abstract: art.Rendered.Abstract,
+	}
+	res.Render = a.toRender(art.Id, art.Rendered)
+	return res
+}
+
+// toRender 没有渲染结果时返回 nil
+func (a *articleRepository) toRender(id int64, r domain.RenderedContent) *article.PublishArticleRender {
+	if r.HTML == "" {
+		return nil
+	}
+	toc, err := json.Marshal(r.TOC)
+	if err != nil {
+		a.log.Error("序列化文章目录失败",
+			logger.Int64("id", id), logger.Error(err))
+	}
+	return &article.PublishArticleRender{
+		HTML:       r.HTML,
+		TOC:        string(toc),
+		FirstImage: r.FirstImage,
+		Text:       r.Text,
 	}
 }
 
@@ -232,6 +722,80 @@ func (a *articleRepository) clearCache(ctx context.Context, id, uid int64) {
 			logger.Int64("id", id),
 			logger.Error(err))
 	}
+
+	err = a.authorCache.DeleteFirstPage(ctx, uid)
+	if err != nil {
+		a.log.Error("清除作者已发表文章列表缓存失败",
+			logger.Int64("author", uid), logger.Error(err))
+	}
+}
+
+// page 从第一页缓存中截取 [offset, offset+limit)
+func (a *articleRepository) page(arts []domain.Article, offset, limit int) []domain.Article {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(arts) {
+		return []domain.Article{}
+	}
+	end := offset + limit
+	if end > len(arts) {
+		end = len(arts)
+	}
+	return arts[offset:end]
+}
+
+// pubTags 线上库中文章当前的标签，查询失败时只记录日志
+func (a *articleRepository) pubTags(ctx context.Context, id int64) []string {
+	art, err := a.artDao.FindPubById(ctx, id)
+	if err != nil {
+		if err != ErrArticleNotFound {
+			a.log.Error("查询文章标签失败",
+				logger.Int64("id", id), logger.Error(err))
+		}
+		return nil
+	}
+	return art.Tags
+}
+
+func (a *articleRepository) clearTagCache(ctx context.Context, tags []string) {
+	err := a.tagCache.DeleteFirstPage(ctx, tags...)
+	if err != nil {
+		a.log.Error("清除标签文章列表缓存失败", logger.Error(err))
+	}
+	err = a.tagCache.DeleteTagCounts(ctx)
+	if err != nil {
+		a.log.Error("清除标签统计缓存失败", logger.Error(err))
+	}
+}
+
+// project 把作者侧线上库中这篇文章的最新状态投影到读者侧，作者侧查不到时从读者侧删除。
+// 两边可能不在同一个库里，投影失败只记日志，由重放修复
+func (a *articleRepository) project(ctx context.Context, id int64) {
+	pub, err := a.artDao.FindPubById(ctx, id)
+	switch err {
+	case nil:
+		err = a.reader.Upsert(ctx, article.NewReaderArticle(pub))
+	case ErrArticleNotFound:
+		err = a.reader.Delete(ctx, []int64{id})
+	}
+	if err != nil {
+		a.log.Error("投影文章到读者侧失败",
+			logger.Int64("id", id), logger.Error(err))
+	}
+}
+
+// pubChanged 作者已发表的文章变了，作者和全站的订阅源都要重新生成，sitemap 也要更新
+func (a *articleRepository) pubChanged(ctx context.Context, uid int64) {
+	err := a.synCache.Delete(ctx, uid)
+	if err != nil {
+		a.log.Error("清除订阅源缓存失败",
+			logger.Int64("author", uid), logger.Error(err))
+	}
+	err = a.siteCache.MarkChanged(ctx)
+	if err != nil {
+		a.log.Error("标记 sitemap 需要更新失败", logger.Error(err))
+	}
 }
 
 func (a *articleRepository) needCache(art domain.Article) bool {
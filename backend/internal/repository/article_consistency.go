@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/pkg/blobstore"
+)
+
+var ErrArticleConsistencyReportNotFound = blobstore.ErrNotFound
+
+// ArticleConsistencyRepository 最近一次一致性检查的报告以 JSON 存放在对象存储中，所有实例共享
+type ArticleConsistencyRepository interface {
+	SaveReport(ctx context.Context, r domain.ArticleConsistencyReport) error
+	// LatestReport 还没有检查过时返回 ErrArticleConsistencyReportNotFound
+	LatestReport(ctx context.Context) (domain.ArticleConsistencyReport, error)
+}
+
+type articleConsistencyRepository struct {
+	store blobstore.BlobStore
+}
+
+func NewArticleConsistencyRepository(store blobstore.BlobStore) ArticleConsistencyRepository {
+	return &articleConsistencyRepository{
+		store: store,
+	}
+}
+
+func (a *articleConsistencyRepository) SaveReport(ctx context.Context, r domain.ArticleConsistencyReport) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return a.store.Put(ctx, a.key(), data, "application/json")
+}
+
+func (a *articleConsistencyRepository) LatestReport(ctx context.Context) (domain.ArticleConsistencyReport, error) {
+	var r domain.ArticleConsistencyReport
+	data, err := a.store.Get(ctx, a.key())
+	if err != nil {
+		return r, err
+	}
+	err = json.Unmarshal(data, &r)
+	return r, err
+}
+
+func (a *articleConsistencyRepository) key() string {
+	return "reports/article_consistency.json"
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository/cache"
+	"github.com/johnwongx/webook/backend/internal/repository/dao/article"
+	"time"
+)
+
+var ErrArticleMigrationDisabled = errors.New("没有开启文章存储迁移")
+
+// ArticleMigrationRepository 文章存储在线迁移，没有开启迁移时所有方法返回 ErrArticleMigrationDisabled
+type ArticleMigrationRepository interface {
+	// Stage 本实例正在使用的阶段
+	Stage(ctx context.Context) (domain.ArticleMigrationStage, error)
+	// SetStage 先保存到 Redis 再在本实例生效，其他实例由 SyncStage 同步
+	SetStage(ctx context.Context, stage domain.ArticleMigrationStage) error
+	// SyncStage 从 Redis 加载阶段，还没有设置过时保持不变
+	SyncStage(ctx context.Context) error
+	// Backfill 全量复制 id 大于 startId 的一批文章，返回最后一篇的 id 和复制的数量
+	Backfill(ctx context.Context, startId int64, limit int) (int64, int, bool, error)
+	// Verify 校验 id 大于 startId 且 since 之后修改过的一批文章，返回最后一篇的 id 和修复的数量
+	Verify(ctx context.Context, startId int64, since time.Time, limit int) (int64, int, bool, error)
+}
+
+type articleMigrationRepository struct {
+	dao   *article.DoubleWriteDAO
+	cache cache.ArticleMigrationCache
+}
+
+// NewArticleMigrationRepository d 为 nil 表示没有开启迁移
+func NewArticleMigrationRepository(d *article.DoubleWriteDAO, c cache.ArticleMigrationCache) ArticleMigrationRepository {
+	return &articleMigrationRepository{
+		dao:   d,
+		cache: c,
+	}
+}
+
+func (a *articleMigrationRepository) Stage(ctx context.Context) (domain.ArticleMigrationStage, error) {
+	if a.dao == nil {
+		return 0, ErrArticleMigrationDisabled
+	}
+	return a.dao.Stage(), nil
+}
+
+func (a *articleMigrationRepository) SetStage(ctx context.Context, stage domain.ArticleMigrationStage) error {
+	if a.dao == nil {
+		return ErrArticleMigrationDisabled
+	}
+	err := a.cache.SetStage(ctx, stage)
+	if err != nil {
+		return err
+	}
+	a.dao.SetStage(stage)
+	return nil
+}
+
+func (a *articleMigrationRepository) SyncStage(ctx context.Context) error {
+	if a.dao == nil {
+		return ErrArticleMigrationDisabled
+	}
+	stage, err := a.cache.GetStage(ctx)
+	if err == cache.ErrKeyNotExisted {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	a.dao.SetStage(stage)
+	return nil
+}
+
+func (a *articleMigrationRepository) Backfill(ctx context.Context, startId int64, limit int) (int64, int, bool, error) {
+	if a.dao == nil {
+		return 0, 0, false, ErrArticleMigrationDisabled
+	}
+	return a.dao.Backfill(ctx, startId, limit)
+}
+
+func (a *articleMigrationRepository) Verify(ctx context.Context, startId int64, since time.Time, limit int) (int64, int, bool, error) {
+	if a.dao == nil {
+		return 0, 0, false, ErrArticleMigrationDisabled
+	}
+	var sinceMs int64
+	if !since.IsZero() {
+		sinceMs = since.UnixMilli()
+	}
+	return a.dao.Verify(ctx, startId, sinceMs, limit)
+}
@@ -4,27 +4,76 @@ import (
 	"context"
 	"github.com/johnwongx/webook/backend/internal/domain"
 	"github.com/johnwongx/webook/backend/internal/repository/dao/article"
+	"math"
 )
 
+// ReaderArticleRepository 读者侧的读模型，日常由 ArticleRepository 在写作者侧之后投影过来
 type ReaderArticleRepository interface {
 	Save(ctx context.Context, art domain.Article) error
+	// Replay 用作者侧线上库中 id 大于 startId 的一批文章重建读者侧，并删除读者侧这个范围内多出来的文章。
+	// 返回最后一篇的 id 和写入的数量，done 为 true 表示已经到头
+	Replay(ctx context.Context, startId int64, limit int) (lastId int64, n int, done bool, err error)
 }
 
 type readerArticleRepository struct {
-	r article.ReaderArticleDAO
+	r      article.ReaderArticleDAO
+	author article.ArticleDAO
 }
 
-func NewReaderArticleRepository(r article.ReaderArticleDAO) ReaderArticleRepository {
+func NewReaderArticleRepository(r article.ReaderArticleDAO, author article.ArticleDAO) ReaderArticleRepository {
 	return &readerArticleRepository{
-		r: r,
+		r:      r,
+		author: author,
 	}
 }
 
 func (r *readerArticleRepository) Save(ctx context.Context, art domain.Article) error {
-	return r.r.Upsert(ctx, article.Article{
+	return r.r.Upsert(ctx, article.NewReaderArticle(article.PublishArticle{
 		Id:       art.Id,
 		Title:    art.Title,
 		Content:  art.Content,
 		AuthorId: art.Author.Id,
-	})
+		Status:   art.Status.ToUint8(),
+	}))
+}
+
+func (r *readerArticleRepository) Replay(ctx context.Context, startId int64, limit int) (int64, int, bool, error) {
+	// ListPub 包括回收站中的文章，也不带标签和渲染结果，逐篇用 FindPubById 补全
+	pubs, err := r.author.ListPub(ctx, startId, limit)
+	if err != nil {
+		return startId, 0, false, err
+	}
+	done := len(pubs) < limit
+	endId := startId
+	if len(pubs) > 0 {
+		endId = pubs[len(pubs)-1].Id
+	}
+
+	keep := make([]int64, 0, len(pubs))
+	for _, p := range pubs {
+		pub, err := r.author.FindPubById(ctx, p.Id)
+		if err == article.ErrArticleNotFound {
+			// 在回收站中或者期间被删除了
+			continue
+		}
+		if err != nil {
+			return startId, len(keep), false, err
+		}
+		err = r.r.Upsert(ctx, article.NewReaderArticle(pub))
+		if err != nil {
+			return startId, len(keep), false, err
+		}
+		keep = append(keep, p.Id)
+	}
+
+	if done {
+		// 作者侧已经到头，读者侧后面剩下的都是多余的
+		err = r.r.DeleteExcept(ctx, startId, math.MaxInt64, keep)
+	} else {
+		err = r.r.DeleteExcept(ctx, startId, endId, keep)
+	}
+	if err != nil {
+		return startId, len(keep), false, err
+	}
+	return endId, len(keep), done, nil
 }
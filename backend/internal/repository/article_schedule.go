@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository/dao/article"
+	"time"
+)
+
+var (
+	ErrScheduleNotFound   = article.ErrScheduleNotFound
+	ErrScheduleNotPending = article.ErrScheduleNotPending
+	ErrScheduleNotOwned   = article.ErrScheduleNotOwned
+)
+
+type ArticleScheduleRepository interface {
+	Create(ctx context.Context, s domain.ArticleSchedule) (int64, error)
+	ListPending(ctx context.Context, uid int64) ([]domain.ArticleSchedule, error)
+	Cancel(ctx context.Context, id, uid int64) error
+	CancelByArticle(ctx context.Context, aid, uid int64, action domain.ArticleScheduleAction) error
+	Preempt(ctx context.Context, now time.Time, timeout time.Duration) (domain.ArticleSchedule, error)
+	// Finish 以 status 结束 Preempt 抢到的任务
+	Finish(ctx context.Context, s domain.ArticleSchedule, status domain.ArticleScheduleStatus) error
+	// Retry 把 Preempt 抢到的任务放回等待队列，到 executeAt 再执行
+	Retry(ctx context.Context, s domain.ArticleSchedule, executeAt time.Time) error
+}
+
+type articleScheduleRepository struct {
+	dao article.ArticleScheduleDAO
+}
+
+func NewArticleScheduleRepository(dao article.ArticleScheduleDAO) ArticleScheduleRepository {
+	return &articleScheduleRepository{
+		dao: dao,
+	}
+}
+
+func (a *articleScheduleRepository) Create(ctx context.Context, s domain.ArticleSchedule) (int64, error) {
+	return a.dao.Insert(ctx, a.toEntity(s))
+}
+
+func (a *articleScheduleRepository) ListPending(ctx context.Context, uid int64) ([]domain.ArticleSchedule, error) {
+	res, err := a.dao.ListPending(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[article.ArticleSchedule, domain.ArticleSchedule](res,
+		func(idx int, src article.ArticleSchedule) domain.ArticleSchedule {
+			return a.toDomain(src)
+		}), nil
+}
+
+func (a *articleScheduleRepository) Cancel(ctx context.Context, id, uid int64) error {
+	return a.dao.Cancel(ctx, id, uid)
+}
+
+func (a *articleScheduleRepository) CancelByArticle(ctx context.Context, aid, uid int64, action domain.ArticleScheduleAction) error {
+	return a.dao.CancelByArticle(ctx, aid, uid, action.ToUint8())
+}
+
+func (a *articleScheduleRepository) Preempt(ctx context.Context, now time.Time, timeout time.Duration) (domain.ArticleSchedule, error) {
+	s, err := a.dao.Preempt(ctx, now.UnixMilli(), timeout)
+	if err != nil {
+		return domain.ArticleSchedule{}, err
+	}
+	return a.toDomain(s), nil
+}
+
+func (a *articleScheduleRepository) Finish(ctx context.Context, s domain.ArticleSchedule, status domain.ArticleScheduleStatus) error {
+	return a.dao.Finish(ctx, a.toEntity(s), status.ToUint8())
+}
+
+func (a *articleScheduleRepository) Retry(ctx context.Context, s domain.ArticleSchedule, executeAt time.Time) error {
+	return a.dao.Retry(ctx, a.toEntity(s), executeAt.UnixMilli())
+}
+
+func (a *articleScheduleRepository) toDomain(s article.ArticleSchedule) domain.ArticleSchedule {
+	return domain.ArticleSchedule{
+		Id:        s.Id,
+		ArticleId: s.ArticleId,
+		Author: domain.Author{
+			Id: s.AuthorId,
+		},
+		Action:    domain.ArticleScheduleAction(s.Action),
+		Status:    domain.ArticleScheduleStatus(s.Status),
+		ExecuteAt: time.UnixMilli(s.ExecuteAt),
+		Retries:   s.Retries,
+		Ctime:     time.UnixMilli(s.Ctime),
+		Utime:     time.UnixMilli(s.Utime),
+	}
+}
+
+func (a *articleScheduleRepository) toEntity(s domain.ArticleSchedule) article.ArticleSchedule {
+	return article.ArticleSchedule{
+		Id:        s.Id,
+		ArticleId: s.ArticleId,
+		AuthorId:  s.Author.Id,
+		Action:    s.Action.ToUint8(),
+		Status:    s.Status.ToUint8(),
+		ExecuteAt: s.ExecuteAt.UnixMilli(),
+		Retries:   s.Retries,
+		Ctime:     s.Ctime.UnixMilli(),
+		Utime:     s.Utime.UnixMilli(),
+	}
+}
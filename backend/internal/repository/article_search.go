@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository/dao/article"
+	"github.com/johnwongx/webook/backend/internal/repository/search"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/johnwongx/webook/backend/pkg/markdown"
+	"time"
+)
+
+type ArticleSearchRepository interface {
+	Search(ctx context.Context, q string, offset, limit int) (domain.ArticleSearchResult, error)
+	// RebuildIndex 遍历线上库重建索引，已发表的加入索引，其余的从索引中移除
+	RebuildIndex(ctx context.Context) error
+}
+
+type articleSearchRepository struct {
+	artDao   article.ArticleDAO
+	userRepo UserRepository
+	index    search.ArticleIndex
+	log      logger.Logger
+}
+
+func NewArticleSearchRepository(d article.ArticleDAO, uRepo UserRepository, index search.ArticleIndex,
+	l logger.Logger) ArticleSearchRepository {
+	return &articleSearchRepository{
+		artDao:   d,
+		userRepo: uRepo,
+		index:    index,
+		log:      l,
+	}
+}
+
+func (a *articleSearchRepository) Search(ctx context.Context, q string, offset, limit int) (domain.ArticleSearchResult, error) {
+	res, err := a.index.Search(ctx, q, offset, limit)
+	if err != nil {
+		return domain.ArticleSearchResult{}, err
+	}
+	return domain.ArticleSearchResult{
+		Total: res.Total,
+		Hits: slice.Map[search.ArticleHit, domain.ArticleSearchHit](res.Hits, func(idx int, src search.ArticleHit) domain.ArticleSearchHit {
+			return domain.ArticleSearchHit{
+				Article: domain.Article{
+					Id:    src.Id,
+					Title: src.Title,
+					Author: domain.Author{
+						Id:   src.AuthorId,
+						Name: src.Author,
+					},
+					Status: domain.ArticleStatusPublished,
+					Utime:  time.UnixMilli(src.Utime),
+				},
+				Score:            src.Score,
+				TitleHighlight:   src.TitleHighlight,
+				ContentHighlight: src.ContentHighlight,
+			}
+		}),
+	}, nil
+}
+
+func (a *articleSearchRepository) RebuildIndex(ctx context.Context) error {
+	const batchSize = 100
+	// 同一个作者只查一次昵称
+	names := make(map[int64]string)
+	var startId int64
+	for {
+		arts, err := a.artDao.ListPub(ctx, startId, batchSize)
+		if err != nil {
+			return err
+		}
+		for _, art := range arts {
+			err = a.rebuildOne(ctx, art, names)
+			if err != nil {
+				return err
+			}
+		}
+		if len(arts) < batchSize {
+			return nil
+		}
+		startId = arts[len(arts)-1].Id
+	}
+}
+
+func (a *articleSearchRepository) rebuildOne(ctx context.Context, art article.PublishArticle, names map[int64]string) error {
+	if art.Status != domain.ArticleStatusPublished.ToUint8() {
+		return a.index.Delete(ctx, art.Id)
+	}
+	name, ok := names[art.AuthorId]
+	if !ok {
+		user, err := a.userRepo.FindById(ctx, art.AuthorId)
+		if err != nil {
+			// 作者信息缺失不影响按标题、内容搜索
+			a.log.Error("获取用户信息失败",
+				logger.Int64("author", art.AuthorId), logger.Error(err))
+		}
+		name = user.NickName
+		names[art.AuthorId] = name
+	}
+	return a.index.Upsert(ctx, search.ArticleDoc{
+		Id:       art.Id,
+		Title:    art.Title,
+		Content:  markdown.PlainText(art.Content),
+		AuthorId: art.AuthorId,
+		Author:   name,
+		Utime:    art.Utime,
+	})
+}
+
+// toSearchDoc 正文用渲染后的纯文本建索引，避免 Markdown 标记影响搜索和高亮
+func toSearchDoc(art domain.Article) search.ArticleDoc {
+	text := art.Rendered.Text
+	if text == "" {
+		text = markdown.PlainText(art.Content)
+	}
+	return search.ArticleDoc{
+		Id:       art.Id,
+		Title:    art.Title,
+		Content:  text,
+		AuthorId: art.Author.Id,
+		Author:   art.Author.Name,
+		Utime:    art.Utime.UnixMilli(),
+	}
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository/dao/article"
+	artdaomocks "github.com/johnwongx/webook/backend/internal/repository/dao/article/mocks"
+	repomocks "github.com/johnwongx/webook/backend/internal/repository/mocks"
+	"github.com/johnwongx/webook/backend/internal/repository/search"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestArticleSearchRepository_RebuildIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	d := artdaomocks.NewMockArticleDAO(ctrl)
+	d.EXPECT().ListPub(gomock.Any(), int64(0), 100).Return([]article.PublishArticle{
+		{Id: 1, Title: "golang 入门", AuthorId: 123, Status: domain.ArticleStatusPublished.ToUint8()},
+		// 其他实例上移入回收站的文章
+		{Id: 2, Title: "golang 进阶", AuthorId: 123, Status: domain.ArticleStatusDeleted.ToUint8(), Dtime: 100},
+	}, nil)
+	ur := repomocks.NewMockUserRepository(ctrl)
+	ur.EXPECT().FindById(gomock.Any(), int64(123)).Return(domain.User{Id: 123, NickName: "作者"}, nil)
+
+	index := search.NewMemoryArticleIndex()
+	for _, id := range []int64{1, 2} {
+		err := index.Upsert(context.Background(), search.ArticleDoc{Id: id, Title: "golang"})
+		require.NoError(t, err)
+	}
+	repo := NewArticleSearchRepository(d, ur, index, logger.NewNopLogger())
+	err := repo.RebuildIndex(context.Background())
+	require.NoError(t, err)
+
+	res, err := index.Search(context.Background(), "golang", 0, 10)
+	require.NoError(t, err)
+	ids := make([]int64, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		ids = append(ids, hit.Id)
+	}
+	assert.Equal(t, []int64{1}, ids)
+}
@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository/cache"
+	cachemocks "github.com/johnwongx/webook/backend/internal/repository/cache/mocks"
+	"github.com/johnwongx/webook/backend/internal/repository/dao/article"
+	artdaomocks "github.com/johnwongx/webook/backend/internal/repository/dao/article/mocks"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestArticleRepository_ListPubByAuthor(t *testing.T) {
+	now := time.UnixMilli(1000)
+	cursor := domain.ArticleCursor{Utime: now, Id: 3}
+	testCases := []struct {
+		name string
+		// mock 返回的 channel 在异步回写缓存完成后关闭，不回写缓存时为 nil
+		mock   func(ctrl *gomock.Controller) (article.ArticleDAO, cache.ArticleAuthorCache, chan struct{})
+		cursor domain.ArticleCursor
+		limit  int
+
+		wantIds []int64
+		wantErr error
+	}{
+		{
+			name: "命中缓存",
+			mock: func(ctrl *gomock.Controller) (article.ArticleDAO, cache.ArticleAuthorCache, chan struct{}) {
+				c := cachemocks.NewMockArticleAuthorCache(ctrl)
+				c.EXPECT().GetPage(gomock.Any(), int64(123), cursor, 2).
+					Return([]domain.Article{{Id: 2}, {Id: 1}}, nil)
+				return artdaomocks.NewMockArticleDAO(ctrl), c, nil
+			},
+			cursor:  cursor,
+			limit:   2,
+			wantIds: []int64{2, 1},
+		},
+		{
+			name: "缓存未命中，第一页整页查出来并回写缓存",
+			mock: func(ctrl *gomock.Controller) (article.ArticleDAO, cache.ArticleAuthorCache, chan struct{}) {
+				c := cachemocks.NewMockArticleAuthorCache(ctrl)
+				c.EXPECT().GetPage(gomock.Any(), int64(123), domain.ArticleCursor{}, 2).
+					Return(nil, cache.ErrKeyNotExisted)
+				d := artdaomocks.NewMockArticleDAO(ctrl)
+				d.EXPECT().ListPubByAuthor(gomock.Any(), int64(123), domain.ArticleCursor{}.Utime.UnixMilli(), int64(0), 100).
+					Return([]article.PublishArticle{{Id: 3}, {Id: 2}, {Id: 1}}, nil)
+				done := make(chan struct{})
+				c.EXPECT().SetFirstPage(gomock.Any(), int64(123), gomock.Len(3)).
+					DoAndReturn(func(ctx context.Context, uid int64, arts []domain.Article) error {
+						close(done)
+						return nil
+					})
+				return d, c, done
+			},
+			limit:   2,
+			wantIds: []int64{3, 2},
+		},
+		{
+			name: "缓存不够，从游标之后查数据库",
+			mock: func(ctrl *gomock.Controller) (article.ArticleDAO, cache.ArticleAuthorCache, chan struct{}) {
+				c := cachemocks.NewMockArticleAuthorCache(ctrl)
+				c.EXPECT().GetPage(gomock.Any(), int64(123), cursor, 10).
+					Return(nil, cache.ErrKeyNotExisted)
+				d := artdaomocks.NewMockArticleDAO(ctrl)
+				d.EXPECT().ListPubByAuthor(gomock.Any(), int64(123), int64(1000), int64(3), 10).
+					Return([]article.PublishArticle{{Id: 2}}, nil)
+				return d, c, nil
+			},
+			cursor:  cursor,
+			limit:   10,
+			wantIds: []int64{2},
+		},
+		{
+			name: "查询出错",
+			mock: func(ctrl *gomock.Controller) (article.ArticleDAO, cache.ArticleAuthorCache, chan struct{}) {
+				c := cachemocks.NewMockArticleAuthorCache(ctrl)
+				c.EXPECT().GetPage(gomock.Any(), int64(123), cursor, 10).
+					Return(nil, errors.New("redis error"))
+				d := artdaomocks.NewMockArticleDAO(ctrl)
+				d.EXPECT().ListPubByAuthor(gomock.Any(), int64(123), int64(1000), int64(3), 10).
+					Return(nil, errors.New("db error"))
+				return d, c, nil
+			},
+			cursor:  cursor,
+			limit:   10,
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			d, c, done := tc.mock(ctrl)
+			repo := &articleRepository{
+				artDao:      d,
+				authorCache: c,
+				log:         logger.NewNopLogger(),
+			}
+			arts, err := repo.ListPubByAuthor(context.Background(), 123, tc.cursor, tc.limit)
+			if done != nil {
+				select {
+				case <-done:
+				case <-time.After(time.Second):
+					t.Fatal("没有回写缓存")
+				}
+			}
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			ids := make([]int64, 0, len(arts))
+			for _, art := range arts {
+				ids = append(ids, art.Id)
+			}
+			require.Equal(t, tc.wantIds, ids)
+		})
+	}
+}
+
+func TestArticleRepository_ListPubByTag(t *testing.T) {
+	testCases := []struct {
+		name string
+		// mock 返回的 channel 在异步回写缓存完成后关闭，不回写缓存时为 nil
+		mock   func(ctrl *gomock.Controller) (article.ArticleDAO, cache.ArticleTagCache, chan struct{})
+		offset int
+		limit  int
+
+		wantIds []int64
+	}{
+		{
+			name: "命中缓存",
+			mock: func(ctrl *gomock.Controller) (article.ArticleDAO, cache.ArticleTagCache, chan struct{}) {
+				c := cachemocks.NewMockArticleTagCache(ctrl)
+				c.EXPECT().GetFirstPage(gomock.Any(), "go").
+					Return([]domain.Article{{Id: 3}, {Id: 2}, {Id: 1}}, nil)
+				return artdaomocks.NewMockArticleDAO(ctrl), c, nil
+			},
+			offset:  1,
+			limit:   2,
+			wantIds: []int64{2, 1},
+		},
+		{
+			name: "offset 为负数，从头开始",
+			mock: func(ctrl *gomock.Controller) (article.ArticleDAO, cache.ArticleTagCache, chan struct{}) {
+				c := cachemocks.NewMockArticleTagCache(ctrl)
+				c.EXPECT().GetFirstPage(gomock.Any(), "go").
+					Return([]domain.Article{{Id: 3}, {Id: 2}, {Id: 1}}, nil)
+				return artdaomocks.NewMockArticleDAO(ctrl), c, nil
+			},
+			offset:  -1,
+			limit:   2,
+			wantIds: []int64{3, 2},
+		},
+		{
+			name: "缓存未命中，整个第一页回写缓存",
+			mock: func(ctrl *gomock.Controller) (article.ArticleDAO, cache.ArticleTagCache, chan struct{}) {
+				c := cachemocks.NewMockArticleTagCache(ctrl)
+				c.EXPECT().GetFirstPage(gomock.Any(), "go").Return(nil, cache.ErrKeyNotExisted)
+				d := artdaomocks.NewMockArticleDAO(ctrl)
+				d.EXPECT().ListPubByTag(gomock.Any(), "go", 0, 100).
+					Return([]article.PublishArticle{{Id: 3}, {Id: 2}, {Id: 1}}, nil)
+				done := make(chan struct{})
+				c.EXPECT().SetFirstPage(gomock.Any(), "go", gomock.Len(3)).
+					DoAndReturn(func(ctx context.Context, tag string, arts []domain.Article) error {
+						close(done)
+						return nil
+					})
+				return d, c, done
+			},
+			limit:   2,
+			wantIds: []int64{3, 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			d, c, done := tc.mock(ctrl)
+			repo := &articleRepository{
+				artDao:   d,
+				tagCache: c,
+				log:      logger.NewNopLogger(),
+			}
+			arts, err := repo.ListPubByTag(context.Background(), "go", tc.offset, tc.limit)
+			if done != nil {
+				select {
+				case <-done:
+				case <-time.After(time.Second):
+					t.Fatal("没有回写缓存")
+				}
+			}
+			require.NoError(t, err)
+			ids := make([]int64, 0, len(arts))
+			for _, art := range arts {
+				ids = append(ids, art.Id)
+			}
+			assert.Equal(t, tc.wantIds, ids)
+		})
+	}
+}
@@ -7,14 +7,21 @@ import (
 	"fmt"
 	"github.com/johnwongx/webook/backend/internal/domain"
 	"github.com/redis/go-redis/v9"
+	"sort"
 	"time"
 )
 
 var ErrKeyNotExisted = errors.New("key not existed")
 var _ ArticleCache = &RedisArticleCache{}
 
+// ArticleFirstPageSize 作者文章列表缓存的文章数量，覆盖前几页
+const ArticleFirstPageSize = 100
+
 type ArticleCache interface {
+	// GetPage 从列表缓存中取游标之后的 limit 篇文章，缓存的数据不够时返回 ErrKeyNotExisted
+	GetPage(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
 	GetFirstPage(ctx context.Context, uid int64) ([]domain.Article, error)
+	// SetFirstPage arts 是作者最新的至多 ArticleFirstPageSize 篇文章，少于该数量表示作者的全部文章
 	SetFirstPage(ctx context.Context, uid int64, arts []domain.Article) error
 	DeleteFirstPage(ctx context.Context, uid int64) error
 
@@ -37,6 +44,26 @@ func NewRedisArticleCache(client redis.Cmdable) ArticleCache {
 	}
 }
 
+func (r *RedisArticleCache) GetPage(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	arts, err := r.GetFirstPage(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	// 缓存按 (utime, id) 倒序，找到第一篇排在游标之后的文章
+	start := sort.Search(len(arts), func(i int) bool {
+		return cursor.Before(arts[i])
+	})
+	end := start + limit
+	if end > len(arts) {
+		// 缓存不完整时，剩下的文章要去数据库查
+		if len(arts) >= ArticleFirstPageSize {
+			return nil, ErrKeyNotExisted
+		}
+		end = len(arts)
+	}
+	return arts[start:end], nil
+}
+
 func (r *RedisArticleCache) GetFirstPage(ctx context.Context, uid int64) ([]domain.Article, error) {
 	bts, err := r.client.Get(ctx, r.firstPageKey(uid)).Bytes()
 	if err == redis.Nil {
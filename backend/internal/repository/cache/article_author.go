@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/redis/go-redis/v9"
+	"sort"
+	"time"
+)
+
+var _ ArticleAuthorCache = &RedisArticleAuthorCache{}
+
+// AuthorArticleFirstPageSize 作者主页已发表文章列表缓存的文章数量，覆盖前几页
+const AuthorArticleFirstPageSize = 100
+
+// ArticleAuthorCache 作者主页上已发表文章列表的缓存
+type ArticleAuthorCache interface {
+	// GetPage 从列表缓存中取游标之后的 limit 篇文章，缓存的数据不够时返回 ErrKeyNotExisted
+	GetPage(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
+	// GetFirstPage 作者第一页的已发表文章，内容只保留摘要
+	GetFirstPage(ctx context.Context, uid int64) ([]domain.Article, error)
+	// SetFirstPage arts 是作者最新的至多 AuthorArticleFirstPageSize 篇已发表文章，少于该数量表示全部
+	SetFirstPage(ctx context.Context, uid int64, arts []domain.Article) error
+	DeleteFirstPage(ctx context.Context, uid int64) error
+}
+
+type RedisArticleAuthorCache struct {
+	client redis.Cmdable
+}
+
+func NewRedisArticleAuthorCache(client redis.Cmdable) ArticleAuthorCache {
+	return &RedisArticleAuthorCache{
+		client: client,
+	}
+}
+
+func (r *RedisArticleAuthorCache) GetPage(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	arts, err := r.GetFirstPage(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	// 缓存按 (utime, id) 倒序，找到第一篇排在游标之后的文章
+	start := sort.Search(len(arts), func(i int) bool {
+		return cursor.Before(arts[i])
+	})
+	end := start + limit
+	if end > len(arts) {
+		// 缓存不完整时，剩下的文章要去数据库查
+		if len(arts) >= AuthorArticleFirstPageSize {
+			return nil, ErrKeyNotExisted
+		}
+		end = len(arts)
+	}
+	return arts[start:end], nil
+}
+
+func (r *RedisArticleAuthorCache) GetFirstPage(ctx context.Context, uid int64) ([]domain.Article, error) {
+	bts, err := r.client.Get(ctx, r.firstPageKey(uid)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrKeyNotExisted
+	} else if err != nil {
+		return nil, err
+	}
+	var arts []domain.Article
+	err = json.Unmarshal(bts, &arts)
+	return arts, err
+}
+
+func (r *RedisArticleAuthorCache) SetFirstPage(ctx context.Context, uid int64, arts []domain.Article) error {
+	for i := range arts {
+		arts[i].Content = arts[i].Abstract()
+	}
+	bts, err := json.Marshal(arts)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, r.firstPageKey(uid), bts, time.Minute*10).Err()
+}
+
+func (r *RedisArticleAuthorCache) DeleteFirstPage(ctx context.Context, uid int64) error {
+	return r.client.Del(ctx, r.firstPageKey(uid)).Err()
+}
+
+func (r *RedisArticleAuthorCache) firstPageKey(uid int64) string {
+	return fmt.Sprintf("author_pub_article_list:%d", uid)
+}
@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"context"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ ArticleMigrationCache = &RedisArticleMigrationCache{}
+
+// ArticleMigrationCache 文章存储迁移所处的阶段，所有实例共享，不过期
+type ArticleMigrationCache interface {
+	// GetStage 还没有设置过时返回 ErrKeyNotExisted
+	GetStage(ctx context.Context) (domain.ArticleMigrationStage, error)
+	SetStage(ctx context.Context, stage domain.ArticleMigrationStage) error
+}
+
+type RedisArticleMigrationCache struct {
+	client redis.Cmdable
+}
+
+func NewRedisArticleMigrationCache(client redis.Cmdable) ArticleMigrationCache {
+	return &RedisArticleMigrationCache{
+		client: client,
+	}
+}
+
+func (r *RedisArticleMigrationCache) GetStage(ctx context.Context) (domain.ArticleMigrationStage, error) {
+	stage, err := r.client.Get(ctx, r.key()).Uint64()
+	if err == redis.Nil {
+		return 0, ErrKeyNotExisted
+	}
+	return domain.ArticleMigrationStage(stage), err
+}
+
+func (r *RedisArticleMigrationCache) SetStage(ctx context.Context, stage domain.ArticleMigrationStage) error {
+	return r.client.Set(ctx, r.key(), uint8(stage), 0).Err()
+}
+
+func (r *RedisArticleMigrationCache) key() string {
+	return "article_migration:stage"
+}
@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+var _ ArticleTagCache = &RedisArticleTagCache{}
+
+type ArticleTagCache interface {
+	// GetFirstPage 标签下第一页的已发表文章，内容只保留摘要
+	GetFirstPage(ctx context.Context, tag string) ([]domain.Article, error)
+	SetFirstPage(ctx context.Context, tag string, arts []domain.Article) error
+	DeleteFirstPage(ctx context.Context, tags ...string) error
+
+	GetTagCounts(ctx context.Context) ([]domain.TagCount, error)
+	SetTagCounts(ctx context.Context, cnts []domain.TagCount) error
+	DeleteTagCounts(ctx context.Context) error
+}
+
+type RedisArticleTagCache struct {
+	client redis.Cmdable
+}
+
+func NewRedisArticleTagCache(client redis.Cmdable) ArticleTagCache {
+	return &RedisArticleTagCache{
+		client: client,
+	}
+}
+
+func (r *RedisArticleTagCache) GetFirstPage(ctx context.Context, tag string) ([]domain.Article, error) {
+	bts, err := r.client.Get(ctx, r.firstPageKey(tag)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrKeyNotExisted
+	} else if err != nil {
+		return nil, err
+	}
+	var arts []domain.Article
+	err = json.Unmarshal(bts, &arts)
+	return arts, err
+}
+
+func (r *RedisArticleTagCache) SetFirstPage(ctx context.Context, tag string, arts []domain.Article) error {
+	for i := range arts {
+		arts[i].Content = arts[i].Abstract()
+	}
+	bts, err := json.Marshal(arts)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, r.firstPageKey(tag), bts, time.Minute*10).Err()
+}
+
+func (r *RedisArticleTagCache) DeleteFirstPage(ctx context.Context, tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		keys = append(keys, r.firstPageKey(tag))
+	}
+	return r.client.Del(ctx, keys...).Err()
+}
+
+func (r *RedisArticleTagCache) GetTagCounts(ctx context.Context) ([]domain.TagCount, error) {
+	bts, err := r.client.Get(ctx, r.tagCountsKey()).Bytes()
+	if err == redis.Nil {
+		return nil, ErrKeyNotExisted
+	} else if err != nil {
+		return nil, err
+	}
+	var cnts []domain.TagCount
+	err = json.Unmarshal(bts, &cnts)
+	return cnts, err
+}
+
+func (r *RedisArticleTagCache) SetTagCounts(ctx context.Context, cnts []domain.TagCount) error {
+	bts, err := json.Marshal(cnts)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, r.tagCountsKey(), bts, time.Minute*10).Err()
+}
+
+func (r *RedisArticleTagCache) DeleteTagCounts(ctx context.Context) error {
+	return r.client.Del(ctx, r.tagCountsKey()).Err()
+}
+
+func (r *RedisArticleTagCache) firstPageKey(tag string) string {
+	return fmt.Sprintf("tag_article_list:%s", tag)
+}
+
+func (r *RedisArticleTagCache) tagCountsKey() string {
+	return "tag_counts"
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+var _ CommentCache = &RedisCommentCache{}
+
+type CommentCache interface {
+	// GetFirstPage 第一页根评论，带内联的回复
+	GetFirstPage(ctx context.Context, biz string, bizId int64) ([]domain.Comment, error)
+	SetFirstPage(ctx context.Context, biz string, bizId int64, cs []domain.Comment) error
+	DeleteFirstPage(ctx context.Context, biz string, bizId int64) error
+}
+
+type RedisCommentCache struct {
+	client redis.Cmdable
+}
+
+func NewRedisCommentCache(client redis.Cmdable) CommentCache {
+	return &RedisCommentCache{
+		client: client,
+	}
+}
+
+func (r *RedisCommentCache) GetFirstPage(ctx context.Context, biz string, bizId int64) ([]domain.Comment, error) {
+	bts, err := r.client.Get(ctx, r.firstPageKey(biz, bizId)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrKeyNotExisted
+	} else if err != nil {
+		return nil, err
+	}
+	var cs []domain.Comment
+	err = json.Unmarshal(bts, &cs)
+	return cs, err
+}
+
+func (r *RedisCommentCache) SetFirstPage(ctx context.Context, biz string, bizId int64, cs []domain.Comment) error {
+	bts, err := json.Marshal(cs)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, r.firstPageKey(biz, bizId), bts, time.Minute*10).Err()
+}
+
+func (r *RedisCommentCache) DeleteFirstPage(ctx context.Context, biz string, bizId int64) error {
+	return r.client.Del(ctx, r.firstPageKey(biz, bizId)).Err()
+}
+
+func (r *RedisCommentCache) firstPageKey(biz string, bizId int64) string {
+	return fmt.Sprintf("comment_first_page:%s:%d", biz, bizId)
+}
@@ -19,6 +19,7 @@ const (
 	fieldReadCnt    = "read_cnt"
 	fieldCollectCnt = "collect_cnt"
 	fieldLikeCnt    = "like_cnt"
+	fieldCommentCnt = "comment_cnt"
 )
 
 type InteractiveCache interface {
@@ -27,8 +28,11 @@ type InteractiveCache interface {
 	IncrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error
 	DecrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error
 	IncrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error
+	DecrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error
+	IncrCommentCntIfPresent(ctx context.Context, biz string, bizId int64, delta int64) error
 	Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error)
 	Set(ctx context.Context, biz string, bizId int64, intr domain.Interactive) error
+	Delete(ctx context.Context, biz string, bizId int64) error
 }
 
 type RedisInteractiveCache struct {
@@ -70,6 +74,18 @@ func (r *RedisInteractiveCache) IncrCollectCntIfPresent(ctx context.Context, biz
 		fieldCollectCnt, 1).Err()
 }
 
+func (r *RedisInteractiveCache) DecrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	return r.client.Eval(ctx, luaIncrCnt,
+		[]string{r.key(biz, bizId)},
+		fieldCollectCnt, -1).Err()
+}
+
+func (r *RedisInteractiveCache) IncrCommentCntIfPresent(ctx context.Context, biz string, bizId int64, delta int64) error {
+	return r.client.Eval(ctx, luaIncrCnt,
+		[]string{r.key(biz, bizId)},
+		fieldCommentCnt, delta).Err()
+}
+
 func (r *RedisInteractiveCache) Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
 	data, err := r.client.HGetAll(ctx, r.key(biz, bizId)).Result()
 	if err != nil {
@@ -82,6 +98,7 @@ func (r *RedisInteractiveCache) Get(ctx context.Context, biz string, bizId int64
 	collectCnt, _ := strconv.ParseInt(data[fieldCollectCnt], 10, 64)
 	likeCnt, _ := strconv.ParseInt(data[fieldLikeCnt], 10, 64)
 	readCnt, _ := strconv.ParseInt(data[fieldReadCnt], 10, 64)
+	commentCnt, _ := strconv.ParseInt(data[fieldCommentCnt], 10, 64)
 
 	return domain.Interactive{
 		BizId:      bizId,
@@ -89,6 +106,7 @@ func (r *RedisInteractiveCache) Get(ctx context.Context, biz string, bizId int64
 		ReadCnt:    readCnt,
 		LikeCnt:    likeCnt,
 		CollectCnt: collectCnt,
+		CommentCnt: commentCnt,
 	}, nil
 }
 
@@ -97,13 +115,18 @@ func (r *RedisInteractiveCache) Set(ctx context.Context, biz string, bizId int64
 	err := r.client.HMSet(ctx, key,
 		fieldLikeCnt, intr.LikeCnt,
 		fieldReadCnt, intr.ReadCnt,
-		fieldCollectCnt, intr.CollectCnt).Err()
+		fieldCollectCnt, intr.CollectCnt,
+		fieldCommentCnt, intr.CommentCnt).Err()
 	if err != nil {
 		return err
 	}
 	return r.client.Expire(ctx, key, time.Minute*15).Err()
 }
 
+func (r *RedisInteractiveCache) Delete(ctx context.Context, biz string, bizId int64) error {
+	return r.client.Del(ctx, r.key(biz, bizId)).Err()
+}
+
 func (r *RedisInteractiveCache) key(biz string, bizId int64) string {
 	return fmt.Sprintf("interactive:%s:%d", biz, bizId)
 }
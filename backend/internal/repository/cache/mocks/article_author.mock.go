@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backend/internal/repository/cache/article_author.go
+
+// Package cachemocks is a generated GoMock package.
+package cachemocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/johnwongx/webook/backend/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleAuthorCache is a mock of ArticleAuthorCache interface.
+type MockArticleAuthorCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleAuthorCacheMockRecorder
+}
+
+// MockArticleAuthorCacheMockRecorder is the mock recorder for MockArticleAuthorCache.
+type MockArticleAuthorCacheMockRecorder struct {
+	mock *MockArticleAuthorCache
+}
+
+// NewMockArticleAuthorCache creates a new mock instance.
+func NewMockArticleAuthorCache(ctrl *gomock.Controller) *MockArticleAuthorCache {
+	mock := &MockArticleAuthorCache{ctrl: ctrl}
+	mock.recorder = &MockArticleAuthorCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleAuthorCache) EXPECT() *MockArticleAuthorCacheMockRecorder {
+	return m.recorder
+}
+
+// DeleteFirstPage mocks base method.
+func (m *MockArticleAuthorCache) DeleteFirstPage(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFirstPage", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFirstPage indicates an expected call of DeleteFirstPage.
+func (mr *MockArticleAuthorCacheMockRecorder) DeleteFirstPage(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFirstPage", reflect.TypeOf((*MockArticleAuthorCache)(nil).DeleteFirstPage), ctx, uid)
+}
+
+// GetFirstPage mocks base method.
+func (m *MockArticleAuthorCache) GetFirstPage(ctx context.Context, uid int64) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFirstPage", ctx, uid)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFirstPage indicates an expected call of GetFirstPage.
+func (mr *MockArticleAuthorCacheMockRecorder) GetFirstPage(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFirstPage", reflect.TypeOf((*MockArticleAuthorCache)(nil).GetFirstPage), ctx, uid)
+}
+
+// GetPage mocks base method.
+func (m *MockArticleAuthorCache) GetPage(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", ctx, uid, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockArticleAuthorCacheMockRecorder) GetPage(ctx, uid, cursor, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockArticleAuthorCache)(nil).GetPage), ctx, uid, cursor, limit)
+}
+
+// SetFirstPage mocks base method.
+func (m *MockArticleAuthorCache) SetFirstPage(ctx context.Context, uid int64, arts []domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFirstPage", ctx, uid, arts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFirstPage indicates an expected call of SetFirstPage.
+func (mr *MockArticleAuthorCacheMockRecorder) SetFirstPage(ctx, uid, arts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFirstPage", reflect.TypeOf((*MockArticleAuthorCache)(nil).SetFirstPage), ctx, uid, arts)
+}
@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backend/internal/repository/cache/article_tag.go
+
+// Package cachemocks is a generated GoMock package.
+package cachemocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/johnwongx/webook/backend/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleTagCache is a mock of ArticleTagCache interface.
+type MockArticleTagCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleTagCacheMockRecorder
+}
+
+// MockArticleTagCacheMockRecorder is the mock recorder for MockArticleTagCache.
+type MockArticleTagCacheMockRecorder struct {
+	mock *MockArticleTagCache
+}
+
+// NewMockArticleTagCache creates a new mock instance.
+func NewMockArticleTagCache(ctrl *gomock.Controller) *MockArticleTagCache {
+	mock := &MockArticleTagCache{ctrl: ctrl}
+	mock.recorder = &MockArticleTagCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleTagCache) EXPECT() *MockArticleTagCacheMockRecorder {
+	return m.recorder
+}
+
+// DeleteFirstPage mocks base method.
+func (m *MockArticleTagCache) DeleteFirstPage(ctx context.Context, tags ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteFirstPage", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFirstPage indicates an expected call of DeleteFirstPage.
+func (mr *MockArticleTagCacheMockRecorder) DeleteFirstPage(ctx interface{}, tags ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFirstPage", reflect.TypeOf((*MockArticleTagCache)(nil).DeleteFirstPage), varargs...)
+}
+
+// DeleteTagCounts mocks base method.
+func (m *MockArticleTagCache) DeleteTagCounts(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTagCounts", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTagCounts indicates an expected call of DeleteTagCounts.
+func (mr *MockArticleTagCacheMockRecorder) DeleteTagCounts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTagCounts", reflect.TypeOf((*MockArticleTagCache)(nil).DeleteTagCounts), ctx)
+}
+
+// GetFirstPage mocks base method.
+func (m *MockArticleTagCache) GetFirstPage(ctx context.Context, tag string) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFirstPage", ctx, tag)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFirstPage indicates an expected call of GetFirstPage.
+func (mr *MockArticleTagCacheMockRecorder) GetFirstPage(ctx, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFirstPage", reflect.TypeOf((*MockArticleTagCache)(nil).GetFirstPage), ctx, tag)
+}
+
+// GetTagCounts mocks base method.
+func (m *MockArticleTagCache) GetTagCounts(ctx context.Context) ([]domain.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagCounts", ctx)
+	ret0, _ := ret[0].([]domain.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagCounts indicates an expected call of GetTagCounts.
+func (mr *MockArticleTagCacheMockRecorder) GetTagCounts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagCounts", reflect.TypeOf((*MockArticleTagCache)(nil).GetTagCounts), ctx)
+}
+
+// SetFirstPage mocks base method.
+func (m *MockArticleTagCache) SetFirstPage(ctx context.Context, tag string, arts []domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFirstPage", ctx, tag, arts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFirstPage indicates an expected call of SetFirstPage.
+func (mr *MockArticleTagCacheMockRecorder) SetFirstPage(ctx, tag, arts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFirstPage", reflect.TypeOf((*MockArticleTagCache)(nil).SetFirstPage), ctx, tag, arts)
+}
+
+// SetTagCounts mocks base method.
+func (m *MockArticleTagCache) SetTagCounts(ctx context.Context, cnts []domain.TagCount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTagCounts", ctx, cnts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTagCounts indicates an expected call of SetTagCounts.
+func (mr *MockArticleTagCacheMockRecorder) SetTagCounts(ctx, cnts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTagCounts", reflect.TypeOf((*MockArticleTagCache)(nil).SetTagCounts), ctx, cnts)
+}
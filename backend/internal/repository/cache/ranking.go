@@ -0,0 +1,134 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var _ RankingCache = &RedisRankingCache{}
+
+type RankingCache interface {
+	// Set 整体替换热榜，arts 已经按分数从高到低排好序
+	Set(ctx context.Context, arts []domain.HotArticle) error
+	Get(ctx context.Context) ([]domain.HotArticle, error)
+}
+
+// RedisRankingCache 有序集合保存文章 id 和分数，哈希保存文章摘要
+type RedisRankingCache struct {
+	client     redis.Cmdable
+	key        string
+	detailKey  string
+	expiration time.Duration
+}
+
+func NewRedisRankingCache(client redis.Cmdable) RankingCache {
+	return &RedisRankingCache{
+		client:    client,
+		key:       "ranking:article",
+		detailKey: "ranking:article:detail",
+		// 比计算间隔长得多，任务偶尔失败时继续使用旧的热榜
+		expiration: time.Hour,
+	}
+}
+
+func (r *RedisRankingCache) Set(ctx context.Context, arts []domain.HotArticle) error {
+	members := make([]redis.Z, 0, len(arts))
+	details := make(map[string]any, len(arts))
+	for _, art := range arts {
+		bts, err := json.Marshal(art)
+		if err != nil {
+			return err
+		}
+		id := strconv.FormatInt(art.Article.Id, 10)
+		members = append(members, redis.Z{Score: art.Score, Member: id})
+		details[id] = bts
+	}
+	// 在事务里替换，读的人不会看到一半新一半旧的热榜
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, r.key, r.detailKey)
+		if len(arts) == 0 {
+			return nil
+		}
+		pipe.ZAdd(ctx, r.key, members...)
+		pipe.HSet(ctx, r.detailKey, details)
+		pipe.Expire(ctx, r.key, r.expiration)
+		pipe.Expire(ctx, r.detailKey, r.expiration)
+		return nil
+	})
+	return err
+}
+
+func (r *RedisRankingCache) Get(ctx context.Context) ([]domain.HotArticle, error) {
+	ids, err := r.client.ZRevRange(ctx, r.key, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, ErrKeyNotExisted
+	}
+	vals, err := r.client.HMGet(ctx, r.detailKey, ids...).Result()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.HotArticle, 0, len(vals))
+	for _, val := range vals {
+		str, ok := val.(string)
+		if !ok {
+			// 两个 key 是一起写入的，缺失说明正好过期了
+			return nil, ErrKeyNotExisted
+		}
+		var art domain.HotArticle
+		err = json.Unmarshal([]byte(str), &art)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, art)
+	}
+	return res, nil
+}
+
+// LocalRankingCache 本地缓存的热榜，Redis 不可用时兜底
+type LocalRankingCache struct {
+	lock       sync.RWMutex
+	arts       []domain.HotArticle
+	ddl        time.Time
+	expiration time.Duration
+}
+
+func NewLocalRankingCache() *LocalRankingCache {
+	return &LocalRankingCache{
+		expiration: time.Minute,
+	}
+}
+
+func (l *LocalRankingCache) Set(ctx context.Context, arts []domain.HotArticle) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.arts = arts
+	l.ddl = time.Now().Add(l.expiration)
+	return nil
+}
+
+func (l *LocalRankingCache) Get(ctx context.Context) ([]domain.HotArticle, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	if l.arts == nil || time.Now().After(l.ddl) {
+		return nil, ErrKeyNotExisted
+	}
+	return l.arts, nil
+}
+
+// ForceGet 忽略过期时间，只要有数据就返回
+func (l *LocalRankingCache) ForceGet(ctx context.Context) ([]domain.HotArticle, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	if l.arts == nil {
+		return nil, ErrKeyNotExisted
+	}
+	return l.arts, nil
+}
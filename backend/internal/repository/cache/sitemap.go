@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"context"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ SitemapCache = &RedisSitemapCache{}
+
+// SitemapCache 记录已发表的文章是否有变化，多个实例共享
+type SitemapCache interface {
+	MarkChanged(ctx context.Context) error
+	// TakeChanged 返回是否有变化并清除标记，只有一个调用方会拿到 true
+	TakeChanged(ctx context.Context) (bool, error)
+}
+
+type RedisSitemapCache struct {
+	client redis.Cmdable
+}
+
+func NewRedisSitemapCache(client redis.Cmdable) SitemapCache {
+	return &RedisSitemapCache{
+		client: client,
+	}
+}
+
+func (r *RedisSitemapCache) MarkChanged(ctx context.Context) error {
+	return r.client.Set(ctx, r.key(), 1, 0).Err()
+}
+
+func (r *RedisSitemapCache) TakeChanged(ctx context.Context) (bool, error) {
+	n, err := r.client.Del(ctx, r.key()).Result()
+	return n > 0, err
+}
+
+func (r *RedisSitemapCache) key() string {
+	return "sitemap:changed"
+}
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+var _ SyndicationCache = &RedisSyndicationCache{}
+
+// SyndicationCache 生成好的订阅源，uid 为 0 表示全站的订阅源
+type SyndicationCache interface {
+	Get(ctx context.Context, uid int64, format domain.SyndicationFormat) (domain.SyndicationFeed, error)
+	Set(ctx context.Context, uid int64, feed domain.SyndicationFeed) error
+	// Delete 作者已发表的文章变化后，清除作者和全站的所有格式的订阅源
+	Delete(ctx context.Context, uid int64) error
+}
+
+type RedisSyndicationCache struct {
+	client redis.Cmdable
+}
+
+func NewRedisSyndicationCache(client redis.Cmdable) SyndicationCache {
+	return &RedisSyndicationCache{
+		client: client,
+	}
+}
+
+func (r *RedisSyndicationCache) Get(ctx context.Context, uid int64, format domain.SyndicationFormat) (domain.SyndicationFeed, error) {
+	bts, err := r.client.Get(ctx, r.key(uid, format)).Bytes()
+	if err == redis.Nil {
+		return domain.SyndicationFeed{}, ErrKeyNotExisted
+	} else if err != nil {
+		return domain.SyndicationFeed{}, err
+	}
+	var res domain.SyndicationFeed
+	err = json.Unmarshal(bts, &res)
+	return res, err
+}
+
+func (r *RedisSyndicationCache) Set(ctx context.Context, uid int64, feed domain.SyndicationFeed) error {
+	bts, err := json.Marshal(feed)
+	if err != nil {
+		return err
+	}
+	// 作者改昵称等不会主动清除，靠过期时间兜底
+	return r.client.Set(ctx, r.key(uid, feed.Format), bts, time.Minute*30).Err()
+}
+
+func (r *RedisSyndicationCache) Delete(ctx context.Context, uid int64) error {
+	keys := []string{
+		r.key(0, domain.SyndicationFormatRSS),
+		r.key(0, domain.SyndicationFormatAtom),
+	}
+	if uid > 0 {
+		keys = append(keys,
+			r.key(uid, domain.SyndicationFormatRSS),
+			r.key(uid, domain.SyndicationFormatAtom))
+	}
+	return r.client.Del(ctx, keys...).Err()
+}
+
+func (r *RedisSyndicationCache) key(uid int64, format domain.SyndicationFormat) string {
+	return fmt.Sprintf("syndication:%d:%d", uid, format)
+}
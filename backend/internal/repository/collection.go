@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository/cache"
+	"github.com/johnwongx/webook/backend/internal/repository/dao"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"time"
+)
+
+var (
+	ErrCollectionNotFound  = dao.ErrCollectionNotFound
+	ErrCollectItemNotFound = dao.ErrDataNotFound
+)
+
+type CollectionRepository interface {
+	Create(ctx context.Context, c domain.Collection) (int64, error)
+	Rename(ctx context.Context, id, uid int64, name string) error
+	// Delete 收藏夹里的收藏一并删除
+	Delete(ctx context.Context, id, uid int64) error
+	FindById(ctx context.Context, id, uid int64) (domain.Collection, error)
+	List(ctx context.Context, uid int64, offset, limit int) ([]domain.Collection, error)
+	// ListItems 只有收藏本身的信息，不包含被收藏的对象
+	ListItems(ctx context.Context, cid, uid int64, offset, limit int) ([]domain.CollectionItem, error)
+	MoveItem(ctx context.Context, biz string, bizId, uid, cid int64) error
+}
+
+type collectionRepository struct {
+	d         dao.CollectionDAO
+	intrCache cache.InteractiveCache
+	l         logger.Logger
+}
+
+func NewCollectionRepository(d dao.CollectionDAO, intrCache cache.InteractiveCache,
+	l logger.Logger) CollectionRepository {
+	return &collectionRepository{
+		d:         d,
+		intrCache: intrCache,
+		l:         l,
+	}
+}
+
+func (c *collectionRepository) Create(ctx context.Context, col domain.Collection) (int64, error) {
+	return c.d.Insert(ctx, dao.Collection{
+		Name:   col.Name,
+		UserId: col.Uid,
+	})
+}
+
+func (c *collectionRepository) Rename(ctx context.Context, id, uid int64, name string) error {
+	return c.d.UpdateName(ctx, id, uid, name)
+}
+
+func (c *collectionRepository) Delete(ctx context.Context, id, uid int64) error {
+	items, err := c.d.Delete(ctx, id, uid)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		er := c.intrCache.DecrCollectCntIfPresent(ctx, item.Biz, item.BizId)
+		if er != nil {
+			c.l.Error("减少收藏数缓存失败",
+				logger.String("biz", item.Biz),
+				logger.Int64("bizId", item.BizId),
+				logger.Error(er))
+		}
+	}
+	return nil
+}
+
+func (c *collectionRepository) FindById(ctx context.Context, id, uid int64) (domain.Collection, error) {
+	col, err := c.d.FindById(ctx, id, uid)
+	if err != nil {
+		return domain.Collection{}, err
+	}
+	return c.toDomain(col), nil
+}
+
+func (c *collectionRepository) List(ctx context.Context, uid int64, offset, limit int) ([]domain.Collection, error) {
+	cols, err := c.d.FindByUid(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Collection, domain.Collection](cols,
+		func(idx int, src dao.Collection) domain.Collection {
+			return c.toDomain(src)
+		}), nil
+}
+
+func (c *collectionRepository) ListItems(ctx context.Context, cid, uid int64, offset, limit int) ([]domain.CollectionItem, error) {
+	items, err := c.d.FindItems(ctx, cid, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.UserCollectBiz, domain.CollectionItem](items,
+		func(idx int, src dao.UserCollectBiz) domain.CollectionItem {
+			return domain.CollectionItem{
+				Cid:   src.Cid,
+				Biz:   src.Biz,
+				BizId: src.BizId,
+				Ctime: time.UnixMilli(src.Ctime),
+			}
+		}), nil
+}
+
+func (c *collectionRepository) MoveItem(ctx context.Context, biz string, bizId, uid, cid int64) error {
+	return c.d.MoveItem(ctx, biz, bizId, uid, cid)
+}
+
+func (c *collectionRepository) toDomain(col dao.Collection) domain.Collection {
+	return domain.Collection{
+		Id:    col.Id,
+		Uid:   col.UserId,
+		Name:  col.Name,
+		Ctime: time.UnixMilli(col.Ctime),
+		Utime: time.UnixMilli(col.Utime),
+	}
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository/cache"
+	"github.com/johnwongx/webook/backend/internal/repository/dao"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"time"
+)
+
+var ErrCommentNotFound = dao.ErrCommentNotFound
+
+type CommentRepository interface {
+	Create(ctx context.Context, c domain.Comment) (int64, error)
+	FindById(ctx context.Context, id int64) (domain.Comment, error)
+	// FindRoots 根评论按时间倒序，每条根评论内联最早的几条回复
+	FindRoots(ctx context.Context, biz string, bizId int64, beforeId int64, limit int) ([]domain.Comment, error)
+	// FindReplies 根评论下 id 大于 afterId 的回复，按时间正序
+	FindReplies(ctx context.Context, rootId int64, afterId int64, limit int) ([]domain.Comment, error)
+	// Delete 删除评论以及回复它的评论，返回删除的条数
+	Delete(ctx context.Context, c domain.Comment) (int64, error)
+}
+
+type commentRepository struct {
+	d        dao.CommentDAO
+	cache    cache.CommentCache
+	userRepo UserRepository
+	l        logger.Logger
+	// replyLimit 每条根评论内联的回复数
+	replyLimit    int
+	firstPageSize int
+}
+
+func NewCommentRepository(d dao.CommentDAO, c cache.CommentCache, userRepo UserRepository,
+	l logger.Logger) CommentRepository {
+	return &commentRepository{
+		d:             d,
+		cache:         c,
+		userRepo:      userRepo,
+		l:             l,
+		replyLimit:    3,
+		firstPageSize: 50,
+	}
+}
+
+func (r *commentRepository) Create(ctx context.Context, c domain.Comment) (int64, error) {
+	now := time.Now().UnixMilli()
+	id, err := r.d.Insert(ctx, dao.Comment{
+		Uid:      c.Commentator.Id,
+		Biz:      c.Biz,
+		BizId:    c.BizId,
+		RootId:   c.RootId,
+		ParentId: c.ParentId,
+		Content:  c.Content,
+		Ctime:    now,
+		Utime:    now,
+	})
+	if err != nil {
+		return 0, err
+	}
+	r.clearCache(ctx, c.Biz, c.BizId)
+	return id, nil
+}
+
+func (r *commentRepository) FindById(ctx context.Context, id int64) (domain.Comment, error) {
+	c, err := r.d.FindById(ctx, id)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return r.toDomain(c), nil
+}
+
+func (r *commentRepository) FindRoots(ctx context.Context, biz string, bizId int64, beforeId int64, limit int) ([]domain.Comment, error) {
+	if beforeId == 0 && limit <= r.firstPageSize {
+		cs, err := r.cache.GetFirstPage(ctx, biz, bizId)
+		if err == nil {
+			if len(cs) > limit {
+				cs = cs[:limit]
+			}
+			return cs, nil
+		}
+		if err != cache.ErrKeyNotExisted {
+			r.l.Error("获取评论第一页缓存失败",
+				logger.String("biz", biz), logger.Int64("bizId", bizId), logger.Error(err))
+		}
+	}
+
+	// 第一页整页查出来，方便缓存
+	fill := beforeId == 0 && limit <= r.firstPageSize
+	qLimit := limit
+	if fill {
+		qLimit = r.firstPageSize
+	}
+	roots, err := r.d.FindRoots(ctx, biz, bizId, beforeId, qLimit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Comment, 0, len(roots))
+	for _, root := range roots {
+		c := r.toDomain(root)
+		replies, err := r.d.FindReplies(ctx, root.Id, 0, r.replyLimit)
+		if err != nil {
+			return nil, err
+		}
+		c.Children = slice.Map[dao.Comment, domain.Comment](replies, func(idx int, src dao.Comment) domain.Comment {
+			return r.toDomain(src)
+		})
+		res = append(res, c)
+	}
+	r.fillCommentators(ctx, res)
+
+	if fill {
+		go func() {
+			err := r.cache.SetFirstPage(ctx, biz, bizId, res)
+			if err != nil {
+				r.l.Error("缓存评论第一页失败",
+					logger.String("biz", biz), logger.Int64("bizId", bizId), logger.Error(err))
+			}
+		}()
+		if len(res) > limit {
+			res = res[:limit]
+		}
+	}
+	return res, nil
+}
+
+func (r *commentRepository) FindReplies(ctx context.Context, rootId int64, afterId int64, limit int) ([]domain.Comment, error) {
+	replies, err := r.d.FindReplies(ctx, rootId, afterId, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := slice.Map[dao.Comment, domain.Comment](replies, func(idx int, src dao.Comment) domain.Comment {
+		return r.toDomain(src)
+	})
+	r.fillCommentators(ctx, res)
+	return res, nil
+}
+
+func (r *commentRepository) Delete(ctx context.Context, c domain.Comment) (int64, error) {
+	cnt, err := r.d.Delete(ctx, dao.Comment{
+		Id:     c.Id,
+		RootId: c.RootId,
+	})
+	if err != nil {
+		return 0, err
+	}
+	r.clearCache(ctx, c.Biz, c.BizId)
+	return cnt, nil
+}
+
+// fillCommentators 补充评论人昵称，包括内联的回复，同一个人只查一次
+func (r *commentRepository) fillCommentators(ctx context.Context, cs []domain.Comment) {
+	names := make(map[int64]string)
+	var fill func(cs []domain.Comment)
+	fill = func(cs []domain.Comment) {
+		for i := range cs {
+			uid := cs[i].Commentator.Id
+			name, ok := names[uid]
+			if !ok {
+				user, err := r.userRepo.FindById(ctx, uid)
+				if err != nil {
+					r.l.Error("获取用户信息失败",
+						logger.Int64("uid", uid), logger.Error(err))
+				}
+				name = user.NickName
+				names[uid] = name
+			}
+			cs[i].Commentator.Name = name
+			fill(cs[i].Children)
+		}
+	}
+	fill(cs)
+}
+
+func (r *commentRepository) clearCache(ctx context.Context, biz string, bizId int64) {
+	err := r.cache.DeleteFirstPage(ctx, biz, bizId)
+	if err != nil {
+		r.l.Error("清除评论第一页缓存失败",
+			logger.String("biz", biz), logger.Int64("bizId", bizId), logger.Error(err))
+	}
+}
+
+func (r *commentRepository) toDomain(c dao.Comment) domain.Comment {
+	return domain.Comment{
+		Id:    c.Id,
+		Biz:   c.Biz,
+		BizId: c.BizId,
+		Commentator: domain.Author{
+			Id: c.Uid,
+		},
+		Content:  c.Content,
+		RootId:   c.RootId,
+		ParentId: c.ParentId,
+		Ctime:    time.UnixMilli(c.Ctime),
+		Utime:    time.UnixMilli(c.Utime),
+	}
+}
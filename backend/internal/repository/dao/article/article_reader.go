@@ -3,10 +3,87 @@ package article
 import (
 	"context"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
 )
 
+// ReaderArticleDAO 读者侧的读模型，由作者侧线上库中没有删除的文章投影而来，
+// 可以放在单独的库里。读者看文章详情只查这里，不再关联标签、分类和渲染结果
 type ReaderArticleDAO interface {
-	Upsert(ctx context.Context, art Article) error
+	// Upsert 用作者侧的数据整体覆盖
+	Upsert(ctx context.Context, art ReaderArticle) error
+	Delete(ctx context.Context, ids []int64) error
+	// DeleteExcept 删除 id 在 (startId, endId] 中且不在 keep 里的文章，用于重放时清理作者侧已经没有的文章
+	DeleteExcept(ctx context.Context, startId, endId int64, keep []int64) error
+	FindById(ctx context.Context, id int64) (ReaderArticle, error)
+}
+
+// ReaderArticle 一行就是文章详情需要的全部数据
+type ReaderArticle struct {
+	Id       int64  `gorm:"primaryKey,autoIncrement:false"`
+	Title    string `gorm:"type:varchar(4096)"`
+	Content  string `gorm:"type:BLOB"`
+	AuthorId int64  `gorm:"index"`
+	Status   uint8
+	Category string   `gorm:"type:varchar(64)"`
+	Tags     []string `gorm:"type:TEXT;serializer:json"`
+
+	HTML       string `gorm:"type:MEDIUMTEXT"`
+	TOC        string `gorm:"type:TEXT"`
+	FirstImage string `gorm:"type:varchar(1024)"`
+	Text       string `gorm:"type:MEDIUMTEXT"`
+
+	Ctime int64
+	Utime int64
+	// Ptime 投影到读者侧的时间
+	Ptime int64
+}
+
+// NewReaderArticle 把作者侧带标签和渲染结果的线上库文章展开成一行
+func NewReaderArticle(pub PublishArticle) ReaderArticle {
+	res := ReaderArticle{
+		Id:       pub.Id,
+		Title:    pub.Title,
+		Content:  pub.Content,
+		AuthorId: pub.AuthorId,
+		Status:   pub.Status,
+		Category: pub.Category,
+		Tags:     pub.Tags,
+		Ctime:    pub.Ctime,
+		Utime:    pub.Utime,
+	}
+	if r := pub.Render; r != nil {
+		res.HTML = r.HTML
+		res.TOC = r.TOC
+		res.FirstImage = r.FirstImage
+		res.Text = r.Text
+	}
+	return res
+}
+
+// Publish 还原成作者侧线上库的形式，方便和作者侧共用转换逻辑
+func (r ReaderArticle) Publish() PublishArticle {
+	res := PublishArticle{
+		Id:       r.Id,
+		Title:    r.Title,
+		Content:  r.Content,
+		AuthorId: r.AuthorId,
+		Status:   r.Status,
+		Category: r.Category,
+		Tags:     r.Tags,
+		Ctime:    r.Ctime,
+		Utime:    r.Utime,
+	}
+	if r.HTML != "" {
+		res.Render = &PublishArticleRender{
+			Id:         r.Id,
+			HTML:       r.HTML,
+			TOC:        r.TOC,
+			FirstImage: r.FirstImage,
+			Text:       r.Text,
+		}
+	}
+	return res
 }
 
 type GORMReaderArticleDAO struct {
@@ -19,8 +96,33 @@ func NewGORMReaderArticleDAO(db *gorm.DB) ReaderArticleDAO {
 	}
 }
 
-func (g *GORMReaderArticleDAO) Upsert(ctx context.Context, art Article) error {
-	//TODO implement me
-	panic("implement me")
-	return nil
+func (g *GORMReaderArticleDAO) Upsert(ctx context.Context, art ReaderArticle) error {
+	art.Ptime = time.Now().UnixMilli()
+	if art.Tags == nil {
+		art.Tags = []string{}
+	}
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(&art).Error
+}
+
+func (g *GORMReaderArticleDAO) Delete(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return g.db.WithContext(ctx).Where("id IN ?", ids).Delete(&ReaderArticle{}).Error
+}
+
+func (g *GORMReaderArticleDAO) DeleteExcept(ctx context.Context, startId, endId int64, keep []int64) error {
+	db := g.db.WithContext(ctx).Where("id > ? AND id <= ?", startId, endId)
+	if len(keep) > 0 {
+		db = db.Where("id NOT IN ?", keep)
+	}
+	return db.Delete(&ReaderArticle{}).Error
+}
+
+func (g *GORMReaderArticleDAO) FindById(ctx context.Context, id int64) (ReaderArticle, error) {
+	var art ReaderArticle
+	err := g.db.WithContext(ctx).Where("id = ?", id).First(&art).Error
+	return art, err
 }
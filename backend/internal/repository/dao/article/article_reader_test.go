@@ -0,0 +1,89 @@
+package article
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestReaderArticle_Publish(t *testing.T) {
+	pub := PublishArticle{
+		Id:       1,
+		Title:    "标题",
+		Content:  "# 内容",
+		AuthorId: 123,
+		Status:   2,
+		Category: "后端",
+		Tags:     []string{"go"},
+		Ctime:    10,
+		Utime:    20,
+		Render: &PublishArticleRender{
+			Id:   1,
+			HTML: "<h1>内容</h1>",
+			TOC:  "[]",
+			Text: "内容",
+		},
+	}
+	assert.Equal(t, pub, NewReaderArticle(pub).Publish())
+
+	// 没有渲染结果时不凭空生成
+	pub.Render = nil
+	assert.Equal(t, pub, NewReaderArticle(pub).Publish())
+}
+
+func TestGORMReaderArticleDAO_DeleteExcept(t *testing.T) {
+	testCases := []struct {
+		name  string
+		mock  func(t *testing.T) *sql.DB
+		keep  []int64
+		endId int64
+	}{
+		{
+			name: "保留作者侧还有的文章",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("DELETE FROM `reader_articles` WHERE \\(id > \\? AND id <= \\?\\) AND id NOT IN \\(\\?,\\?\\)").
+					WithArgs(10, 20, 11, 15).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				return mockDB
+			},
+			keep:  []int64{11, 15},
+			endId: 20,
+		},
+		{
+			name: "作者侧这一段没有文章",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("DELETE FROM `reader_articles` WHERE id > \\? AND id <= \\?$").
+					WithArgs(10, 20).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				return mockDB
+			},
+			endId: 20,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      tc.mock(t),
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			d := NewGORMReaderArticleDAO(db)
+			err = d.DeleteExcept(context.Background(), 10, tc.endId, tc.keep)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package article
+
+import (
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"math"
+)
+
+// ArticlePair 同一篇文章在制作库和线上库中的记录，用于检查两边是否一致
+type ArticlePair struct {
+	Id int64
+	// Draft 制作库，为 nil 表示制作库中已经没有这篇文章
+	Draft *Article
+	// Pub 线上库，为 nil 表示没有发表过
+	Pub *PublishArticle
+	// PubContentStored 线上库是否保存了内容，内容放在对象存储中时撤回后就不再保存
+	PubContentStored bool
+	// ObjectMissing 已发表的文章在对象存储中没有内容
+	ObjectMissing bool
+	// ObjectStale 已撤回或删除的文章在对象存储中还有内容
+	ObjectStale bool
+}
+
+// pairArticles 把两边按 id 升序查出的记录按 id 配对。一边查满了 limit 条时，
+// 另一边 id 更大的记录还没有查全，只返回两边都已经查到的部分
+func pairArticles(drafts []Article, pubs []PublishArticle, limit int) []ArticlePair {
+	maxId := int64(math.MaxInt64)
+	if len(drafts) >= limit && len(drafts) > 0 {
+		maxId = drafts[len(drafts)-1].Id
+	}
+	if len(pubs) >= limit && len(pubs) > 0 && pubs[len(pubs)-1].Id < maxId {
+		maxId = pubs[len(pubs)-1].Id
+	}
+
+	res := make([]ArticlePair, 0, len(drafts))
+	i, j := 0, 0
+	for i < len(drafts) || j < len(pubs) {
+		var p ArticlePair
+		switch {
+		case j >= len(pubs) || i < len(drafts) && drafts[i].Id < pubs[j].Id:
+			p = ArticlePair{Id: drafts[i].Id, Draft: &drafts[i]}
+			i++
+		case i >= len(drafts) || pubs[j].Id < drafts[i].Id:
+			p = ArticlePair{Id: pubs[j].Id, Pub: &pubs[j]}
+			j++
+		default:
+			p = ArticlePair{Id: drafts[i].Id, Draft: &drafts[i], Pub: &pubs[j]}
+			i++
+			j++
+		}
+		if p.Id > maxId {
+			break
+		}
+		p.PubContentStored = p.Pub != nil
+		res = append(res, p)
+	}
+	return res
+}
+
+// repairedPub 以制作库为准推算线上库应有的记录，不包括渲染结果，pub 为线上库现有的记录。
+// 返回 nil 表示线上库不需要这篇文章的记录
+func repairedPub(draft Article, pub *PublishArticle, now int64) *PublishArticle {
+	switch {
+	case draft.Dtime > 0:
+		// 删除时线上库跟着删除，没有发表过的文章线上库里没有记录
+		if pub == nil {
+			return nil
+		}
+		res := *pub
+		res.Status = domain.ArticleStatusDeleted.ToUint8()
+		res.Dtime = draft.Dtime
+		return &res
+	case draft.Status == domain.ArticleStatusPublished.ToUint8() ||
+		draft.Status == domain.ArticleStatusPrivate.ToUint8():
+		res := PublishArticle(draft)
+		res.Version = 0
+		res.Render = nil
+		res.Ctime = now
+		if pub != nil {
+			res.Ctime = pub.Ctime
+		}
+		res.Utime = now
+		return &res
+	default:
+		// 草稿在修改或审核中，线上库保持上一次发表的内容；
+		// 从回收站恢复的文章线上库是仅自己可见
+		if pub == nil {
+			return nil
+		}
+		res := *pub
+		if res.Dtime > 0 {
+			res.Status = domain.ArticleStatusPrivate.ToUint8()
+			res.Dtime = 0
+			res.Utime = now
+		}
+		return &res
+	}
+}
@@ -0,0 +1,121 @@
+package article
+
+import (
+	"testing"
+
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPairArticles(t *testing.T) {
+	ids := func(pairs []ArticlePair) [][3]int64 {
+		res := make([][3]int64, 0, len(pairs))
+		for _, p := range pairs {
+			var d, pub int64
+			if p.Draft != nil {
+				d = p.Draft.Id
+			}
+			if p.Pub != nil {
+				pub = p.Pub.Id
+			}
+			res = append(res, [3]int64{p.Id, d, pub})
+		}
+		return res
+	}
+	testCases := []struct {
+		name   string
+		drafts []Article
+		pubs   []PublishArticle
+		limit  int
+		want   [][3]int64
+	}{
+		{
+			name:   "两边都没查满，全部配对",
+			drafts: []Article{{Id: 1}, {Id: 2}, {Id: 4}},
+			pubs:   []PublishArticle{{Id: 2}, {Id: 3}},
+			limit:  10,
+			want:   [][3]int64{{1, 1, 0}, {2, 2, 2}, {3, 0, 3}, {4, 4, 0}},
+		},
+		{
+			name:   "制作库查满，线上库多出来的留到下一批",
+			drafts: []Article{{Id: 1}, {Id: 2}},
+			pubs:   []PublishArticle{{Id: 2}, {Id: 5}},
+			limit:  2,
+			want:   [][3]int64{{1, 1, 0}, {2, 2, 2}},
+		},
+		{
+			name:   "两边都查满，以较小的为界",
+			drafts: []Article{{Id: 1}, {Id: 6}},
+			pubs:   []PublishArticle{{Id: 3}, {Id: 4}},
+			limit:  2,
+			want:   [][3]int64{{1, 1, 0}, {3, 0, 3}, {4, 0, 4}},
+		},
+		{
+			name:  "没有数据",
+			limit: 2,
+			want:  [][3]int64{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pairs := pairArticles(tc.drafts, tc.pubs, tc.limit)
+			assert.Equal(t, tc.want, ids(pairs))
+			for _, p := range pairs {
+				assert.Equal(t, p.Pub != nil, p.PubContentStored)
+			}
+		})
+	}
+}
+
+func TestRepairedPub(t *testing.T) {
+	const now = 1000
+	published := domain.ArticleStatusPublished.ToUint8()
+	private := domain.ArticleStatusPrivate.ToUint8()
+	testCases := []struct {
+		name  string
+		draft Article
+		pub   *PublishArticle
+		want  *PublishArticle
+	}{
+		{
+			name:  "已发表，以制作库为准",
+			draft: Article{Id: 1, Title: "新标题", Content: "新内容", Status: published, Version: 3, Tags: []string{"go"}},
+			pub:   &PublishArticle{Id: 1, Title: "旧标题", Content: "旧内容", Status: private, Ctime: 10, Utime: 20},
+			want: &PublishArticle{Id: 1, Title: "新标题", Content: "新内容", Status: published,
+				Tags: []string{"go"}, Ctime: 10, Utime: now},
+		},
+		{
+			name:  "已发表，线上库丢失",
+			draft: Article{Id: 1, Title: "标题", Status: published, Version: 3},
+			want:  &PublishArticle{Id: 1, Title: "标题", Status: published, Ctime: now, Utime: now},
+		},
+		{
+			name:  "制作库已删除",
+			draft: Article{Id: 1, Status: domain.ArticleStatusDeleted.ToUint8(), Dtime: 500},
+			pub:   &PublishArticle{Id: 1, Title: "标题", Status: published, Utime: 20},
+			want: &PublishArticle{Id: 1, Title: "标题", Status: domain.ArticleStatusDeleted.ToUint8(),
+				Dtime: 500, Utime: 20},
+		},
+		{
+			name:  "没有发表过的文章删除后不需要线上库",
+			draft: Article{Id: 1, Status: domain.ArticleStatusDeleted.ToUint8(), Dtime: 500},
+		},
+		{
+			name:  "草稿修改中，保持线上库",
+			draft: Article{Id: 1, Title: "修改中", Status: domain.ArticleStatusUnpublished.ToUint8()},
+			pub:   &PublishArticle{Id: 1, Title: "标题", Status: published, Utime: 20},
+			want:  &PublishArticle{Id: 1, Title: "标题", Status: published, Utime: 20},
+		},
+		{
+			name:  "草稿已恢复，线上库还在回收站",
+			draft: Article{Id: 1, Status: domain.ArticleStatusUnpublished.ToUint8()},
+			pub:   &PublishArticle{Id: 1, Title: "标题", Status: domain.ArticleStatusDeleted.ToUint8(), Dtime: 500},
+			want:  &PublishArticle{Id: 1, Title: "标题", Status: private, Utime: now},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, repairedPub(tc.draft, tc.pub, now))
+		})
+	}
+}
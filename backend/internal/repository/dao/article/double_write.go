@@ -0,0 +1,333 @@
+package article
+
+import (
+	"context"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"sync/atomic"
+)
+
+var _ ArticleDAO = &DoubleWriteDAO{}
+
+// DoubleWriteDAO 在两种存储之间在线迁移文章，按阶段决定读写哪一边。
+// 双写时先写主存储，成功后把主存储中这篇文章的快照原样复制到另一边，
+// 两边的 id、版本号和时间完全一致；复制失败只记日志，由校验修复
+type DoubleWriteDAO struct {
+	src   MigratableDAO
+	dst   MigratableDAO
+	stage atomic.Uint32
+	l     logger.Logger
+}
+
+func NewDoubleWriteDAO(src, dst MigratableDAO, l logger.Logger) *DoubleWriteDAO {
+	return &DoubleWriteDAO{
+		src: src,
+		dst: dst,
+		l:   l,
+	}
+}
+
+func (d *DoubleWriteDAO) Stage() domain.ArticleMigrationStage {
+	return domain.ArticleMigrationStage(d.stage.Load())
+}
+
+func (d *DoubleWriteDAO) SetStage(stage domain.ArticleMigrationStage) {
+	old := domain.ArticleMigrationStage(d.stage.Swap(uint32(stage)))
+	if old != stage {
+		d.l.Info("切换文章存储迁移阶段",
+			logger.String("from", old.String()), logger.String("to", stage.String()))
+	}
+}
+
+// targets 当前阶段的主存储，以及需要同步的另一边，不双写时 secondary 为 nil
+func (d *DoubleWriteDAO) targets() (primary, secondary MigratableDAO) {
+	stage := d.Stage()
+	primary, secondary = d.src, d.dst
+	if !stage.SrcPrimary() {
+		primary, secondary = d.dst, d.src
+	}
+	if !stage.DoubleWrite() {
+		secondary = nil
+	}
+	return
+}
+
+func (d *DoubleWriteDAO) primary() MigratableDAO {
+	p, _ := d.targets()
+	return p
+}
+
+// replicate 把主存储中文章的最新状态复制到另一边
+func (d *DoubleWriteDAO) replicate(ctx context.Context, primary, secondary MigratableDAO, id int64) {
+	if secondary == nil || id <= 0 {
+		return
+	}
+	_, err := copyArticle(ctx, primary, secondary, id)
+	if err != nil {
+		d.l.Error("双写文章失败，等待校验修复",
+			logger.Int64("id", id), logger.Error(err))
+	}
+}
+
+// copyArticle 用 from 中的快照覆盖 to，返回覆盖前两边是否不一致
+func copyArticle(ctx context.Context, from, to MigratableDAO, id int64) (bool, error) {
+	s, err := from.Snapshot(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	old, err := to.Snapshot(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if s.Equal(old) {
+		return false, nil
+	}
+	return true, to.ApplySnapshot(ctx, s)
+}
+
+func (d *DoubleWriteDAO) Insert(ctx context.Context, art Article) (int64, error) {
+	p, s := d.targets()
+	id, err := p.Insert(ctx, art)
+	if err == nil {
+		d.replicate(ctx, p, s, id)
+	}
+	return id, err
+}
+
+func (d *DoubleWriteDAO) UpdateById(ctx context.Context, art Article) error {
+	p, s := d.targets()
+	err := p.UpdateById(ctx, art)
+	if err == nil {
+		d.replicate(ctx, p, s, art.Id)
+	}
+	return err
+}
+
+func (d *DoubleWriteDAO) Sync(ctx context.Context, art Article) (int64, error) {
+	p, s := d.targets()
+	id, err := p.Sync(ctx, art)
+	if err == nil {
+		d.replicate(ctx, p, s, id)
+	}
+	return id, err
+}
+
+func (d *DoubleWriteDAO) Upsert(ctx context.Context, art PublishArticle) error {
+	p, s := d.targets()
+	err := p.Upsert(ctx, art)
+	if err == nil {
+		d.replicate(ctx, p, s, art.Id)
+	}
+	return err
+}
+
+func (d *DoubleWriteDAO) SyncStatus(ctx context.Context, id, usrId int64, status uint8) error {
+	p, s := d.targets()
+	err := p.SyncStatus(ctx, id, usrId, status)
+	if err == nil {
+		d.replicate(ctx, p, s, id)
+	}
+	return err
+}
+
+func (d *DoubleWriteDAO) RepairPub(ctx context.Context, id, version int64, render *PublishArticleRender) error {
+	p, s := d.targets()
+	err := p.RepairPub(ctx, id, version, render)
+	if err == nil {
+		d.replicate(ctx, p, s, id)
+	}
+	return err
+}
+
+func (d *DoubleWriteDAO) Delete(ctx context.Context, id, uid int64) error {
+	p, s := d.targets()
+	err := p.Delete(ctx, id, uid)
+	if err == nil {
+		d.replicate(ctx, p, s, id)
+	}
+	return err
+}
+
+func (d *DoubleWriteDAO) Restore(ctx context.Context, id, uid int64, since int64) error {
+	p, s := d.targets()
+	err := p.Restore(ctx, id, uid, since)
+	if err == nil {
+		d.replicate(ctx, p, s, id)
+	}
+	return err
+}
+
+func (d *DoubleWriteDAO) Purge(ctx context.Context, ids []int64) error {
+	p, s := d.targets()
+	err := p.Purge(ctx, ids)
+	if err == nil && s != nil {
+		er := s.Purge(ctx, ids)
+		if er != nil {
+			d.l.Error("双写彻底删除文章失败，等待校验修复", logger.Error(er))
+		}
+	}
+	return err
+}
+
+func (d *DoubleWriteDAO) TransitStatus(ctx context.Context, id int64, from, to uint8) error {
+	p, s := d.targets()
+	err := p.TransitStatus(ctx, id, from, to)
+	if err == nil {
+		d.replicate(ctx, p, s, id)
+	}
+	return err
+}
+
+func (d *DoubleWriteDAO) GetByAuthor(ctx context.Context, uid int64, cursorUtime, cursorId int64, limit int) ([]Article, error) {
+	return d.primary().GetByAuthor(ctx, uid, cursorUtime, cursorId, limit)
+}
+
+func (d *DoubleWriteDAO) FindById(ctx context.Context, id, uid int64) (Article, error) {
+	return d.primary().FindById(ctx, id, uid)
+}
+
+func (d *DoubleWriteDAO) FindPubById(ctx context.Context, id int64) (PublishArticle, error) {
+	return d.primary().FindPubById(ctx, id)
+}
+
+func (d *DoubleWriteDAO) ListPub(ctx context.Context, startId int64, limit int) ([]PublishArticle, error) {
+	return d.primary().ListPub(ctx, startId, limit)
+}
+
+func (d *DoubleWriteDAO) ScanPub(ctx context.Context, startId int64, limit int) ([]PublishArticle, error) {
+	return d.primary().ScanPub(ctx, startId, limit)
+}
+
+func (d *DoubleWriteDAO) ScanPairs(ctx context.Context, startId int64, limit int) ([]ArticlePair, error) {
+	return d.primary().ScanPairs(ctx, startId, limit)
+}
+
+func (d *DoubleWriteDAO) ListPubByAuthor(ctx context.Context, uid int64, cursorUtime, cursorId int64, limit int) ([]PublishArticle, error) {
+	return d.primary().ListPubByAuthor(ctx, uid, cursorUtime, cursorId, limit)
+}
+
+func (d *DoubleWriteDAO) ListPubByAuthors(ctx context.Context, uids []int64, cursorUtime, cursorId int64, limit int) ([]PublishArticle, error) {
+	return d.primary().ListPubByAuthors(ctx, uids, cursorUtime, cursorId, limit)
+}
+
+func (d *DoubleWriteDAO) ListPubByTag(ctx context.Context, tag string, offset int, limit int) ([]PublishArticle, error) {
+	return d.primary().ListPubByTag(ctx, tag, offset, limit)
+}
+
+func (d *DoubleWriteDAO) ListLatestPub(ctx context.Context, limit int) ([]PublishArticle, error) {
+	return d.primary().ListLatestPub(ctx, limit)
+}
+
+func (d *DoubleWriteDAO) TagCounts(ctx context.Context) ([]TagCount, error) {
+	return d.primary().TagCounts(ctx)
+}
+
+func (d *DoubleWriteDAO) ListDeleted(ctx context.Context, uid int64, since int64, offset int, limit int) ([]Article, error) {
+	return d.primary().ListDeleted(ctx, uid, since, offset, limit)
+}
+
+func (d *DoubleWriteDAO) ListExpired(ctx context.Context, before int64, limit int) ([]Article, error) {
+	return d.primary().ListExpired(ctx, before, limit)
+}
+
+func (d *DoubleWriteDAO) ListByStatus(ctx context.Context, status uint8, offset int, limit int) ([]Article, error) {
+	return d.primary().ListByStatus(ctx, status, offset, limit)
+}
+
+func (d *DoubleWriteDAO) FindByStatus(ctx context.Context, id int64, status uint8) (Article, error) {
+	return d.primary().FindByStatus(ctx, id, status)
+}
+
+func (d *DoubleWriteDAO) ListRevisions(ctx context.Context, aid, uid int64, offset int, limit int) ([]ArticleRevision, error) {
+	return d.primary().ListRevisions(ctx, aid, uid, offset, limit)
+}
+
+func (d *DoubleWriteDAO) FindRevision(ctx context.Context, id, aid, uid int64) (ArticleRevision, error) {
+	return d.primary().FindRevision(ctx, id, aid, uid)
+}
+
+// Backfill 从 src 往 dst 全量复制一批文章，两边一致的跳过，
+// 返回这批最后一篇的 id 和实际复制的数量，没有更多时 done 为 true
+func (d *DoubleWriteDAO) Backfill(ctx context.Context, startId int64, limit int) (lastId int64, copied int, done bool, err error) {
+	ids, err := d.src.ScanIds(ctx, startId, 0, limit)
+	if err != nil {
+		return startId, 0, false, err
+	}
+	for _, id := range ids {
+		var diff bool
+		diff, err = copyArticle(ctx, d.src, d.dst, id)
+		if err != nil {
+			return startId, copied, false, err
+		}
+		if diff {
+			copied++
+		}
+		startId = id
+	}
+	return startId, copied, len(ids) < limit, nil
+}
+
+// Verify 校验一批 utime 不早于 since 的文章，两边不一致时以当前阶段的主存储为准修复另一边。
+// 两边的 id 都会扫描，只存在于另一边的文章会被删除。返回这批最后一篇的 id 和修复的数量
+func (d *DoubleWriteDAO) Verify(ctx context.Context, startId int64, since int64, limit int) (lastId int64, repaired int, done bool, err error) {
+	p, s := d.src, d.dst
+	if !d.Stage().SrcPrimary() {
+		p, s = d.dst, d.src
+	}
+	pIds, err := p.ScanIds(ctx, startId, since, limit)
+	if err != nil {
+		return startId, 0, false, err
+	}
+	sIds, err := s.ScanIds(ctx, startId, since, limit)
+	if err != nil {
+		return startId, 0, false, err
+	}
+	// 两边都可能还有下一批，只处理两边都扫描到的范围
+	var hi int64 = -1
+	if len(pIds) == limit {
+		hi = pIds[len(pIds)-1]
+	}
+	if len(sIds) == limit && (hi < 0 || sIds[len(sIds)-1] < hi) {
+		hi = sIds[len(sIds)-1]
+	}
+	ids := mergeIds(pIds, sIds, hi)
+	for _, id := range ids {
+		var diff bool
+		diff, err = copyArticle(ctx, p, s, id)
+		if err != nil {
+			return startId, repaired, false, err
+		}
+		if diff {
+			repaired++
+			d.l.Warn("文章两边存储不一致，已修复", logger.Int64("id", id))
+		}
+		startId = id
+	}
+	return startId, repaired, hi < 0, nil
+}
+
+// mergeIds 合并两个升序的 id 列表并去重，hi 不小于 0 时只保留不大于 hi 的
+func mergeIds(a, b []int64, hi int64) []int64 {
+	res := make([]int64, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		var id int64
+		switch {
+		case j >= len(b) || i < len(a) && a[i] < b[j]:
+			id = a[i]
+			i++
+		case i >= len(a) || b[j] < a[i]:
+			id = b[j]
+			j++
+		default:
+			id = a[i]
+			i++
+			j++
+		}
+		if hi >= 0 && id > hi {
+			break
+		}
+		res = append(res, id)
+	}
+	return res
+}
@@ -0,0 +1,183 @@
+package article
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryDAO 只实现迁移用到的方法，其余方法调用时会 panic
+type memoryDAO struct {
+	ArticleDAO
+	nextId int64
+	snaps  map[int64]ArticleSnapshot
+}
+
+func newMemoryDAO(nextId int64) *memoryDAO {
+	return &memoryDAO{nextId: nextId, snaps: map[int64]ArticleSnapshot{}}
+}
+
+func (m *memoryDAO) Insert(ctx context.Context, art Article) (int64, error) {
+	m.nextId++
+	art.Id = m.nextId
+	art.Version = 1
+	m.snaps[art.Id] = ArticleSnapshot{
+		Id:        art.Id,
+		Draft:     &art,
+		Revisions: []ArticleRevision{{Id: art.Id * 10, ArticleId: art.Id, Title: art.Title}},
+	}
+	return art.Id, nil
+}
+
+func (m *memoryDAO) FindById(ctx context.Context, id, uid int64) (Article, error) {
+	s, ok := m.snaps[id]
+	if !ok {
+		return Article{}, ErrArticleNotFound
+	}
+	return *s.Draft, nil
+}
+
+func (m *memoryDAO) Purge(ctx context.Context, ids []int64) error {
+	for _, id := range ids {
+		delete(m.snaps, id)
+	}
+	return nil
+}
+
+func (m *memoryDAO) Snapshot(ctx context.Context, id int64) (ArticleSnapshot, error) {
+	s, ok := m.snaps[id]
+	if !ok {
+		return ArticleSnapshot{Id: id}, nil
+	}
+	return s, nil
+}
+
+func (m *memoryDAO) ApplySnapshot(ctx context.Context, s ArticleSnapshot) error {
+	if s.Draft == nil {
+		delete(m.snaps, s.Id)
+		return nil
+	}
+	m.snaps[s.Id] = s
+	return nil
+}
+
+func (m *memoryDAO) ScanIds(ctx context.Context, startId int64, since int64, limit int) ([]int64, error) {
+	var ids []int64
+	for id, s := range m.snaps {
+		if id > startId && s.Draft.Utime >= since {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids, nil
+}
+
+func TestDoubleWriteDAO_Stage(t *testing.T) {
+	testCases := []struct {
+		name    string
+		stage   domain.ArticleMigrationStage
+		wantSrc bool
+		wantDst bool
+		// wantId 新文章的 id 由主存储生成
+		wantId int64
+	}{
+		{name: "只写源存储", stage: domain.ArticleMigrationStageSrcOnly, wantSrc: true, wantId: 1},
+		{name: "双写以源存储为准", stage: domain.ArticleMigrationStageSrcFirst, wantSrc: true, wantDst: true, wantId: 1},
+		{name: "双写以目标存储为准", stage: domain.ArticleMigrationStageDstFirst, wantSrc: true, wantDst: true, wantId: 1001},
+		{name: "只写目标存储", stage: domain.ArticleMigrationStageDstOnly, wantDst: true, wantId: 1001},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			src, dst := newMemoryDAO(0), newMemoryDAO(1000)
+			d := NewDoubleWriteDAO(src, dst, logger.NewNopLogger())
+			d.SetStage(tc.stage)
+
+			id, err := d.Insert(context.Background(), Article{Title: "标题", AuthorId: 123})
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantId, id)
+			_, ok := src.snaps[id]
+			assert.Equal(t, tc.wantSrc, ok)
+			_, ok = dst.snaps[id]
+			assert.Equal(t, tc.wantDst, ok)
+			if tc.wantSrc && tc.wantDst {
+				assert.True(t, src.snaps[id].Equal(dst.snaps[id]))
+			}
+
+			art, err := d.FindById(context.Background(), id, 123)
+			require.NoError(t, err)
+			assert.Equal(t, "标题", art.Title)
+		})
+	}
+}
+
+func TestDoubleWriteDAO_Verify(t *testing.T) {
+	src, dst := newMemoryDAO(0), newMemoryDAO(0)
+	d := NewDoubleWriteDAO(src, dst, logger.NewNopLogger())
+	d.SetStage(domain.ArticleMigrationStageSrcFirst)
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		_, err := d.Insert(ctx, Article{Title: "标题", Utime: int64(i)})
+		require.NoError(t, err)
+	}
+	// 目标存储上有一篇被改过、一篇缺失、一篇多余
+	changed := *dst.snaps[2].Draft
+	changed.Title = "被改过"
+	dst.snaps[2] = ArticleSnapshot{Id: 2, Draft: &changed}
+	delete(dst.snaps, 4)
+	extra := Article{Id: 9, Title: "多余", Utime: 9}
+	dst.snaps[9] = ArticleSnapshot{Id: 9, Draft: &extra}
+
+	var (
+		startId  int64
+		repaired int
+	)
+	for {
+		lastId, n, done, err := d.Verify(ctx, startId, 0, 2)
+		require.NoError(t, err)
+		repaired += n
+		startId = lastId
+		if done {
+			break
+		}
+	}
+	assert.Equal(t, 3, repaired)
+	assert.Len(t, dst.snaps, 5)
+	for id, s := range src.snaps {
+		assert.True(t, s.Equal(dst.snaps[id]), "文章 %d 不一致", id)
+	}
+}
+
+func TestDoubleWriteDAO_Backfill(t *testing.T) {
+	src, dst := newMemoryDAO(0), newMemoryDAO(0)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		_, err := src.Insert(ctx, Article{Title: "标题"})
+		require.NoError(t, err)
+	}
+	d := NewDoubleWriteDAO(src, dst, logger.NewNopLogger())
+	lastId, copied, done, err := d.Backfill(ctx, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), lastId)
+	assert.Equal(t, 2, copied)
+	assert.False(t, done)
+	lastId, copied, done, err = d.Backfill(ctx, lastId, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), lastId)
+	assert.Equal(t, 1, copied)
+	assert.True(t, done)
+	assert.Len(t, dst.snaps, 3)
+}
+
+func TestMergeIds(t *testing.T) {
+	assert.Equal(t, []int64{1, 2, 3, 5}, mergeIds([]int64{1, 3, 5}, []int64{2, 3}, -1))
+	assert.Equal(t, []int64{1, 2, 3}, mergeIds([]int64{1, 3, 5}, []int64{2, 3}, 3))
+	assert.Equal(t, []int64{}, mergeIds(nil, nil, -1))
+}
@@ -6,8 +6,71 @@ type Article struct {
 	Id       int64  `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
 	Title    string `gorm:"type=varchar(4096)" bson:"title,omitempty"`
 	Content  string `gorm:"type=BLOB" bson:"content,omitempty"`
-	AuthorId int64  `gorm:"index" bson:"author_id,omitempty"`
-	Status   uint8  `bson:"status,omitempty"`
+	AuthorId int64  `gorm:"index:author_utime,priority:1" bson:"author_id,omitempty"`
+	Status   uint8  `gorm:"index" bson:"status,omitempty"`
+	Version  int64  `bson:"version,omitempty"` // 乐观锁版本号，只有制作库使用
 	Ctime    int64  `bson:"ctime,omitempty"`
-	Utime    int64  `bson:"utime,omitempty"`
+	Utime    int64  `gorm:"index:author_utime,priority:2" bson:"utime,omitempty"`
+	// Dtime 移入回收站的时间，0 表示没有删除
+	Dtime int64 `gorm:"index" bson:"dtime,omitempty"`
+	// Abstract 发表时生成的纯文本摘要，保存草稿时清空
+	Abstract string `gorm:"type:varchar(1024)" bson:"abstract,omitempty"`
+
+	// MySQL 中标签、分类存放在单独的表里，MongoDB 直接内嵌在文档中
+	CategoryId int64    `gorm:"index" bson:"-"`
+	Category   string   `gorm:"-" bson:"category,omitempty"`
+	Tags       []string `gorm:"-" bson:"tags,omitempty"`
+	// Render 渲染结果单独存放，只在同步到线上库时写入
+	Render *PublishArticleRender `gorm:"-" bson:"-"`
+}
+
+// PublishArticleRender 发表时渲染的结果，和线上库的文章一一对应
+type PublishArticleRender struct {
+	// Id 即文章 id
+	Id         int64  `gorm:"primaryKey,autoIncrement:false" bson:"id,omitempty"`
+	HTML       string `gorm:"type:MEDIUMTEXT" bson:"html,omitempty"`
+	TOC        string `gorm:"type:TEXT" bson:"toc,omitempty"` // JSON 格式的目录
+	FirstImage string `gorm:"type:varchar(1024)" bson:"first_image,omitempty"`
+	Text       string `gorm:"type:MEDIUMTEXT" bson:"text,omitempty"`
+	Utime      int64  `bson:"utime,omitempty"`
+}
+
+type Tag struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	Name  string `gorm:"type:varchar(64);uniqueIndex"`
+	Ctime int64
+}
+
+type Category struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	Name  string `gorm:"type:varchar(64);uniqueIndex"`
+	Ctime int64
+}
+
+// ArticleTag 制作库中文章和标签的关联
+type ArticleTag struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	ArticleId int64 `gorm:"uniqueIndex:aid_tid"`
+	TagId     int64 `gorm:"uniqueIndex:aid_tid;index"`
+	Ctime     int64
+}
+
+// PublishArticleTag 线上库中文章和标签的关联
+type PublishArticleTag ArticleTag
+
+// TagCount 标签下已发表文章的数量
+type TagCount struct {
+	Name string
+	Cnt  int64
+}
+
+// ArticleRevision 文章历史版本，只追加不修改
+type ArticleRevision struct {
+	Id        int64  `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
+	ArticleId int64  `gorm:"index:aid_author" bson:"article_id,omitempty"`
+	Title     string `gorm:"type:varchar(4096)" bson:"title,omitempty"`
+	Content   string `gorm:"type:BLOB" bson:"content,omitempty"`
+	AuthorId  int64  `gorm:"index:aid_author" bson:"author_id,omitempty"`
+	Status    uint8  `bson:"status,omitempty"`
+	Ctime     int64  `bson:"ctime,omitempty"`
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/johnwongx/webook/backend/internal/domain"
 	"github.com/johnwongx/webook/backend/pkg/logger"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -26,29 +27,93 @@ func (g *GORMArticleDAO) Insert(ctx context.Context, art Article) (int64, error)
 	now := time.Now().UnixMilli()
 	art.Ctime = now
 	art.Utime = now
-	err := g.db.WithContext(ctx).Create(&art).Error
+	art.Version = 1
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		art.CategoryId, err = g.categoryId(tx, art.Category)
+		if err != nil {
+			return err
+		}
+		err = tx.Create(&art).Error
+		if err != nil {
+			return err
+		}
+		err = g.setTags(tx, art.Id, art.Tags, false)
+		if err != nil {
+			return err
+		}
+		return g.insertRevision(tx, art)
+	})
 	return art.Id, err
 }
 
 func (g *GORMArticleDAO) UpdateById(ctx context.Context, art Article) error {
 	now := time.Now().UnixMilli()
 	art.Utime = now
-	res := g.db.Model(&Article{}).WithContext(ctx).
-		Where("id=? AND author_id=?", art.Id, art.AuthorId).
-		Updates(map[string]any{
-			"title":   art.Title,
-			"content": art.Content,
-			"utime":   art.Utime,
-			"status":  art.Status,
-		})
-	err := res.Error
-	if err != nil {
-		return err
-	}
-	if res.RowsAffected == 0 {
-		return errors.New("更新数据失败")
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&Article{}).
+			Where("id=? AND author_id=? AND dtime = 0", art.Id, art.AuthorId)
+		if art.Version > 0 {
+			query = query.Where("version = ?", art.Version)
+		}
+		updates := map[string]any{
+			"title":    art.Title,
+			"content":  art.Content,
+			"utime":    art.Utime,
+			"status":   art.Status,
+			"abstract": art.Abstract,
+			"version":  gorm.Expr("`version`+1"),
+		}
+		if art.Category != "" {
+			cid, err := g.categoryId(tx, art.Category)
+			if err != nil {
+				return err
+			}
+			updates["category_id"] = cid
+		}
+		res := query.Updates(updates)
+		err := res.Error
+		if err != nil {
+			return err
+		}
+		if res.RowsAffected == 0 {
+			return g.updateFailedReason(tx, art)
+		}
+		err = g.setTags(tx, art.Id, art.Tags, false)
+		if err != nil {
+			return err
+		}
+		return g.insertRevision(tx, art)
+	})
+}
+
+// updateFailedReason 区分是版本号过期还是文章不存在、不属于该作者
+func (g *GORMArticleDAO) updateFailedReason(tx *gorm.DB, art Article) error {
+	if art.Version > 0 {
+		var cnt int64
+		err := tx.Model(&Article{}).
+			Where("id=? AND author_id=? AND dtime = 0", art.Id, art.AuthorId).
+			Count(&cnt).Error
+		if err != nil {
+			return err
+		}
+		if cnt > 0 {
+			return ErrVersionConflict
+		}
 	}
-	return nil
+	return errors.New("更新数据失败")
+}
+
+// insertRevision 每次写制作库都追加一个历史版本
+func (g *GORMArticleDAO) insertRevision(tx *gorm.DB, art Article) error {
+	return tx.Create(&ArticleRevision{
+		ArticleId: art.Id,
+		Title:     art.Title,
+		Content:   art.Content,
+		AuthorId:  art.AuthorId,
+		Status:    art.Status,
+		Ctime:     art.Utime,
+	}).Error
 }
 
 func (g *GORMArticleDAO) Sync(ctx context.Context, art Article) (int64, error) {
@@ -59,42 +124,103 @@ func (g *GORMArticleDAO) Sync(ctx context.Context, art Article) (int64, error) {
 
 	// 使用事物保证两张表同时成功或失败
 	err = g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txDAO := &GORMArticleDAO{db: tx, l: g.l}
 		// 更新制作库，插入或删除
 		if id > 0 {
-			err = g.UpdateById(ctx, art)
+			err = txDAO.UpdateById(ctx, art)
 		} else {
-			id, err = g.Insert(ctx, art)
+			id, err = txDAO.Insert(ctx, art)
 		}
 		if err != nil {
 			return err
 		}
 		// 更新数据到线上库
 		art.Id = id
-		return g.Upsert(ctx, PublishArticle(art))
+		err = txDAO.draftMeta(&art)
+		if err != nil {
+			return err
+		}
+		return txDAO.Upsert(ctx, PublishArticle(art))
 	})
 	return id, err
 }
 
+// draftMeta 标签、分类以制作库为准，同步到线上库
+func (g *GORMArticleDAO) draftMeta(art *Article) error {
+	var draft Article
+	err := g.db.Select("id", "category_id").
+		Where("id = ?", art.Id).
+		First(&draft).Error
+	if err != nil {
+		return err
+	}
+	err = g.loadMeta(g.db, &draft, false)
+	if err != nil {
+		return err
+	}
+	art.CategoryId = draft.CategoryId
+	art.Category = draft.Category
+	art.Tags = draft.Tags
+	return nil
+}
+
 func (g *GORMArticleDAO) Upsert(ctx context.Context, art PublishArticle) error {
 	now := time.Now().UnixMilli()
 	art.Ctime = now
 	art.Utime = now
-	return g.db.WithContext(ctx).
-		Clauses(clause.OnConflict{
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
 			DoUpdates: clause.Assignments(map[string]interface{}{
-				"title":   art.Title,
-				"content": art.Content,
-				"utime":   art.Utime,
-				"status":  art.Status,
+				"title":       art.Title,
+				"content":     art.Content,
+				"utime":       art.Utime,
+				"status":      art.Status,
+				"category_id": art.CategoryId,
+				"abstract":    art.Abstract,
 			}),
 		}).Create(&art).Error
+		if err != nil {
+			return err
+		}
+		err = g.upsertRender(tx, art.Id, art.Render)
+		if err != nil {
+			return err
+		}
+		return g.setTags(tx, art.Id, art.Tags, true)
+	})
+}
+
+// upsertRender r 为 nil 时保持原有的渲染结果
+func (g *GORMArticleDAO) upsertRender(tx *gorm.DB, id int64, r *PublishArticleRender) error {
+	if r == nil {
+		return nil
+	}
+	render := *r
+	render.Id = id
+	render.Utime = time.Now().UnixMilli()
+	return tx.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(&render).Error
+}
+
+// findRender 文章还没有渲染结果时返回 nil
+func (g *GORMArticleDAO) findRender(tx *gorm.DB, id int64) (*PublishArticleRender, error) {
+	var r PublishArticleRender
+	err := tx.Where("id = ?", id).First(&r).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
 }
 
 func (g *GORMArticleDAO) SyncStatus(ctx context.Context, id, usrId int64, status uint8) error {
 	now := time.Now().UnixMilli()
 	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		res := tx.Model(&Article{}).
-			Where("id = ? AND author_id = ?", id, usrId).
+			Where("id = ? AND author_id = ? AND dtime = 0", id, usrId).
 			Updates(map[string]any{
 				"status": status,
 				"utime":  now,
@@ -107,7 +233,7 @@ func (g *GORMArticleDAO) SyncStatus(ctx context.Context, id, usrId int64, status
 		}
 
 		return tx.Model(&PublishArticle{}).
-			Where("id = ?", id).
+			Where("id = ? AND dtime = 0", id).
 			Updates(map[string]any{
 				"status": status,
 				"utime":  now,
@@ -116,36 +242,268 @@ func (g *GORMArticleDAO) SyncStatus(ctx context.Context, id, usrId int64, status
 	return err
 }
 
-func (g *GORMArticleDAO) GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]Article, error) {
+func (g *GORMArticleDAO) GetByAuthor(ctx context.Context, uid int64, cursorUtime, cursorId int64, limit int) ([]Article, error) {
 	var arts []Article
-	err := g.db.WithContext(ctx).Model(&Article{}).
-		Where("author_id = ?", uid).
-		Offset(offset).
+	query := g.db.WithContext(ctx).Model(&Article{}).
+		Where("author_id = ? AND dtime = 0", uid)
+	if cursorId > 0 {
+		query = query.Where("utime < ? OR (utime = ? AND id < ?)", cursorUtime, cursorUtime, cursorId)
+	}
+	err := query.Order("utime DESC, id DESC").
+		Limit(limit).
+		Find(&arts).Error
+	return arts, err
+}
+
+func (g *GORMArticleDAO) ListPubByAuthor(ctx context.Context, uid int64, cursorUtime, cursorId int64, limit int) ([]PublishArticle, error) {
+	var arts []PublishArticle
+	query := g.db.WithContext(ctx).Model(&PublishArticle{}).
+		Where("author_id = ? AND status = ?", uid, domain.ArticleStatusPublished.ToUint8())
+	if cursorId > 0 {
+		query = query.Where("utime < ? OR (utime = ? AND id < ?)", cursorUtime, cursorUtime, cursorId)
+	}
+	err := query.Order("utime DESC, id DESC").
+		Limit(limit).
+		Find(&arts).Error
+	return arts, err
+}
+
+func (g *GORMArticleDAO) ListPubByAuthors(ctx context.Context, uids []int64, cursorUtime, cursorId int64, limit int) ([]PublishArticle, error) {
+	var arts []PublishArticle
+	query := g.db.WithContext(ctx).Model(&PublishArticle{}).
+		Where("author_id IN ? AND status = ?", uids, domain.ArticleStatusPublished.ToUint8())
+	if cursorId > 0 {
+		query = query.Where("utime < ? OR (utime = ? AND id < ?)", cursorUtime, cursorUtime, cursorId)
+	}
+	err := query.Order("utime DESC, id DESC").
 		Limit(limit).
-		Order("utime DESC").
-		Find(arts).Error
+		Find(&arts).Error
+	return arts, err
+}
+
+func (g *GORMArticleDAO) ListLatestPub(ctx context.Context, limit int) ([]PublishArticle, error) {
+	var arts []PublishArticle
+	err := g.db.WithContext(ctx).Model(&PublishArticle{}).
+		Where("status = ?", domain.ArticleStatusPublished.ToUint8()).
+		Order("utime DESC, id DESC").
+		Limit(limit).
+		Find(&arts).Error
 	return arts, err
 }
 
 func (g *GORMArticleDAO) FindById(ctx context.Context, id, uid int64) (Article, error) {
 	var art Article
 	err := g.db.WithContext(ctx).Model(&Article{}).
-		Where("id = ? AND author_id = ?", id, uid).
+		Where("id = ? AND author_id = ? AND dtime = 0", id, uid).
 		First(&art).Error
 	if err != nil {
 		g.l.Error(fmt.Sprintf("可能有人在攻击系统，误操作非自己的文章, id:%d, authorId:%d", id, uid), logger.Error(err))
 		return Article{}, err
 	}
-	return art, nil
+	err = g.loadMeta(g.db.WithContext(ctx), &art, false)
+	return art, err
 }
 
 func (g *GORMArticleDAO) FindPubById(ctx context.Context, id int64) (PublishArticle, error) {
 	var art PublishArticle
 	err := g.db.WithContext(ctx).Model(&PublishArticle{}).
-		Where("id = ?", id).
+		Where("id = ? AND dtime = 0", id).
 		First(&art).Error
 	if err != nil {
 		return PublishArticle{}, err
 	}
-	return art, nil
+	err = g.loadMeta(g.db.WithContext(ctx), (*Article)(&art), true)
+	if err != nil {
+		return PublishArticle{}, err
+	}
+	art.Render, err = g.findRender(g.db.WithContext(ctx), id)
+	return art, err
+}
+
+func (g *GORMArticleDAO) ListPub(ctx context.Context, startId int64, limit int) ([]PublishArticle, error) {
+	var arts []PublishArticle
+	err := g.db.WithContext(ctx).Model(&PublishArticle{}).
+		Where("id > ?", startId).
+		Order("id ASC").
+		Limit(limit).
+		Find(&arts).Error
+	return arts, err
+}
+
+func (g *GORMArticleDAO) ScanPub(ctx context.Context, startId int64, limit int) ([]PublishArticle, error) {
+	var arts []PublishArticle
+	err := g.db.WithContext(ctx).Model(&PublishArticle{}).
+		Select("id", "utime").
+		Where("id > ? AND status = ?", startId, domain.ArticleStatusPublished.ToUint8()).
+		Order("id ASC").
+		Limit(limit).
+		Find(&arts).Error
+	return arts, err
+}
+
+func (g *GORMArticleDAO) Delete(ctx context.Context, id, uid int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Article{}).
+			Where("id = ? AND author_id = ? AND dtime = 0", id, uid).
+			Updates(map[string]any{
+				"status": domain.ArticleStatusDeleted.ToUint8(),
+				"dtime":  now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrArticleNotFound
+		}
+		// 没有发表过的文章线上库里没有记录
+		return tx.Model(&PublishArticle{}).
+			Where("id = ?", id).
+			Updates(map[string]any{
+				"status": domain.ArticleStatusDeleted.ToUint8(),
+				"dtime":  now,
+			}).Error
+	})
+}
+
+func (g *GORMArticleDAO) Restore(ctx context.Context, id, uid int64, since int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Article{}).
+			Where("id = ? AND author_id = ? AND dtime > 0 AND dtime >= ?", id, uid, since).
+			Updates(map[string]any{
+				"status": domain.ArticleStatusUnpublished.ToUint8(),
+				"dtime":  0,
+				"utime":  now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrArticleNotFound
+		}
+		// 线上库恢复为仅自己可见，需要作者重新发表
+		return tx.Model(&PublishArticle{}).
+			Where("id = ?", id).
+			Updates(map[string]any{
+				"status": domain.ArticleStatusPrivate.ToUint8(),
+				"dtime":  0,
+				"utime":  now,
+			}).Error
+	})
+}
+
+func (g *GORMArticleDAO) ListDeleted(ctx context.Context, uid int64, since int64, offset int, limit int) ([]Article, error) {
+	var arts []Article
+	err := g.db.WithContext(ctx).Model(&Article{}).
+		Where("author_id = ? AND dtime > 0 AND dtime >= ?", uid, since).
+		Order("dtime DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&arts).Error
+	return arts, err
+}
+
+func (g *GORMArticleDAO) ListByStatus(ctx context.Context, status uint8, offset int, limit int) ([]Article, error) {
+	var arts []Article
+	err := g.db.WithContext(ctx).Model(&Article{}).
+		Where("status = ? AND dtime = 0", status).
+		Order("utime ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&arts).Error
+	return arts, err
+}
+
+func (g *GORMArticleDAO) FindByStatus(ctx context.Context, id int64, status uint8) (Article, error) {
+	var art Article
+	err := g.db.WithContext(ctx).Model(&Article{}).
+		Where("id = ? AND status = ? AND dtime = 0", id, status).
+		First(&art).Error
+	if err != nil {
+		return Article{}, err
+	}
+	err = g.loadMeta(g.db.WithContext(ctx), &art, false)
+	return art, err
+}
+
+func (g *GORMArticleDAO) TransitStatus(ctx context.Context, id int64, from, to uint8) error {
+	res := g.db.WithContext(ctx).Model(&Article{}).
+		Where("id = ? AND status = ? AND dtime = 0", id, from).
+		Updates(map[string]any{
+			"status": to,
+			"utime":  time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrArticleNotFound
+	}
+	return nil
+}
+
+func (g *GORMArticleDAO) ListExpired(ctx context.Context, before int64, limit int) ([]Article, error) {
+	var arts []Article
+	err := g.db.WithContext(ctx).Model(&Article{}).
+		Select("id", "author_id").
+		Where("dtime > 0 AND dtime < ?", before).
+		Order("id ASC").
+		Limit(limit).
+		Find(&arts).Error
+	return arts, err
+}
+
+func (g *GORMArticleDAO) Purge(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return g.purge(tx, ids)
+	})
+}
+
+func (g *GORMArticleDAO) purge(tx *gorm.DB, ids []int64) error {
+	err := tx.Where("id IN ?", ids).Delete(&Article{}).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Where("id IN ?", ids).Delete(&PublishArticle{}).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Where("id IN ?", ids).Delete(&PublishArticleRender{}).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Where("article_id IN ?", ids).Delete(&ArticleTag{}).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Where("article_id IN ?", ids).Delete(&PublishArticleTag{}).Error
+	if err != nil {
+		return err
+	}
+	return tx.Where("article_id IN ?", ids).Delete(&ArticleRevision{}).Error
+}
+
+func (g *GORMArticleDAO) ListRevisions(ctx context.Context, aid, uid int64, offset int, limit int) ([]ArticleRevision, error) {
+	var revs []ArticleRevision
+	// 列表不需要内容
+	err := g.db.WithContext(ctx).Model(&ArticleRevision{}).
+		Select("id", "article_id", "title", "author_id", "status", "ctime").
+		Where("article_id = ? AND author_id = ?", aid, uid).
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&revs).Error
+	return revs, err
+}
+
+func (g *GORMArticleDAO) FindRevision(ctx context.Context, id, aid, uid int64) (ArticleRevision, error) {
+	var rev ArticleRevision
+	err := g.db.WithContext(ctx).
+		Where("id = ? AND article_id = ? AND author_id = ?", id, aid, uid).
+		First(&rev).Error
+	return rev, err
 }
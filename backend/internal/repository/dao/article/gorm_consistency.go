@@ -0,0 +1,84 @@
+package article
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+func (g *GORMArticleDAO) ScanPairs(ctx context.Context, startId int64, limit int) ([]ArticlePair, error) {
+	db := g.db.WithContext(ctx)
+	var drafts []Article
+	err := db.Where("id > ?", startId).Order("id ASC").Limit(limit).Find(&drafts).Error
+	if err != nil {
+		return nil, err
+	}
+	var pubs []PublishArticle
+	err = db.Where("id > ?", startId).Order("id ASC").Limit(limit).Find(&pubs).Error
+	if err != nil {
+		return nil, err
+	}
+	return pairArticles(drafts, pubs, limit), nil
+}
+
+func (g *GORMArticleDAO) RepairPub(ctx context.Context, id, version int64, render *PublishArticleRender) error {
+	_, err := g.repairPub(ctx, id, version, render, true)
+	return err
+}
+
+// repairPub 返回修复后线上库的记录，线上库没有记录时返回 nil；withContent 为 false 时内容不写入数据库
+func (g *GORMArticleDAO) repairPub(ctx context.Context, id, version int64,
+	render *PublishArticleRender, withContent bool) (*PublishArticle, error) {
+	var res *PublishArticle
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 锁住草稿，避免和作者同时发表互相覆盖
+		var draft Article
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", id).First(&draft).Error
+		if err == gorm.ErrRecordNotFound {
+			// 制作库中已经彻底删除，线上库残留的数据一并清理
+			return g.purge(tx, []int64{id})
+		}
+		if err != nil {
+			return err
+		}
+		if draft.Version != version {
+			return ErrVersionConflict
+		}
+		err = g.loadMeta(tx, &draft, false)
+		if err != nil {
+			return err
+		}
+
+		var old *PublishArticle
+		var pub PublishArticle
+		err = tx.Where("id = ?", id).First(&pub).Error
+		switch err {
+		case nil:
+			err = g.loadMeta(tx, (*Article)(&pub), true)
+			if err != nil {
+				return err
+			}
+			old = &pub
+		case gorm.ErrRecordNotFound:
+		default:
+			return err
+		}
+
+		res = repairedPub(draft, old, time.Now().UnixMilli())
+		if res == nil {
+			return nil
+		}
+		art := Article(*res)
+		if !withContent {
+			art.Content = ""
+		}
+		err = g.replace(tx, art, true)
+		if err != nil {
+			return err
+		}
+		return g.upsertRender(tx, id, render)
+	})
+	return res, err
+}
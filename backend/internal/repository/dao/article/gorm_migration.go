@@ -0,0 +1,128 @@
+package article
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ MigratableDAO = &GORMArticleDAO{}
+
+func (g *GORMArticleDAO) Snapshot(ctx context.Context, id int64) (ArticleSnapshot, error) {
+	res := ArticleSnapshot{Id: id}
+	db := g.db.WithContext(ctx)
+	var draft Article
+	err := db.Where("id = ?", id).First(&draft).Error
+	if err == gorm.ErrRecordNotFound {
+		return res, nil
+	}
+	if err != nil {
+		return res, err
+	}
+	err = g.loadMeta(db, &draft, false)
+	if err != nil {
+		return res, err
+	}
+	res.Draft = &draft
+
+	var pub PublishArticle
+	err = db.Where("id = ?", id).First(&pub).Error
+	switch err {
+	case nil:
+		err = g.loadMeta(db, (*Article)(&pub), true)
+		if err != nil {
+			return res, err
+		}
+		pub.Render, err = g.findRender(db, id)
+		if err != nil {
+			return res, err
+		}
+		res.Pub = &pub
+	case gorm.ErrRecordNotFound:
+	default:
+		return res, err
+	}
+
+	err = db.Where("article_id = ?", id).Order("id ASC").Find(&res.Revisions).Error
+	return res, err
+}
+
+func (g *GORMArticleDAO) ApplySnapshot(ctx context.Context, s ArticleSnapshot) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if s.Draft == nil {
+			return g.purge(tx, []int64{s.Id})
+		}
+		err := g.replace(tx, *s.Draft, false)
+		if err != nil {
+			return err
+		}
+
+		if s.Pub == nil {
+			err = tx.Where("id = ?", s.Id).Delete(&PublishArticle{}).Error
+			if err == nil {
+				err = tx.Where("id = ?", s.Id).Delete(&PublishArticleRender{}).Error
+			}
+			if err == nil {
+				err = tx.Where("article_id = ?", s.Id).Delete(&PublishArticleTag{}).Error
+			}
+		} else {
+			err = g.replace(tx, Article(*s.Pub), true)
+			if err == nil {
+				err = g.replaceRender(tx, s.Id, s.Pub.Render)
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		// 历史版本只追加，数量不多，整体替换最简单
+		err = tx.Where("article_id = ?", s.Id).Delete(&ArticleRevision{}).Error
+		if err != nil || len(s.Revisions) == 0 {
+			return err
+		}
+		return tx.Create(&s.Revisions).Error
+	})
+}
+
+// replace 原样写入一行文章，覆盖已有的所有字段和标签
+func (g *GORMArticleDAO) replace(tx *gorm.DB, art Article, live bool) error {
+	var err error
+	art.CategoryId, err = g.categoryId(tx, art.Category)
+	if err != nil {
+		return err
+	}
+	var model any = &art
+	if live {
+		pub := PublishArticle(art)
+		model = &pub
+	}
+	err = tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(model).Error
+	if err != nil {
+		return err
+	}
+	tags := art.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return g.setTags(tx, art.Id, tags, live)
+}
+
+// replaceRender 和 upsertRender 不同，保留原来的更新时间，r 为 nil 时删除
+func (g *GORMArticleDAO) replaceRender(tx *gorm.DB, id int64, r *PublishArticleRender) error {
+	if r == nil {
+		return tx.Where("id = ?", id).Delete(&PublishArticleRender{}).Error
+	}
+	render := *r
+	render.Id = id
+	return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&render).Error
+}
+
+func (g *GORMArticleDAO) ScanIds(ctx context.Context, startId int64, since int64, limit int) ([]int64, error) {
+	var ids []int64
+	err := g.db.WithContext(ctx).Model(&Article{}).
+		Where("id > ? AND utime >= ?", startId, since).
+		Order("id ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
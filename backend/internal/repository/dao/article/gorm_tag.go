@@ -0,0 +1,117 @@
+package article
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+func tagTable(live bool) string {
+	if live {
+		return "publish_article_tags"
+	}
+	return "article_tags"
+}
+
+// categoryId 按名字找到分类，不存在则创建
+func (g *GORMArticleDAO) categoryId(tx *gorm.DB, name string) (int64, error) {
+	if name == "" {
+		return 0, nil
+	}
+	c := Category{Name: name, Ctime: time.Now().UnixMilli()}
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&c).Error
+	if err != nil {
+		return 0, err
+	}
+	err = tx.Where("name = ?", name).First(&c).Error
+	return c.Id, err
+}
+
+// tagIds 按名字找到标签，不存在的标签会被创建
+func (g *GORMArticleDAO) tagIds(tx *gorm.DB, names []string) ([]int64, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	now := time.Now().UnixMilli()
+	tags := slice.Map[string, Tag](names, func(idx int, src string) Tag {
+		return Tag{Name: src, Ctime: now}
+	})
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	var ids []int64
+	err = tx.Model(&Tag{}).Where("name IN ?", names).Pluck("id", &ids).Error
+	return ids, err
+}
+
+// setTags 用 tags 覆盖文章的标签，tags 为 nil 时保持不变
+func (g *GORMArticleDAO) setTags(tx *gorm.DB, aid int64, tags []string, live bool) error {
+	if tags == nil {
+		return nil
+	}
+	ids, err := g.tagIds(tx, tags)
+	if err != nil {
+		return err
+	}
+	err = tx.Table(tagTable(live)).Where("article_id = ?", aid).Delete(&ArticleTag{}).Error
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+	now := time.Now().UnixMilli()
+	rows := slice.Map[int64, ArticleTag](ids, func(idx int, src int64) ArticleTag {
+		return ArticleTag{ArticleId: aid, TagId: src, Ctime: now}
+	})
+	return tx.Table(tagTable(live)).Create(&rows).Error
+}
+
+// loadMeta 填充文章的标签和分类名
+func (g *GORMArticleDAO) loadMeta(tx *gorm.DB, art *Article, live bool) error {
+	tags := make([]string, 0)
+	err := tx.Table(tagTable(live)+" AS at").
+		Joins("JOIN tags ON tags.id = at.tag_id").
+		Where("at.article_id = ?", art.Id).
+		Order("at.id ASC").
+		Pluck("tags.name", &tags).Error
+	if err != nil {
+		return err
+	}
+	art.Tags = tags
+	if art.CategoryId == 0 {
+		return nil
+	}
+	var c Category
+	err = tx.Where("id = ?", art.CategoryId).First(&c).Error
+	art.Category = c.Name
+	return err
+}
+
+func (g *GORMArticleDAO) ListPubByTag(ctx context.Context, tag string, offset int, limit int) ([]PublishArticle, error) {
+	var arts []PublishArticle
+	err := g.db.WithContext(ctx).Model(&PublishArticle{}).
+		Select("publish_articles.*").
+		Joins("JOIN publish_article_tags pat ON pat.article_id = publish_articles.id").
+		Joins("JOIN tags ON tags.id = pat.tag_id").
+		Where("tags.name = ? AND publish_articles.status = ?", tag, domain.ArticleStatusPublished.ToUint8()).
+		Order("publish_articles.utime DESC, publish_articles.id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&arts).Error
+	return arts, err
+}
+
+func (g *GORMArticleDAO) TagCounts(ctx context.Context) ([]TagCount, error) {
+	var res []TagCount
+	err := g.db.WithContext(ctx).Table("publish_article_tags pat").
+		Select("tags.name AS name, COUNT(*) AS cnt").
+		Joins("JOIN tags ON tags.id = pat.tag_id").
+		Joins("JOIN publish_articles pa ON pa.id = pat.article_id").
+		Where("pa.status = ?", domain.ArticleStatusPublished.ToUint8()).
+		Group("tags.name").
+		Order("cnt DESC").
+		Scan(&res).Error
+	return res, err
+}
@@ -0,0 +1,530 @@
+package article
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestGORMArticleDAO_UpdateById(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(t *testing.T) *sql.DB
+		art     Article
+		wantErr error
+	}{
+		{
+			name: "更新成功并追加历史版本",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `article_revisions` .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				return mockDB
+			},
+			art: Article{Id: 1, Title: "title", Content: "content", AuthorId: 123},
+		},
+		{
+			name: "更新标签",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `tags` .*").
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectQuery("SELECT `id` FROM `tags` WHERE name IN .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+				mock.ExpectExec("DELETE FROM `article_tags` WHERE article_id = ?").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `article_tags` .*").
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectExec("INSERT INTO `article_revisions` .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				return mockDB
+			},
+			art: Article{Id: 1, Title: "title", Content: "content", AuthorId: 123, Tags: []string{"go", "gin"}},
+		},
+		{
+			name: "清空标签",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM `article_tags` WHERE article_id = ?").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("INSERT INTO `article_revisions` .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				return mockDB
+			},
+			art: Article{Id: 1, Title: "title", Content: "content", AuthorId: 123, Tags: []string{}},
+		},
+		{
+			name: "不是自己的文章，不追加历史版本",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+				return mockDB
+			},
+			art:     Article{Id: 1, Title: "title", Content: "content", AuthorId: 234},
+			wantErr: errors.New("更新数据失败"),
+		},
+		{
+			name: "版本号过期",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` SET .* WHERE .*version = ?").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM `articles`").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectRollback()
+				return mockDB
+			},
+			art:     Article{Id: 1, Title: "title", Content: "content", AuthorId: 123, Version: 2},
+			wantErr: ErrVersionConflict,
+		},
+		{
+			name: "带版本号，但不是自己的文章",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM `articles`").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectRollback()
+				return mockDB
+			},
+			art:     Article{Id: 1, Title: "title", Content: "content", AuthorId: 234, Version: 2},
+			wantErr: errors.New("更新数据失败"),
+		},
+		{
+			name: "写历史版本失败，整体回滚",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `article_revisions` .*").
+					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+				return mockDB
+			},
+			art:     Article{Id: 1, Title: "title", Content: "content", AuthorId: 123},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      tc.mock(t),
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			d := NewGORMArticleDAO(db, logger.NewNopLogger())
+			err = d.UpdateById(context.Background(), tc.art)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestGORMArticleDAO_GetByAuthor(t *testing.T) {
+	testCases := []struct {
+		name        string
+		mock        func(t *testing.T) *sql.DB
+		cursorUtime int64
+		cursorId    int64
+		wantIds     []int64
+	}{
+		{
+			name: "第一页",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `articles` WHERE author_id = \\? AND dtime = 0 ORDER BY utime DESC, id DESC LIMIT 2").
+					WithArgs(123).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "utime"}).AddRow(3, 200).AddRow(2, 100))
+				return mockDB
+			},
+			wantIds: []int64{3, 2},
+		},
+		{
+			name: "从游标之后开始",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `articles` WHERE \\(author_id = \\? AND dtime = 0\\) AND \\(utime < \\? OR \\(utime = \\? AND id < \\?\\)\\) ORDER BY utime DESC, id DESC LIMIT 2").
+					WithArgs(123, 100, 100, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "utime"}).AddRow(1, 100))
+				return mockDB
+			},
+			cursorUtime: 100,
+			cursorId:    2,
+			wantIds:     []int64{1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      tc.mock(t),
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			d := NewGORMArticleDAO(db, logger.NewNopLogger())
+			arts, err := d.GetByAuthor(context.Background(), 123, tc.cursorUtime, tc.cursorId, 2)
+			require.NoError(t, err)
+			ids := make([]int64, 0, len(arts))
+			for _, art := range arts {
+				ids = append(ids, art.Id)
+			}
+			assert.Equal(t, tc.wantIds, ids)
+		})
+	}
+}
+
+func TestGORMArticleDAO_Restore(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(t *testing.T) *sql.DB
+		wantErr error
+	}{
+		{
+			name: "恢复为草稿，线上库改为仅自己可见",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` SET .* WHERE id = \\? AND author_id = \\? AND dtime > 0 AND dtime >= \\?").
+					WithArgs(0, domain.ArticleStatusUnpublished.ToUint8(), sqlmock.AnyArg(), 1, 123, 1000).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `publish_articles` SET .*").
+					WithArgs(0, domain.ArticleStatusPrivate.ToUint8(), sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				return mockDB
+			},
+		},
+		{
+			name: "不在回收站或已超过保留期限",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+				return mockDB
+			},
+			wantErr: ErrArticleNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      tc.mock(t),
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			d := NewGORMArticleDAO(db, logger.NewNopLogger())
+			err = d.Restore(context.Background(), 1, 123, 1000)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestGORMArticleDAO_TransitStatus(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(t *testing.T) *sql.DB
+		wantErr error
+	}{
+		{
+			name: "从等待审核退回为草稿",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `articles` SET .* WHERE id = \\? AND status = \\? AND dtime = 0").
+					WithArgs(domain.ArticleStatusUnpublished.ToUint8(), sqlmock.AnyArg(),
+						1, domain.ArticleStatusPendingReview.ToUint8()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				return mockDB
+			},
+		},
+		{
+			name: "不在等待审核",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `articles` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				return mockDB
+			},
+			wantErr: ErrArticleNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      tc.mock(t),
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			d := NewGORMArticleDAO(db, logger.NewNopLogger())
+			err = d.TransitStatus(context.Background(), 1,
+				domain.ArticleStatusPendingReview.ToUint8(), domain.ArticleStatusUnpublished.ToUint8())
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestGORMArticleDAO_ScanPub(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	// 只查已发表的，仅自己可见的不会出现
+	mock.ExpectQuery("SELECT `id`,`utime` FROM `publish_articles` WHERE id > \\? AND status = \\? ORDER BY id ASC LIMIT 2").
+		WithArgs(10, domain.ArticleStatusPublished.ToUint8()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "utime"}).AddRow(11, 100).AddRow(13, 300))
+
+	db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+		Conn:                      mockDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	d := NewGORMArticleDAO(db, logger.NewNopLogger())
+	arts, err := d.ScanPub(context.Background(), 10, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []PublishArticle{{Id: 11, Utime: 100}, {Id: 13, Utime: 300}}, arts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGORMArticleDAO_ListPubByAuthors(t *testing.T) {
+	testCases := []struct {
+		name        string
+		mock        func(t *testing.T) *sql.DB
+		cursorUtime int64
+		cursorId    int64
+		wantIds     []int64
+	}{
+		{
+			name: "第一页",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `publish_articles` WHERE author_id IN \\(\\?,\\?\\) AND status = \\? ORDER BY utime DESC, id DESC LIMIT 2").
+					WithArgs(123, 456, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "utime"}).AddRow(3, 100).AddRow(2, 100))
+				return mockDB
+			},
+			wantIds: []int64{3, 2},
+		},
+		{
+			name: "同一时间的文章用 id 区分",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `publish_articles` WHERE \\(author_id IN \\(\\?,\\?\\) AND status = \\?\\) AND \\(utime < \\? OR \\(utime = \\? AND id < \\?\\)\\) ORDER BY utime DESC, id DESC LIMIT 2").
+					WithArgs(123, 456, 2, 100, 100, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "utime"}).AddRow(1, 100))
+				return mockDB
+			},
+			cursorUtime: 100,
+			cursorId:    2,
+			wantIds:     []int64{1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      tc.mock(t),
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			d := NewGORMArticleDAO(db, logger.NewNopLogger())
+			arts, err := d.ListPubByAuthors(context.Background(), []int64{123, 456}, tc.cursorUtime, tc.cursorId, 2)
+			require.NoError(t, err)
+			ids := make([]int64, 0, len(arts))
+			for _, art := range arts {
+				ids = append(ids, art.Id)
+			}
+			assert.Equal(t, tc.wantIds, ids)
+		})
+	}
+}
+
+func TestGORMArticleDAO_ListPubByAuthor(t *testing.T) {
+	testCases := []struct {
+		name        string
+		mock        func(t *testing.T) *sql.DB
+		cursorUtime int64
+		cursorId    int64
+		wantIds     []int64
+		wantErr     error
+	}{
+		{
+			name: "只查已发表的",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `publish_articles` WHERE author_id = \\? AND status = \\? ORDER BY utime DESC, id DESC LIMIT 2").
+					WithArgs(123, domain.ArticleStatusPublished.ToUint8()).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "utime"}).AddRow(3, 200).AddRow(2, 100))
+				return mockDB
+			},
+			wantIds: []int64{3, 2},
+		},
+		{
+			name: "从游标之后开始，同一时间的文章用 id 区分",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `publish_articles` WHERE \\(author_id = \\? AND status = \\?\\) AND \\(utime < \\? OR \\(utime = \\? AND id < \\?\\)\\) ORDER BY utime DESC, id DESC LIMIT 2").
+					WithArgs(123, domain.ArticleStatusPublished.ToUint8(), 100, 100, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "utime"}).AddRow(1, 100))
+				return mockDB
+			},
+			cursorUtime: 100,
+			cursorId:    2,
+			wantIds:     []int64{1},
+		},
+		{
+			name: "作者还没有发表文章",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `publish_articles` .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "utime"}))
+				return mockDB
+			},
+			wantIds: []int64{},
+		},
+		{
+			name: "查询出错",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `publish_articles` .*").
+					WillReturnError(sql.ErrConnDone)
+				return mockDB
+			},
+			wantErr: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      tc.mock(t),
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			d := NewGORMArticleDAO(db, logger.NewNopLogger())
+			arts, err := d.ListPubByAuthor(context.Background(), 123, tc.cursorUtime, tc.cursorId, 2)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			ids := make([]int64, 0, len(arts))
+			for _, art := range arts {
+				ids = append(ids, art.Id)
+			}
+			assert.Equal(t, tc.wantIds, ids)
+		})
+	}
+}
+
+func TestGORMArticleDAO_ListPub(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	// 回收站中的也要查出来，重建索引时才能从索引中移除
+	mock.ExpectQuery("SELECT \\* FROM `publish_articles` WHERE id > \\? ORDER BY id ASC LIMIT 2").
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "dtime"}).
+			AddRow(11, domain.ArticleStatusPublished.ToUint8(), 0).
+			AddRow(12, domain.ArticleStatusDeleted.ToUint8(), 100))
+	db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+		Conn:                      mockDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	d := NewGORMArticleDAO(db, logger.NewNopLogger())
+	arts, err := d.ListPub(context.Background(), 10, 2)
+	require.NoError(t, err)
+	assert.Len(t, arts, 2)
+	assert.Equal(t, int64(100), arts[1].Dtime)
+}
+
+func TestGORMArticleDAO_ListPubByTag(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	// 更新时间相同的按 id 排，翻页时顺序才稳定
+	mock.ExpectQuery("SELECT publish_articles.\\* FROM `publish_articles` JOIN publish_article_tags pat ON pat.article_id = publish_articles.id JOIN tags ON tags.id = pat.tag_id "+
+		"WHERE tags.name = \\? AND publish_articles.status = \\? ORDER BY publish_articles.utime DESC, publish_articles.id DESC LIMIT 2 OFFSET 2").
+		WithArgs("go", domain.ArticleStatusPublished.ToUint8()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "utime"}).AddRow(3, 100).AddRow(2, 100))
+	db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+		Conn:                      mockDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	d := NewGORMArticleDAO(db, logger.NewNopLogger())
+	arts, err := d.ListPubByTag(context.Background(), "go", 2, 2)
+	require.NoError(t, err)
+	assert.Len(t, arts, 2)
+}
@@ -0,0 +1,82 @@
+package article
+
+import (
+	"context"
+)
+
+// MigratableDAO 可以按 id 原样读写一篇文章全部数据的 DAO，用于在不同存储之间迁移
+type MigratableDAO interface {
+	ArticleDAO
+	// Snapshot 文章已经彻底删除或者不存在时返回 Draft 为 nil 的快照
+	Snapshot(ctx context.Context, id int64) (ArticleSnapshot, error)
+	// ApplySnapshot 用快照覆盖文章的全部数据，保留 id、版本号和时间，Draft 为 nil 时彻底删除
+	ApplySnapshot(ctx context.Context, s ArticleSnapshot) error
+	// ScanIds 按 id 升序遍历制作库中 id 大于 startId 且 utime 不早于 since 的文章，包括回收站中的
+	ScanIds(ctx context.Context, startId int64, since int64, limit int) ([]int64, error)
+}
+
+// ArticleSnapshot 一篇文章在制作库、线上库和历史版本中的全部数据
+type ArticleSnapshot struct {
+	Id int64
+	// Draft 制作库，为 nil 表示文章不存在
+	Draft *Article
+	// Pub 线上库，带渲染结果，为 nil 表示没有发表过
+	Pub *PublishArticle
+	// Revisions 按 id 升序
+	Revisions []ArticleRevision
+}
+
+// Equal 比较两边存储的数据是否一致，忽略只有 MySQL 才有的分类 id
+func (s ArticleSnapshot) Equal(o ArticleSnapshot) bool {
+	if (s.Draft == nil) != (o.Draft == nil) || (s.Pub == nil) != (o.Pub == nil) {
+		return false
+	}
+	if s.Draft != nil && !articleEqual(*s.Draft, *o.Draft) {
+		return false
+	}
+	if s.Pub != nil {
+		if !articleEqual(Article(*s.Pub), Article(*o.Pub)) {
+			return false
+		}
+		r1, r2 := s.Pub.Render, o.Pub.Render
+		if (r1 == nil) != (r2 == nil) {
+			return false
+		}
+		if r1 != nil {
+			a, b := *r1, *r2
+			a.Id, b.Id = 0, 0
+			if a != b {
+				return false
+			}
+		}
+	}
+	if len(s.Revisions) != len(o.Revisions) {
+		return false
+	}
+	for i := range s.Revisions {
+		if s.Revisions[i] != o.Revisions[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func articleEqual(a, b Article) bool {
+	return a.Id == b.Id && a.Title == b.Title && a.Content == b.Content &&
+		a.AuthorId == b.AuthorId && a.Status == b.Status && a.Version == b.Version &&
+		a.Ctime == b.Ctime && a.Utime == b.Utime && a.Dtime == b.Dtime &&
+		a.Category == b.Category && a.Abstract == b.Abstract && tagsEqual(a.Tags, b.Tags)
+}
+
+// tagsEqual MySQL 查出来的是空切片，MongoDB 没有标签时是 nil
+func tagsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,485 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backend/internal/repository/dao/article/types.go
+
+// Package artdaomocks is a generated GoMock package.
+package artdaomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	article "github.com/johnwongx/webook/backend/internal/repository/dao/article"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuthorArticleDAO is a mock of AuthorArticleDAO interface.
+type MockAuthorArticleDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorArticleDAOMockRecorder
+}
+
+// MockAuthorArticleDAOMockRecorder is the mock recorder for MockAuthorArticleDAO.
+type MockAuthorArticleDAOMockRecorder struct {
+	mock *MockAuthorArticleDAO
+}
+
+// NewMockAuthorArticleDAO creates a new mock instance.
+func NewMockAuthorArticleDAO(ctrl *gomock.Controller) *MockAuthorArticleDAO {
+	mock := &MockAuthorArticleDAO{ctrl: ctrl}
+	mock.recorder = &MockAuthorArticleDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorArticleDAO) EXPECT() *MockAuthorArticleDAOMockRecorder {
+	return m.recorder
+}
+
+// Insert mocks base method.
+func (m *MockAuthorArticleDAO) Insert(ctx context.Context, art article.Article) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, art)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockAuthorArticleDAOMockRecorder) Insert(ctx, art interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockAuthorArticleDAO)(nil).Insert), ctx, art)
+}
+
+// UpdateById mocks base method.
+func (m *MockAuthorArticleDAO) UpdateById(ctx context.Context, art article.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, art)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockAuthorArticleDAOMockRecorder) UpdateById(ctx, art interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockAuthorArticleDAO)(nil).UpdateById), ctx, art)
+}
+
+// MockArticleDAO is a mock of ArticleDAO interface.
+type MockArticleDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleDAOMockRecorder
+}
+
+// MockArticleDAOMockRecorder is the mock recorder for MockArticleDAO.
+type MockArticleDAOMockRecorder struct {
+	mock *MockArticleDAO
+}
+
+// NewMockArticleDAO creates a new mock instance.
+func NewMockArticleDAO(ctrl *gomock.Controller) *MockArticleDAO {
+	mock := &MockArticleDAO{ctrl: ctrl}
+	mock.recorder = &MockArticleDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleDAO) EXPECT() *MockArticleDAOMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockArticleDAO) Delete(ctx context.Context, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArticleDAOMockRecorder) Delete(ctx, id, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleDAO)(nil).Delete), ctx, id, uid)
+}
+
+// FindById mocks base method.
+func (m *MockArticleDAO) FindById(ctx context.Context, id, uid int64) (article.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id, uid)
+	ret0, _ := ret[0].(article.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockArticleDAOMockRecorder) FindById(ctx, id, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockArticleDAO)(nil).FindById), ctx, id, uid)
+}
+
+// FindByStatus mocks base method.
+func (m *MockArticleDAO) FindByStatus(ctx context.Context, id int64, status uint8) (article.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByStatus", ctx, id, status)
+	ret0, _ := ret[0].(article.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByStatus indicates an expected call of FindByStatus.
+func (mr *MockArticleDAOMockRecorder) FindByStatus(ctx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByStatus", reflect.TypeOf((*MockArticleDAO)(nil).FindByStatus), ctx, id, status)
+}
+
+// FindPubById mocks base method.
+func (m *MockArticleDAO) FindPubById(ctx context.Context, id int64) (article.PublishArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPubById", ctx, id)
+	ret0, _ := ret[0].(article.PublishArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPubById indicates an expected call of FindPubById.
+func (mr *MockArticleDAOMockRecorder) FindPubById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPubById", reflect.TypeOf((*MockArticleDAO)(nil).FindPubById), ctx, id)
+}
+
+// FindRevision mocks base method.
+func (m *MockArticleDAO) FindRevision(ctx context.Context, id, aid, uid int64) (article.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRevision", ctx, id, aid, uid)
+	ret0, _ := ret[0].(article.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRevision indicates an expected call of FindRevision.
+func (mr *MockArticleDAOMockRecorder) FindRevision(ctx, id, aid, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRevision", reflect.TypeOf((*MockArticleDAO)(nil).FindRevision), ctx, id, aid, uid)
+}
+
+// GetByAuthor mocks base method.
+func (m *MockArticleDAO) GetByAuthor(ctx context.Context, uid, cursorUtime, cursorId int64, limit int) ([]article.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAuthor", ctx, uid, cursorUtime, cursorId, limit)
+	ret0, _ := ret[0].([]article.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByAuthor indicates an expected call of GetByAuthor.
+func (mr *MockArticleDAOMockRecorder) GetByAuthor(ctx, uid, cursorUtime, cursorId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthor", reflect.TypeOf((*MockArticleDAO)(nil).GetByAuthor), ctx, uid, cursorUtime, cursorId, limit)
+}
+
+// Insert mocks base method.
+func (m *MockArticleDAO) Insert(ctx context.Context, art article.Article) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, art)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockArticleDAOMockRecorder) Insert(ctx, art interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockArticleDAO)(nil).Insert), ctx, art)
+}
+
+// ListByStatus mocks base method.
+func (m *MockArticleDAO) ListByStatus(ctx context.Context, status uint8, offset, limit int) ([]article.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByStatus", ctx, status, offset, limit)
+	ret0, _ := ret[0].([]article.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByStatus indicates an expected call of ListByStatus.
+func (mr *MockArticleDAOMockRecorder) ListByStatus(ctx, status, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByStatus", reflect.TypeOf((*MockArticleDAO)(nil).ListByStatus), ctx, status, offset, limit)
+}
+
+// ListDeleted mocks base method.
+func (m *MockArticleDAO) ListDeleted(ctx context.Context, uid, since int64, offset, limit int) ([]article.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeleted", ctx, uid, since, offset, limit)
+	ret0, _ := ret[0].([]article.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeleted indicates an expected call of ListDeleted.
+func (mr *MockArticleDAOMockRecorder) ListDeleted(ctx, uid, since, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeleted", reflect.TypeOf((*MockArticleDAO)(nil).ListDeleted), ctx, uid, since, offset, limit)
+}
+
+// ListExpired mocks base method.
+func (m *MockArticleDAO) ListExpired(ctx context.Context, before int64, limit int) ([]article.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpired", ctx, before, limit)
+	ret0, _ := ret[0].([]article.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpired indicates an expected call of ListExpired.
+func (mr *MockArticleDAOMockRecorder) ListExpired(ctx, before, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpired", reflect.TypeOf((*MockArticleDAO)(nil).ListExpired), ctx, before, limit)
+}
+
+// ListLatestPub mocks base method.
+func (m *MockArticleDAO) ListLatestPub(ctx context.Context, limit int) ([]article.PublishArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLatestPub", ctx, limit)
+	ret0, _ := ret[0].([]article.PublishArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLatestPub indicates an expected call of ListLatestPub.
+func (mr *MockArticleDAOMockRecorder) ListLatestPub(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLatestPub", reflect.TypeOf((*MockArticleDAO)(nil).ListLatestPub), ctx, limit)
+}
+
+// ListPub mocks base method.
+func (m *MockArticleDAO) ListPub(ctx context.Context, startId int64, limit int) ([]article.PublishArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPub", ctx, startId, limit)
+	ret0, _ := ret[0].([]article.PublishArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPub indicates an expected call of ListPub.
+func (mr *MockArticleDAOMockRecorder) ListPub(ctx, startId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleDAO)(nil).ListPub), ctx, startId, limit)
+}
+
+// ListPubByAuthor mocks base method.
+func (m *MockArticleDAO) ListPubByAuthor(ctx context.Context, uid, cursorUtime, cursorId int64, limit int) ([]article.PublishArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByAuthor", ctx, uid, cursorUtime, cursorId, limit)
+	ret0, _ := ret[0].([]article.PublishArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByAuthor indicates an expected call of ListPubByAuthor.
+func (mr *MockArticleDAOMockRecorder) ListPubByAuthor(ctx, uid, cursorUtime, cursorId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByAuthor", reflect.TypeOf((*MockArticleDAO)(nil).ListPubByAuthor), ctx, uid, cursorUtime, cursorId, limit)
+}
+
+// ListPubByAuthors mocks base method.
+func (m *MockArticleDAO) ListPubByAuthors(ctx context.Context, uids []int64, cursorUtime, cursorId int64, limit int) ([]article.PublishArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByAuthors", ctx, uids, cursorUtime, cursorId, limit)
+	ret0, _ := ret[0].([]article.PublishArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByAuthors indicates an expected call of ListPubByAuthors.
+func (mr *MockArticleDAOMockRecorder) ListPubByAuthors(ctx, uids, cursorUtime, cursorId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByAuthors", reflect.TypeOf((*MockArticleDAO)(nil).ListPubByAuthors), ctx, uids, cursorUtime, cursorId, limit)
+}
+
+// ListPubByTag mocks base method.
+func (m *MockArticleDAO) ListPubByTag(ctx context.Context, tag string, offset, limit int) ([]article.PublishArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByTag", ctx, tag, offset, limit)
+	ret0, _ := ret[0].([]article.PublishArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByTag indicates an expected call of ListPubByTag.
+func (mr *MockArticleDAOMockRecorder) ListPubByTag(ctx, tag, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByTag", reflect.TypeOf((*MockArticleDAO)(nil).ListPubByTag), ctx, tag, offset, limit)
+}
+
+// ListRevisions mocks base method.
+func (m *MockArticleDAO) ListRevisions(ctx context.Context, aid, uid int64, offset, limit int) ([]article.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, aid, uid, offset, limit)
+	ret0, _ := ret[0].([]article.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockArticleDAOMockRecorder) ListRevisions(ctx, aid, uid, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockArticleDAO)(nil).ListRevisions), ctx, aid, uid, offset, limit)
+}
+
+// Purge mocks base method.
+func (m *MockArticleDAO) Purge(ctx context.Context, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockArticleDAOMockRecorder) Purge(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockArticleDAO)(nil).Purge), ctx, ids)
+}
+
+// RepairPub mocks base method.
+func (m *MockArticleDAO) RepairPub(ctx context.Context, id, version int64, render *article.PublishArticleRender) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairPub", ctx, id, version, render)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RepairPub indicates an expected call of RepairPub.
+func (mr *MockArticleDAOMockRecorder) RepairPub(ctx, id, version, render interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairPub", reflect.TypeOf((*MockArticleDAO)(nil).RepairPub), ctx, id, version, render)
+}
+
+// Restore mocks base method.
+func (m *MockArticleDAO) Restore(ctx context.Context, id, uid, since int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, uid, since)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockArticleDAOMockRecorder) Restore(ctx, id, uid, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockArticleDAO)(nil).Restore), ctx, id, uid, since)
+}
+
+// ScanPairs mocks base method.
+func (m *MockArticleDAO) ScanPairs(ctx context.Context, startId int64, limit int) ([]article.ArticlePair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScanPairs", ctx, startId, limit)
+	ret0, _ := ret[0].([]article.ArticlePair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScanPairs indicates an expected call of ScanPairs.
+func (mr *MockArticleDAOMockRecorder) ScanPairs(ctx, startId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanPairs", reflect.TypeOf((*MockArticleDAO)(nil).ScanPairs), ctx, startId, limit)
+}
+
+// ScanPub mocks base method.
+func (m *MockArticleDAO) ScanPub(ctx context.Context, startId int64, limit int) ([]article.PublishArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScanPub", ctx, startId, limit)
+	ret0, _ := ret[0].([]article.PublishArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScanPub indicates an expected call of ScanPub.
+func (mr *MockArticleDAOMockRecorder) ScanPub(ctx, startId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanPub", reflect.TypeOf((*MockArticleDAO)(nil).ScanPub), ctx, startId, limit)
+}
+
+// Sync mocks base method.
+func (m *MockArticleDAO) Sync(ctx context.Context, art article.Article) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sync", ctx, art)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sync indicates an expected call of Sync.
+func (mr *MockArticleDAOMockRecorder) Sync(ctx, art interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockArticleDAO)(nil).Sync), ctx, art)
+}
+
+// SyncStatus mocks base method.
+func (m *MockArticleDAO) SyncStatus(ctx context.Context, id, usrId int64, status uint8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncStatus", ctx, id, usrId, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncStatus indicates an expected call of SyncStatus.
+func (mr *MockArticleDAOMockRecorder) SyncStatus(ctx, id, usrId, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncStatus", reflect.TypeOf((*MockArticleDAO)(nil).SyncStatus), ctx, id, usrId, status)
+}
+
+// TagCounts mocks base method.
+func (m *MockArticleDAO) TagCounts(ctx context.Context) ([]article.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagCounts", ctx)
+	ret0, _ := ret[0].([]article.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagCounts indicates an expected call of TagCounts.
+func (mr *MockArticleDAOMockRecorder) TagCounts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagCounts", reflect.TypeOf((*MockArticleDAO)(nil).TagCounts), ctx)
+}
+
+// TransitStatus mocks base method.
+func (m *MockArticleDAO) TransitStatus(ctx context.Context, id int64, from, to uint8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitStatus", ctx, id, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransitStatus indicates an expected call of TransitStatus.
+func (mr *MockArticleDAOMockRecorder) TransitStatus(ctx, id, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitStatus", reflect.TypeOf((*MockArticleDAO)(nil).TransitStatus), ctx, id, from, to)
+}
+
+// UpdateById mocks base method.
+func (m *MockArticleDAO) UpdateById(ctx context.Context, art article.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, art)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockArticleDAOMockRecorder) UpdateById(ctx, art interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockArticleDAO)(nil).UpdateById), ctx, art)
+}
+
+// Upsert mocks base method.
+func (m *MockArticleDAO) Upsert(ctx context.Context, art article.PublishArticle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, art)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockArticleDAOMockRecorder) Upsert(ctx, art interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockArticleDAO)(nil).Upsert), ctx, art)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/bwmarrin/snowflake"
+	"github.com/johnwongx/webook/backend/internal/domain"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -12,11 +13,17 @@ import (
 
 var _ ArticleDAO = &MongoDBArticleDAO{}
 
+// notDeleted dtime 带 omitempty，没有删除的文档里没有这个字段
+var notDeleted = bson.M{"$exists": false}
+
 type MongoDBArticleDAO struct {
 	mdb     *mongo.Database
 	col     *mongo.Collection
 	liveCol *mongo.Collection
-	node    *snowflake.Node
+	revCol  *mongo.Collection
+	// renderCol 发表时的渲染结果
+	renderCol *mongo.Collection
+	node      *snowflake.Node
 }
 
 func InitCollections(mdb *mongo.Database) error {
@@ -32,6 +39,11 @@ func InitCollections(mdb *mongo.Database) error {
 			Keys:    bson.D{{"author_id", 1}, {"ctime", 1}},
 			Options: options.Index(),
 		},
+		{
+			// 游标分页
+			Keys:    bson.D{{"author_id", 1}, {"utime", -1}, {"id", -1}},
+			Options: options.Index(),
+		},
 	}
 
 	_, err := mdb.Collection("articles").Indexes().CreateMany(ctx, models)
@@ -39,15 +51,37 @@ func InitCollections(mdb *mongo.Database) error {
 		return err
 	}
 	_, err = mdb.Collection("published_articles").Indexes().CreateMany(ctx, models)
+	if err != nil {
+		return err
+	}
+	_, err = mdb.Collection("published_article_renders").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{"id", 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = mdb.Collection("article_revisions").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{"id", 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{"article_id", 1}, {"author_id", 1}},
+			Options: options.Index(),
+		},
+	})
 	return err
 }
 
 func NewMongoArticleDAO(mdb *mongo.Database, node *snowflake.Node) *MongoDBArticleDAO {
 	return &MongoDBArticleDAO{
-		mdb:     mdb,
-		col:     mdb.Collection("articles"),
-		liveCol: mdb.Collection("published_articles"),
-		node:    node,
+		mdb:       mdb,
+		col:       mdb.Collection("articles"),
+		liveCol:   mdb.Collection("published_articles"),
+		revCol:    mdb.Collection("article_revisions"),
+		renderCol: mdb.Collection("published_article_renders"),
+		node:      node,
 	}
 }
 
@@ -56,8 +90,12 @@ func (m *MongoDBArticleDAO) Insert(ctx context.Context, art Article) (int64, err
 	art.Id = int64(m.node.Generate())
 	art.Ctime = now
 	art.Utime = now
+	art.Version = 1
 	_, err := m.col.InsertOne(ctx, &art)
-	return art.Id, err
+	if err != nil {
+		return 0, err
+	}
+	return art.Id, m.insertRevision(ctx, art)
 }
 
 func (m *MongoDBArticleDAO) UpdateById(ctx context.Context, art Article) error {
@@ -66,19 +104,60 @@ func (m *MongoDBArticleDAO) UpdateById(ctx context.Context, art Article) error {
 	filter := bson.M{
 		"id":        art.Id,
 		"author_id": art.AuthorId,
+		"dtime":     notDeleted,
+	}
+	if art.Version > 0 {
+		filter["version"] = art.Version
+	}
+	set := bson.M{
+		"title":    art.Title,
+		"content":  art.Content,
+		"status":   art.Status,
+		"utime":    art.Utime,
+		"abstract": art.Abstract,
+	}
+	// 标签为 nil、分类为空表示不修改
+	if art.Tags != nil {
+		set["tags"] = art.Tags
+	}
+	if art.Category != "" {
+		set["category"] = art.Category
 	}
 	update := bson.M{
-		"$set": bson.M{
-			"title":   art.Title,
-			"content": art.Content,
-			"status":  art.Status,
-			"utime":   art.Utime,
-		},
+		"$set": set,
+		"$inc": bson.M{"version": 1},
 	}
 	res, err := m.col.UpdateOne(ctx, filter, update)
-	if err == nil && res.ModifiedCount != 1 {
+	if err != nil {
+		return err
+	}
+	if res.ModifiedCount != 1 {
+		if art.Version > 0 {
+			// 区分是版本号过期还是文章不存在、不属于该作者
+			cnt, er := m.col.CountDocuments(ctx, bson.M{"id": art.Id, "author_id": art.AuthorId, "dtime": notDeleted})
+			if er != nil {
+				return er
+			}
+			if cnt > 0 {
+				return ErrVersionConflict
+			}
+		}
 		return fmt.Errorf("更新行数错误，更新了%d行", res.ModifiedCount)
 	}
+	return m.insertRevision(ctx, art)
+}
+
+// insertRevision 每次写制作库都追加一个历史版本
+func (m *MongoDBArticleDAO) insertRevision(ctx context.Context, art Article) error {
+	_, err := m.revCol.InsertOne(ctx, &ArticleRevision{
+		Id:        int64(m.node.Generate()),
+		ArticleId: art.Id,
+		Title:     art.Title,
+		Content:   art.Content,
+		AuthorId:  art.AuthorId,
+		Status:    art.Status,
+		Ctime:     art.Utime,
+	})
 	return err
 }
 
@@ -99,6 +178,16 @@ func (m *MongoDBArticleDAO) Sync(ctx context.Context, art Article) (int64, error
 		return 0, err
 	}
 
+	// 标签、分类以制作库为准
+	var draft Article
+	err = m.col.FindOne(ctx, bson.M{"id": id},
+		options.FindOne().SetProjection(bson.M{"tags": 1, "category": 1})).Decode(&draft)
+	if err != nil {
+		return 0, err
+	}
+	art.Tags = draft.Tags
+	art.Category = draft.Category
+
 	// 更新线上库
 	err = m.Upsert(ctx, PublishArticle(art))
 	return id, err
@@ -110,13 +199,24 @@ func (m *MongoDBArticleDAO) Upsert(ctx context.Context, art PublishArticle) erro
 
 	filter := bson.M{"id": art.Id, "author_id": art.AuthorId}
 	update := bson.M{"$set": art, "$setOnInsert": bson.M{"ctime": now}}
+	if len(art.Tags) == 0 {
+		// omitempty 不会清空线上库已有的标签
+		update["$unset"] = bson.M{"tags": ""}
+	}
 	_, err := m.liveCol.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil || art.Render == nil {
+		return err
+	}
+	render := *art.Render
+	render.Id = art.Id
+	render.Utime = now
+	_, err = m.renderCol.ReplaceOne(ctx, bson.M{"id": art.Id}, &render, options.Replace().SetUpsert(true))
 	return err
 }
 
 func (m *MongoDBArticleDAO) SyncStatus(ctx context.Context, id, usrId int64, status uint8) error {
 	now := time.Now().UnixMilli()
-	filter := bson.M{"id": id, "author_id": usrId}
+	filter := bson.M{"id": id, "author_id": usrId, "dtime": notDeleted}
 	update := bson.M{"$set": bson.D{{"status", status}, {"utime", now}}}
 	res, err := m.col.UpdateOne(ctx, filter, update)
 	if err != nil {
@@ -135,37 +235,328 @@ func (m *MongoDBArticleDAO) SyncStatus(ctx context.Context, id, usrId int64, sta
 	return err
 }
 
-func (m *MongoDBArticleDAO) GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]Article, error) {
-	filter := bson.M{"author_id": uid}
-	opts := options.Find().SetSkip(int64(offset)).SetLimit(int64(limit)).SetSort(bson.M{"utime": -1})
-	cur, err := m.liveCol.Find(ctx, filter, opts)
+func (m *MongoDBArticleDAO) GetByAuthor(ctx context.Context, uid int64, cursorUtime, cursorId int64, limit int) ([]Article, error) {
+	filter := bson.M{"author_id": uid, "dtime": notDeleted}
+	if cursorId > 0 {
+		filter["$or"] = bson.A{
+			bson.M{"utime": bson.M{"$lt": cursorUtime}},
+			bson.M{"utime": cursorUtime, "id": bson.M{"$lt": cursorId}},
+		}
+	}
+	opts := options.Find().SetLimit(int64(limit)).SetSort(bson.D{{"utime", -1}, {"id", -1}})
+	// 作者看到的是自己的草稿，从制作库中查
+	cur, err := m.col.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
 	}
 	defer cur.Close(ctx)
 	var arts []Article
-	err = cur.All(ctx, arts)
+	err = cur.All(ctx, &arts)
 	if err != nil {
 		return nil, err
 	}
 	return arts, nil
 }
 
+func (m *MongoDBArticleDAO) ListPubByAuthor(ctx context.Context, uid int64, cursorUtime, cursorId int64, limit int) ([]PublishArticle, error) {
+	filter := bson.M{"author_id": uid, "status": domain.ArticleStatusPublished.ToUint8()}
+	if cursorId > 0 {
+		filter["$or"] = bson.A{
+			bson.M{"utime": bson.M{"$lt": cursorUtime}},
+			bson.M{"utime": cursorUtime, "id": bson.M{"$lt": cursorId}},
+		}
+	}
+	opts := options.Find().SetLimit(int64(limit)).SetSort(bson.D{{"utime", -1}, {"id", -1}})
+	cur, err := m.liveCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	var arts []PublishArticle
+	err = cur.All(ctx, &arts)
+	return arts, err
+}
+
+func (m *MongoDBArticleDAO) ListPubByAuthors(ctx context.Context, uids []int64, cursorUtime, cursorId int64, limit int) ([]PublishArticle, error) {
+	filter := bson.M{
+		"author_id": bson.M{"$in": uids},
+		"status":    domain.ArticleStatusPublished.ToUint8(),
+	}
+	if cursorId > 0 {
+		filter["$or"] = bson.A{
+			bson.M{"utime": bson.M{"$lt": cursorUtime}},
+			bson.M{"utime": cursorUtime, "id": bson.M{"$lt": cursorId}},
+		}
+	}
+	opts := options.Find().SetLimit(int64(limit)).SetSort(bson.D{{"utime", -1}, {"id", -1}})
+	cur, err := m.liveCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	var arts []PublishArticle
+	err = cur.All(ctx, &arts)
+	return arts, err
+}
+
+func (m *MongoDBArticleDAO) ListLatestPub(ctx context.Context, limit int) ([]PublishArticle, error) {
+	filter := bson.M{"status": domain.ArticleStatusPublished.ToUint8()}
+	opts := options.Find().SetLimit(int64(limit)).
+		SetSort(bson.D{{"utime", -1}, {"id", -1}})
+	cur, err := m.liveCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	var arts []PublishArticle
+	err = cur.All(ctx, &arts)
+	return arts, err
+}
+
 func (m *MongoDBArticleDAO) FindById(ctx context.Context, id, uid int64) (Article, error) {
-	filter := bson.M{"id": id, "author_id": uid}
+	filter := bson.M{"id": id, "author_id": uid, "dtime": notDeleted}
 	var art Article
-	err := m.col.FindOne(ctx, filter).Decode(art)
+	err := m.col.FindOne(ctx, filter).Decode(&art)
+	if err == mongo.ErrNoDocuments {
+		return Article{}, ErrArticleNotFound
+	}
 	if err != nil {
 		return Article{}, err
 	}
 	return art, nil
 }
+
 func (m *MongoDBArticleDAO) FindPubById(ctx context.Context, id int64) (PublishArticle, error) {
-	filter := bson.M{"id": id}
 	var art PublishArticle
-	err := m.col.FindOne(ctx, filter).Decode(art)
+	err := m.liveCol.FindOne(ctx, bson.M{"id": id, "dtime": notDeleted}).Decode(&art)
+	if err == mongo.ErrNoDocuments {
+		return PublishArticle{}, ErrArticleNotFound
+	}
 	if err != nil {
 		return PublishArticle{}, err
 	}
+	var render PublishArticleRender
+	err = m.renderCol.FindOne(ctx, bson.M{"id": id}).Decode(&render)
+	switch err {
+	case nil:
+		art.Render = &render
+	case mongo.ErrNoDocuments:
+	default:
+		return PublishArticle{}, err
+	}
 	return art, nil
 }
+
+func (m *MongoDBArticleDAO) ListPub(ctx context.Context, startId int64, limit int) ([]PublishArticle, error) {
+	filter := bson.M{"id": bson.M{"$gt": startId}}
+	opts := options.Find().SetLimit(int64(limit)).SetSort(bson.M{"id": 1})
+	cur, err := m.liveCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	var arts []PublishArticle
+	err = cur.All(ctx, &arts)
+	return arts, err
+}
+
+func (m *MongoDBArticleDAO) ScanPub(ctx context.Context, startId int64, limit int) ([]PublishArticle, error) {
+	filter := bson.M{"id": bson.M{"$gt": startId}, "status": domain.ArticleStatusPublished.ToUint8()}
+	opts := options.Find().SetLimit(int64(limit)).SetSort(bson.M{"id": 1}).
+		SetProjection(bson.M{"id": 1, "utime": 1})
+	cur, err := m.liveCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	var arts []PublishArticle
+	err = cur.All(ctx, &arts)
+	return arts, err
+}
+
+func (m *MongoDBArticleDAO) Delete(ctx context.Context, id, uid int64) error {
+	now := time.Now().UnixMilli()
+	update := bson.M{"$set": bson.M{
+		"status": domain.ArticleStatusDeleted.ToUint8(),
+		"dtime":  now,
+	}}
+	res, err := m.col.UpdateOne(ctx, bson.M{"id": id, "author_id": uid, "dtime": notDeleted}, update)
+	if err != nil {
+		return err
+	}
+	if res.ModifiedCount == 0 {
+		return ErrArticleNotFound
+	}
+	// 没有发表过的文章线上库里没有记录
+	_, err = m.liveCol.UpdateOne(ctx, bson.M{"id": id}, update)
+	return err
+}
+
+func (m *MongoDBArticleDAO) Restore(ctx context.Context, id, uid int64, since int64) error {
+	now := time.Now().UnixMilli()
+	filter := bson.M{"id": id, "author_id": uid, "dtime": bson.M{"$gte": since}}
+	res, err := m.col.UpdateOne(ctx, filter, bson.M{
+		"$set":   bson.M{"status": domain.ArticleStatusUnpublished.ToUint8(), "utime": now},
+		"$unset": bson.M{"dtime": ""},
+	})
+	if err != nil {
+		return err
+	}
+	if res.ModifiedCount == 0 {
+		return ErrArticleNotFound
+	}
+	// 线上库恢复为仅自己可见，需要作者重新发表
+	_, err = m.liveCol.UpdateOne(ctx, bson.M{"id": id}, bson.M{
+		"$set":   bson.M{"status": domain.ArticleStatusPrivate.ToUint8(), "utime": now},
+		"$unset": bson.M{"dtime": ""},
+	})
+	return err
+}
+
+func (m *MongoDBArticleDAO) ListDeleted(ctx context.Context, uid int64, since int64, offset int, limit int) ([]Article, error) {
+	filter := bson.M{"author_id": uid, "dtime": bson.M{"$gte": since}}
+	opts := options.Find().SetSkip(int64(offset)).SetLimit(int64(limit)).SetSort(bson.M{"dtime": -1})
+	cur, err := m.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	var arts []Article
+	err = cur.All(ctx, &arts)
+	return arts, err
+}
+
+func (m *MongoDBArticleDAO) ListByStatus(ctx context.Context, status uint8, offset int, limit int) ([]Article, error) {
+	filter := bson.M{"status": status, "dtime": notDeleted}
+	opts := options.Find().SetSkip(int64(offset)).SetLimit(int64(limit)).SetSort(bson.M{"utime": 1})
+	cur, err := m.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	var arts []Article
+	err = cur.All(ctx, &arts)
+	return arts, err
+}
+
+func (m *MongoDBArticleDAO) FindByStatus(ctx context.Context, id int64, status uint8) (Article, error) {
+	var art Article
+	err := m.col.FindOne(ctx, bson.M{"id": id, "status": status, "dtime": notDeleted}).Decode(&art)
+	if err == mongo.ErrNoDocuments {
+		return Article{}, ErrArticleNotFound
+	}
+	return art, err
+}
+
+func (m *MongoDBArticleDAO) TransitStatus(ctx context.Context, id int64, from, to uint8) error {
+	filter := bson.M{"id": id, "status": from, "dtime": notDeleted}
+	res, err := m.col.UpdateOne(ctx, filter, bson.M{"$set": bson.M{
+		"status": to,
+		"utime":  time.Now().UnixMilli(),
+	}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrArticleNotFound
+	}
+	return nil
+}
+
+func (m *MongoDBArticleDAO) ListExpired(ctx context.Context, before int64, limit int) ([]Article, error) {
+	filter := bson.M{"dtime": bson.M{"$gt": 0, "$lt": before}}
+	opts := options.Find().SetLimit(int64(limit)).SetSort(bson.M{"id": 1}).
+		SetProjection(bson.M{"id": 1, "author_id": 1})
+	cur, err := m.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	var arts []Article
+	err = cur.All(ctx, &arts)
+	return arts, err
+}
+
+func (m *MongoDBArticleDAO) Purge(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	filter := bson.M{"id": bson.M{"$in": ids}}
+	for _, col := range []*mongo.Collection{m.col, m.liveCol, m.renderCol} {
+		_, err := col.DeleteMany(ctx, filter)
+		if err != nil {
+			return err
+		}
+	}
+	_, err := m.revCol.DeleteMany(ctx, bson.M{"article_id": bson.M{"$in": ids}})
+	return err
+}
+
+func (m *MongoDBArticleDAO) ListRevisions(ctx context.Context, aid, uid int64, offset int, limit int) ([]ArticleRevision, error) {
+	filter := bson.M{"article_id": aid, "author_id": uid}
+	// 雪花 id 单调递增，按 id 倒序即按时间倒序；列表不需要内容
+	opts := options.Find().SetSkip(int64(offset)).SetLimit(int64(limit)).
+		SetSort(bson.M{"id": -1}).
+		SetProjection(bson.M{"content": 0})
+	cur, err := m.revCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	var revs []ArticleRevision
+	err = cur.All(ctx, &revs)
+	return revs, err
+}
+
+func (m *MongoDBArticleDAO) FindRevision(ctx context.Context, id, aid, uid int64) (ArticleRevision, error) {
+	filter := bson.M{"id": id, "article_id": aid, "author_id": uid}
+	var rev ArticleRevision
+	err := m.revCol.FindOne(ctx, filter).Decode(&rev)
+	if err == mongo.ErrNoDocuments {
+		return ArticleRevision{}, ErrArticleNotFound
+	}
+	if err != nil {
+		return ArticleRevision{}, err
+	}
+	return rev, nil
+}
+
+func (m *MongoDBArticleDAO) ListPubByTag(ctx context.Context, tag string, offset int, limit int) ([]PublishArticle, error) {
+	filter := bson.M{"tags": tag, "status": domain.ArticleStatusPublished.ToUint8()}
+	opts := options.Find().SetSkip(int64(offset)).SetLimit(int64(limit)).SetSort(bson.D{{"utime", -1}, {"id", -1}})
+	cur, err := m.liveCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	var arts []PublishArticle
+	err = cur.All(ctx, &arts)
+	return arts, err
+}
+
+func (m *MongoDBArticleDAO) TagCounts(ctx context.Context) ([]TagCount, error) {
+	pipeline := mongo.Pipeline{
+		{{"$match", bson.M{"status": domain.ArticleStatusPublished.ToUint8()}}},
+		{{"$unwind", "$tags"}},
+		{{"$group", bson.M{"_id": "$tags", "cnt": bson.M{"$sum": 1}}}},
+		{{"$sort", bson.M{"cnt": -1}}},
+	}
+	cur, err := m.liveCol.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	var rows []struct {
+		Name string `bson:"_id"`
+		Cnt  int64  `bson:"cnt"`
+	}
+	err = cur.All(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]TagCount, 0, len(rows))
+	for _, r := range rows {
+		res = append(res, TagCount{Name: r.Name, Cnt: r.Cnt})
+	}
+	return res, nil
+}
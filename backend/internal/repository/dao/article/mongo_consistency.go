@@ -0,0 +1,77 @@
+package article
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+func (m *MongoDBArticleDAO) ScanPairs(ctx context.Context, startId int64, limit int) ([]ArticlePair, error) {
+	filter := bson.M{"id": bson.M{"$gt": startId}}
+	opts := options.Find().SetLimit(int64(limit)).SetSort(bson.M{"id": 1})
+	var drafts []Article
+	err := m.findAll(ctx, m.col, filter, opts, &drafts)
+	if err != nil {
+		return nil, err
+	}
+	var pubs []PublishArticle
+	err = m.findAll(ctx, m.liveCol, filter, opts, &pubs)
+	if err != nil {
+		return nil, err
+	}
+	return pairArticles(drafts, pubs, limit), nil
+}
+
+func (m *MongoDBArticleDAO) findAll(ctx context.Context, col *mongo.Collection, filter any,
+	opts *options.FindOptions, res any) error {
+	cur, err := col.Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+	return cur.All(ctx, res)
+}
+
+// RepairPub MongoDB 没有跨集合的事务，只在写入前校验版本号，期间作者再次发表时由下一次检查修复
+func (m *MongoDBArticleDAO) RepairPub(ctx context.Context, id, version int64, render *PublishArticleRender) error {
+	filter := bson.M{"id": id}
+	var draft Article
+	err := m.col.FindOne(ctx, filter).Decode(&draft)
+	if err == mongo.ErrNoDocuments {
+		return m.Purge(ctx, []int64{id})
+	}
+	if err != nil {
+		return err
+	}
+	if draft.Version != version {
+		return ErrVersionConflict
+	}
+
+	var old *PublishArticle
+	var pub PublishArticle
+	err = m.liveCol.FindOne(ctx, filter).Decode(&pub)
+	switch err {
+	case nil:
+		old = &pub
+	case mongo.ErrNoDocuments:
+	default:
+		return err
+	}
+
+	res := repairedPub(draft, old, time.Now().UnixMilli())
+	if res == nil {
+		return nil
+	}
+	upsert := options.Replace().SetUpsert(true)
+	_, err = m.liveCol.ReplaceOne(ctx, filter, res, upsert)
+	if err != nil || render == nil {
+		return err
+	}
+	r := *render
+	r.Id = id
+	r.Utime = res.Utime
+	_, err = m.renderCol.ReplaceOne(ctx, filter, &r, upsert)
+	return err
+}
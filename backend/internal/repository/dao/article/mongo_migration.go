@@ -0,0 +1,113 @@
+package article
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ MigratableDAO = &MongoDBArticleDAO{}
+
+func (m *MongoDBArticleDAO) Snapshot(ctx context.Context, id int64) (ArticleSnapshot, error) {
+	res := ArticleSnapshot{Id: id}
+	filter := bson.M{"id": id}
+	var draft Article
+	err := m.col.FindOne(ctx, filter).Decode(&draft)
+	if err == mongo.ErrNoDocuments {
+		return res, nil
+	}
+	if err != nil {
+		return res, err
+	}
+	res.Draft = &draft
+
+	var pub PublishArticle
+	err = m.liveCol.FindOne(ctx, filter).Decode(&pub)
+	switch err {
+	case nil:
+		var render PublishArticleRender
+		err = m.renderCol.FindOne(ctx, filter).Decode(&render)
+		if err == nil {
+			pub.Render = &render
+		} else if err != mongo.ErrNoDocuments {
+			return res, err
+		}
+		res.Pub = &pub
+	case mongo.ErrNoDocuments:
+	default:
+		return res, err
+	}
+
+	cur, err := m.revCol.Find(ctx, bson.M{"article_id": id}, options.Find().SetSort(bson.M{"id": 1}))
+	if err != nil {
+		return res, err
+	}
+	defer cur.Close(ctx)
+	err = cur.All(ctx, &res.Revisions)
+	return res, err
+}
+
+// ApplySnapshot MongoDB 没有跨集合的事务，中途失败时由下一次校验修复
+func (m *MongoDBArticleDAO) ApplySnapshot(ctx context.Context, s ArticleSnapshot) error {
+	if s.Draft == nil {
+		return m.Purge(ctx, []int64{s.Id})
+	}
+	filter := bson.M{"id": s.Id}
+	upsert := options.Replace().SetUpsert(true)
+	_, err := m.col.ReplaceOne(ctx, filter, s.Draft, upsert)
+	if err != nil {
+		return err
+	}
+
+	if s.Pub == nil {
+		_, err = m.liveCol.DeleteOne(ctx, filter)
+		if err == nil {
+			_, err = m.renderCol.DeleteOne(ctx, filter)
+		}
+	} else {
+		_, err = m.liveCol.ReplaceOne(ctx, filter, s.Pub, upsert)
+		if err == nil && s.Pub.Render == nil {
+			_, err = m.renderCol.DeleteOne(ctx, filter)
+		} else if err == nil {
+			render := *s.Pub.Render
+			render.Id = s.Id
+			_, err = m.renderCol.ReplaceOne(ctx, filter, &render, upsert)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = m.revCol.DeleteMany(ctx, bson.M{"article_id": s.Id})
+	if err != nil || len(s.Revisions) == 0 {
+		return err
+	}
+	docs := make([]any, 0, len(s.Revisions))
+	for i := range s.Revisions {
+		docs = append(docs, &s.Revisions[i])
+	}
+	_, err = m.revCol.InsertMany(ctx, docs)
+	return err
+}
+
+func (m *MongoDBArticleDAO) ScanIds(ctx context.Context, startId int64, since int64, limit int) ([]int64, error) {
+	filter := bson.M{"id": bson.M{"$gt": startId}, "utime": bson.M{"$gte": since}}
+	opts := options.Find().SetLimit(int64(limit)).SetSort(bson.M{"id": 1}).
+		SetProjection(bson.M{"id": 1})
+	cur, err := m.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	var arts []Article
+	err = cur.All(ctx, &arts)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(arts))
+	for _, art := range arts {
+		ids = append(ids, art.Id)
+	}
+	return ids, nil
+}
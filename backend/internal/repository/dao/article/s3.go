@@ -1,12 +1,11 @@
 package article
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"github.com/aws/aws-sdk-go/service/s3"
-	"github.com/ecodeclub/ekit"
 	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/pkg/blobstore"
+	"github.com/johnwongx/webook/backend/pkg/logger"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	"strconv"
@@ -15,18 +14,18 @@ import (
 
 var _ ArticleDAO = &S3DAO{}
 
+// S3DAO 线上库的文章内容存放在对象存储中，数据库只保存其余字段
 type S3DAO struct {
-	oss    *s3.S3
-	bucket *string
+	store blobstore.BlobStore
 	GORMArticleDAO
 }
 
-func NewS3DAO(oss *s3.S3, db *gorm.DB) *S3DAO {
+func NewS3DAO(store blobstore.BlobStore, db *gorm.DB, l logger.Logger) *S3DAO {
 	return &S3DAO{
-		oss:    oss,
-		bucket: ekit.ToPtr[string]("webook-1314583317"),
+		store: store,
 		GORMArticleDAO: GORMArticleDAO{
 			db: db,
+			l:  l,
 		},
 	}
 }
@@ -39,49 +38,61 @@ func (s *S3DAO) Sync(ctx context.Context, art Article) (int64, error) {
 			err error
 		)
 
-		// 更新制作库，插入或删除
+		// 更新制作库，插入或删除；版本号校验由 GORMArticleDAO 完成
+		txDAO := &GORMArticleDAO{db: tx, l: s.l}
 		if id > 0 {
-			err = s.UpdateById(ctx, art)
+			err = txDAO.UpdateById(ctx, art)
 		} else {
-			id, err = s.Insert(ctx, art)
+			id, err = txDAO.Insert(ctx, art)
 		}
 		if err != nil {
 			return err
 		}
 		// 更新数据到线上库
 		art.Id = id
+		err = txDAO.draftMeta(&art)
+		if err != nil {
+			return err
+		}
 
 		pArt := PublishArticle(art)
+		// 内容只放在对象存储里
+		pArt.Content = ""
 		now := time.Now().UnixMilli()
 		pArt.Ctime = now
 		pArt.Utime = now
 
-		return s.db.WithContext(ctx).
+		err = tx.
 			Clauses(clause.OnConflict{
 				DoUpdates: clause.Assignments(map[string]interface{}{
-					"title":  art.Title,
-					"utime":  art.Utime,
-					"status": art.Status,
+					"title":       art.Title,
+					"utime":       art.Utime,
+					"status":      art.Status,
+					"category_id": art.CategoryId,
 				}),
 			}).Create(&pArt).Error
+		if err != nil {
+			return err
+		}
+		err = txDAO.upsertRender(tx, art.Id, art.Render)
+		if err != nil {
+			return err
+		}
+		return txDAO.setTags(tx, art.Id, art.Tags, true)
 	})
 	if err != nil {
 		return 0, err
 	}
-	_, err = s.oss.PutObjectWithContext(ctx, &s3.PutObjectInput{
-		Bucket:      s.bucket,
-		Key:         ekit.ToPtr[string](strconv.FormatInt(art.Id, 10)),
-		Body:        bytes.NewReader([]byte(art.Content)),
-		ContentType: ekit.ToPtr[string]("text/plain;charset=utf-8"),
-	})
+	err = s.store.Put(ctx, s.key(art.Id), []byte(art.Content), "text/plain;charset=utf-8")
 	return art.Id, err
 }
 
 func (s *S3DAO) SyncStatus(ctx context.Context, id, usrId int64, status uint8) error {
 	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		now := time.Now().UnixMilli()
+		// 回收站里的文章不能发表或者撤回
 		res := tx.Model(&Article{}).
-			Where("id = ? AND author_id = ?", id, usrId).
+			Where("id = ? AND author_id = ? AND dtime = 0", id, usrId).
 			Updates(map[string]any{
 				"status": status,
 				"utime":  now,
@@ -90,11 +101,11 @@ func (s *S3DAO) SyncStatus(ctx context.Context, id, usrId int64, status uint8) e
 			return res.Error
 		}
 		if res.RowsAffected != 1 {
-			return fmt.Errorf("可能有人在攻击系统，误操作非自己的文章, Uid:%d, authorId:", id, usrId)
+			return fmt.Errorf("可能有人在攻击系统，误操作非自己的文章, id:%d, authorId:%d", id, usrId)
 		}
 
 		return tx.Model(&PublishArticle{}).
-			Where("id = ?", id).
+			Where("id = ? AND dtime = 0", id).
 			Updates(map[string]any{
 				"status": status,
 				"utime":  now,
@@ -104,10 +115,116 @@ func (s *S3DAO) SyncStatus(ctx context.Context, id, usrId int64, status uint8) e
 		return err
 	}
 	if status == domain.ArticleStatusPrivate.ToUint8() {
-		_, err = s.oss.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
-			Bucket: s.bucket,
-			Key:    ekit.ToPtr[string](strconv.FormatInt(id, 10)),
-		})
+		err = s.store.Delete(ctx, s.key(id))
 	}
 	return err
 }
+
+func (s *S3DAO) Delete(ctx context.Context, id, uid int64) error {
+	err := s.GORMArticleDAO.Delete(ctx, id, uid)
+	if err != nil {
+		return err
+	}
+	// 和撤回一样，删除后线上内容不再对外提供
+	return s.store.Delete(ctx, s.key(id))
+}
+
+func (s *S3DAO) Purge(ctx context.Context, ids []int64) error {
+	// 先删对象再删记录，删除对象失败时下次清理还能找到这些文章；对象不存在不会报错
+	for _, id := range ids {
+		err := s.store.Delete(ctx, s.key(id))
+		if err != nil {
+			return err
+		}
+	}
+	return s.GORMArticleDAO.Purge(ctx, ids)
+}
+
+func (s *S3DAO) FindPubById(ctx context.Context, id int64) (PublishArticle, error) {
+	art, err := s.GORMArticleDAO.FindPubById(ctx, id)
+	if err != nil {
+		return PublishArticle{}, err
+	}
+	err = s.loadContent(ctx, &art)
+	return art, err
+}
+
+func (s *S3DAO) ListPub(ctx context.Context, startId int64, limit int) ([]PublishArticle, error) {
+	arts, err := s.GORMArticleDAO.ListPub(ctx, startId, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range arts {
+		err = s.loadContent(ctx, &arts[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return arts, nil
+}
+
+// ScanPairs 已发表的文章从对象存储加载内容，撤回或删除的文章检查对象是否已经删除
+func (s *S3DAO) ScanPairs(ctx context.Context, startId int64, limit int) ([]ArticlePair, error) {
+	pairs, err := s.GORMArticleDAO.ScanPairs(ctx, startId, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range pairs {
+		p := &pairs[i]
+		if p.Pub == nil {
+			continue
+		}
+		online := s.online(*p.Pub)
+		data, err := s.store.Get(ctx, s.key(p.Id))
+		switch {
+		case err == blobstore.ErrNotFound:
+			p.ObjectMissing = online
+		case err != nil:
+			return nil, err
+		case online:
+			p.Pub.Content = string(data)
+		default:
+			p.ObjectStale = true
+		}
+		p.PubContentStored = online && !p.ObjectMissing
+	}
+	return pairs, nil
+}
+
+// RepairPub 修复数据库之后再按线上库的状态写入或删除对象
+func (s *S3DAO) RepairPub(ctx context.Context, id, version int64, render *PublishArticleRender) error {
+	pub, err := s.GORMArticleDAO.repairPub(ctx, id, version, render, false)
+	if err != nil {
+		return err
+	}
+	if pub == nil || !s.online(*pub) {
+		return s.store.Delete(ctx, s.key(id))
+	}
+	return s.store.Put(ctx, s.key(id), []byte(pub.Content), "text/plain;charset=utf-8")
+}
+
+// online 只有已发表的文章在对象存储中有内容
+func (s *S3DAO) online(art PublishArticle) bool {
+	return art.Status == domain.ArticleStatusPublished.ToUint8() && art.Dtime == 0
+}
+
+// loadContent 撤回后对象已经删除，内容为空
+func (s *S3DAO) loadContent(ctx context.Context, art *PublishArticle) error {
+	data, err := s.store.Get(ctx, s.key(art.Id))
+	switch err {
+	case nil:
+		art.Content = string(data)
+		return nil
+	case blobstore.ErrNotFound:
+		if art.Status == domain.ArticleStatusPublished.ToUint8() {
+			s.l.Warn("已发表文章的内容不存在", logger.Int64("id", art.Id))
+		}
+		return nil
+	default:
+		return err
+	}
+}
+
+func (s *S3DAO) key(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,171 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+const (
+	ScheduleStatusUnknown uint8 = iota
+	ScheduleStatusPending
+	ScheduleStatusRunning
+	ScheduleStatusDone
+	ScheduleStatusCancelled
+	// ScheduleStatusFailed 重试多次仍然失败，不再执行
+	ScheduleStatusFailed
+)
+
+var (
+	ErrScheduleNotFound = gorm.ErrRecordNotFound
+	// ErrScheduleNotPending 任务已经执行或者被取消
+	ErrScheduleNotPending = errors.New("定时任务不处于等待状态")
+	// ErrScheduleNotOwned 执行超时，任务已经被其他实例重新抢占
+	ErrScheduleNotOwned = errors.New("定时任务已经被重新抢占")
+)
+
+type ArticleScheduleDAO interface {
+	Insert(ctx context.Context, s ArticleSchedule) (int64, error)
+	ListPending(ctx context.Context, uid int64) ([]ArticleSchedule, error)
+	Cancel(ctx context.Context, id, uid int64) error
+	CancelByArticle(ctx context.Context, aid, uid int64, action uint8) error
+	// Preempt 抢占一个到期的任务，多实例下同一个任务只会被一个实例抢到。
+	// 返回的 Utime 是抢占的时间，结束任务时用它确认任务还归自己
+	Preempt(ctx context.Context, now int64, timeout time.Duration) (ArticleSchedule, error)
+	// Finish 以 status 结束抢占到的任务，任务已经被重新抢占时返回 ErrScheduleNotOwned
+	Finish(ctx context.Context, s ArticleSchedule, status uint8) error
+	// Retry 把抢占到的任务放回等待队列，到 executeAt 再执行，重试次数加一
+	Retry(ctx context.Context, s ArticleSchedule, executeAt int64) error
+}
+
+type GORMArticleScheduleDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMArticleScheduleDAO(db *gorm.DB) ArticleScheduleDAO {
+	return &GORMArticleScheduleDAO{
+		db: db,
+	}
+}
+
+func (g *GORMArticleScheduleDAO) Insert(ctx context.Context, s ArticleSchedule) (int64, error) {
+	now := time.Now().UnixMilli()
+	s.Status = ScheduleStatusPending
+	s.Ctime = now
+	s.Utime = now
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 同一篇文章的同一种动作只保留最新的定时任务
+		err := NewGORMArticleScheduleDAO(tx).CancelByArticle(ctx, s.ArticleId, s.AuthorId, s.Action)
+		if err != nil {
+			return err
+		}
+		return tx.Create(&s).Error
+	})
+	return s.Id, err
+}
+
+func (g *GORMArticleScheduleDAO) ListPending(ctx context.Context, uid int64) ([]ArticleSchedule, error) {
+	var res []ArticleSchedule
+	err := g.db.WithContext(ctx).
+		Where("author_id = ? AND status = ?", uid, ScheduleStatusPending).
+		Order("execute_at ASC").
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMArticleScheduleDAO) Cancel(ctx context.Context, id, uid int64) error {
+	res := g.db.WithContext(ctx).Model(&ArticleSchedule{}).
+		Where("id = ? AND author_id = ? AND status = ?", id, uid, ScheduleStatusPending).
+		Updates(map[string]any{
+			"status": ScheduleStatusCancelled,
+			"utime":  time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrScheduleNotPending
+	}
+	return nil
+}
+
+func (g *GORMArticleScheduleDAO) CancelByArticle(ctx context.Context, aid, uid int64, action uint8) error {
+	return g.db.WithContext(ctx).Model(&ArticleSchedule{}).
+		Where("article_id = ? AND author_id = ? AND action = ? AND status = ?",
+			aid, uid, action, ScheduleStatusPending).
+		Updates(map[string]any{
+			"status": ScheduleStatusCancelled,
+			"utime":  time.Now().UnixMilli(),
+		}).Error
+}
+
+func (g *GORMArticleScheduleDAO) Preempt(ctx context.Context, now int64, timeout time.Duration) (ArticleSchedule, error) {
+	var s ArticleSchedule
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 执行中但长时间没有结束的任务，认为执行它的实例已经崩溃，允许重新抢占
+		staleTime := now - timeout.Milliseconds()
+		// 占有更新锁，跳过其他实例正在抢占的行
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("(status = ? AND execute_at <= ?) OR (status = ? AND utime < ?)",
+				ScheduleStatusPending, now, ScheduleStatusRunning, staleTime).
+			Order("execute_at ASC").
+			First(&s).Error
+		if err != nil {
+			return err
+		}
+		s.Status = ScheduleStatusRunning
+		s.Utime = now
+		return tx.Model(&ArticleSchedule{}).
+			Where("id = ?", s.Id).
+			Updates(map[string]any{
+				"status": s.Status,
+				"utime":  s.Utime,
+			}).Error
+	})
+	return s, err
+}
+
+func (g *GORMArticleScheduleDAO) Finish(ctx context.Context, s ArticleSchedule, status uint8) error {
+	return g.release(ctx, s, map[string]any{
+		"status": status,
+		"utime":  time.Now().UnixMilli(),
+	})
+}
+
+func (g *GORMArticleScheduleDAO) Retry(ctx context.Context, s ArticleSchedule, executeAt int64) error {
+	return g.release(ctx, s, map[string]any{
+		"status":     ScheduleStatusPending,
+		"execute_at": executeAt,
+		"retries":    gorm.Expr("`retries` + 1"),
+		"utime":      time.Now().UnixMilli(),
+	})
+}
+
+// release 只有任务仍然是自己抢占时的样子才更新，避免和重新抢占的实例重复执行
+func (g *GORMArticleScheduleDAO) release(ctx context.Context, s ArticleSchedule, updates map[string]any) error {
+	res := g.db.WithContext(ctx).Model(&ArticleSchedule{}).
+		Where("id = ? AND status = ? AND utime = ?", s.Id, ScheduleStatusRunning, s.Utime).
+		Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrScheduleNotOwned
+	}
+	return nil
+}
+
+type ArticleSchedule struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	ArticleId int64 `gorm:"index"`
+	AuthorId  int64 `gorm:"index"`
+	Action    uint8
+	Status    uint8 `gorm:"index:status_execute_at"`
+	ExecuteAt int64 `gorm:"index:status_execute_at"`
+	// Retries 执行失败后重试过的次数
+	Retries int
+	Ctime   int64
+	Utime   int64
+}
@@ -0,0 +1,159 @@
+package article
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestGORMArticleScheduleDAO_Preempt(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(t *testing.T) *sql.DB
+		want    ArticleSchedule
+		wantErr error
+	}{
+		{
+			name: "抢占成功",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				rows := sqlmock.NewRows([]string{"id", "article_id", "author_id", "action", "status", "execute_at"}).
+					AddRow(1, 2, 123, 1, ScheduleStatusPending, 1000)
+				mock.ExpectQuery("SELECT .* FROM `article_schedules` WHERE .* FOR UPDATE SKIP LOCKED").
+					WillReturnRows(rows)
+				mock.ExpectExec("UPDATE `article_schedules` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				return mockDB
+			},
+			want: ArticleSchedule{
+				Id:        1,
+				ArticleId: 2,
+				AuthorId:  123,
+				Action:    1,
+				Status:    ScheduleStatusRunning,
+				ExecuteAt: 1000,
+				Utime:     2000,
+			},
+		},
+		{
+			name: "没有到期的任务",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT .* FROM `article_schedules` WHERE .* FOR UPDATE SKIP LOCKED").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+				return mockDB
+			},
+			wantErr: ErrScheduleNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      tc.mock(t),
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			d := NewGORMArticleScheduleDAO(db)
+			s, err := d.Preempt(context.Background(), 2000, time.Minute)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, s)
+		})
+	}
+}
+
+func TestGORMArticleScheduleDAO_Cancel(t *testing.T) {
+	testCases := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{
+			name:     "取消成功",
+			affected: 1,
+		},
+		{
+			name:     "任务已经执行",
+			affected: 0,
+			wantErr:  ErrScheduleNotPending,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			mock.ExpectExec("UPDATE `article_schedules` SET .*").
+				WillReturnResult(sqlmock.NewResult(0, tc.affected))
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      mockDB,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			err = NewGORMArticleScheduleDAO(db).Cancel(context.Background(), 1, 123)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestGORMArticleScheduleDAO_Retry(t *testing.T) {
+	testCases := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{
+			name:     "放回等待队列",
+			affected: 1,
+		},
+		{
+			name:     "已经被其他实例重新抢占",
+			affected: 0,
+			wantErr:  ErrScheduleNotOwned,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			mock.ExpectExec("UPDATE `article_schedules` SET `execute_at`=\\?,`retries`=`retries` \\+ 1,`status`=\\?,`utime`=\\? "+
+				"WHERE id = \\? AND status = \\? AND utime = \\?").
+				WithArgs(int64(5000), ScheduleStatusPending, sqlmock.AnyArg(), int64(1), ScheduleStatusRunning, int64(2000)).
+				WillReturnResult(sqlmock.NewResult(0, tc.affected))
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      mockDB,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			err = NewGORMArticleScheduleDAO(db).Retry(context.Background(), ArticleSchedule{
+				Id:     1,
+				Status: ScheduleStatusRunning,
+				Utime:  2000,
+			}, 5000)
+			assert.Equal(t, tc.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -2,15 +2,73 @@ package article
 
 import (
 	"context"
+	"errors"
+	"gorm.io/gorm"
 )
 
-type ArticleDAO interface {
+var (
+	// ErrVersionConflict 草稿已经被其他地方修改，提交的版本号过期
+	ErrVersionConflict = errors.New("文章版本冲突")
+	ErrArticleNotFound = gorm.ErrRecordNotFound
+)
+
+// AuthorArticleDAO 作者写草稿的部分，只写制作库。
+// 每次写入版本号加一并记录历史版本，由 ArticleDAO 的各个实现提供
+type AuthorArticleDAO interface {
 	Insert(ctx context.Context, art Article) (int64, error)
+	// UpdateById art.Version 大于 0 时会校验版本号，不一致返回 ErrVersionConflict
 	UpdateById(ctx context.Context, art Article) error
+}
+
+// ArticleDAO 作者侧的完整实现，包括制作库和线上库
+type ArticleDAO interface {
+	AuthorArticleDAO
 	Sync(ctx context.Context, art Article) (int64, error)
 	Upsert(ctx context.Context, art PublishArticle) error
 	SyncStatus(ctx context.Context, id, usrId int64, status uint8) error
-	GetByAuthor(ctx context.Context, id int64, offset int, limit int) ([]Article, error)
+	// GetByAuthor 按 (utime, id) 倒序返回排在游标之后的 limit 篇文章，cursorId 为 0 时从头开始
+	GetByAuthor(ctx context.Context, uid int64, cursorUtime, cursorId int64, limit int) ([]Article, error)
 	FindById(ctx context.Context, id, uid int64) (Article, error)
 	FindPubById(ctx context.Context, id int64) (PublishArticle, error)
+	// ListPub 按 id 升序遍历线上库，返回 id 大于 startId 的 limit 条记录，不区分状态，包括回收站中的
+	ListPub(ctx context.Context, startId int64, limit int) ([]PublishArticle, error)
+	// ScanPub 按 id 升序遍历已发表的文章，只查 id 和 utime，不包括仅自己可见的
+	ScanPub(ctx context.Context, startId int64, limit int) ([]PublishArticle, error)
+
+	// ListPubByAuthor 作者已发表的文章，不包括仅自己可见的，按 (utime, id) 倒序返回排在游标之后的 limit 篇，cursorId 为 0 时从头开始
+	ListPubByAuthor(ctx context.Context, uid int64, cursorUtime, cursorId int64, limit int) ([]PublishArticle, error)
+	// ListPubByAuthors 多个作者已发表的文章，按 (utime, id) 倒序返回排在游标之后的 limit 篇，cursorId 为 0 时从头开始
+	ListPubByAuthors(ctx context.Context, uids []int64, cursorUtime, cursorId int64, limit int) ([]PublishArticle, error)
+	ListPubByTag(ctx context.Context, tag string, offset int, limit int) ([]PublishArticle, error)
+	// ListLatestPub 全站最近更新的 limit 篇已发表文章，按 utime 倒序
+	ListLatestPub(ctx context.Context, limit int) ([]PublishArticle, error)
+	TagCounts(ctx context.Context) ([]TagCount, error)
+
+	// Delete 移入回收站，制作库和线上库的状态都改为已删除
+	Delete(ctx context.Context, id, uid int64) error
+	// Restore 恢复 since 之后删除的文章，恢复后是未发表的草稿
+	Restore(ctx context.Context, id, uid int64, since int64) error
+	// ListDeleted 回收站里 since 之后删除的文章，按删除时间倒序
+	ListDeleted(ctx context.Context, uid int64, since int64, offset int, limit int) ([]Article, error)
+	// ListExpired before 之前删除的文章，按 id 升序，只有 id 和作者
+	ListExpired(ctx context.Context, before int64, limit int) ([]Article, error)
+	// Purge 彻底删除文章及其历史版本、标签和渲染结果
+	Purge(ctx context.Context, ids []int64) error
+
+	// ListByStatus 所有作者处于 status 的草稿，按更新时间升序
+	ListByStatus(ctx context.Context, status uint8, offset int, limit int) ([]Article, error)
+	// FindByStatus 不限作者，草稿不处于 status 时返回 ErrArticleNotFound
+	FindByStatus(ctx context.Context, id int64, status uint8) (Article, error)
+	// TransitStatus 只修改制作库，草稿不处于 from 时返回 ErrArticleNotFound
+	TransitStatus(ctx context.Context, id int64, from, to uint8) error
+
+	ListRevisions(ctx context.Context, aid, uid int64, offset int, limit int) ([]ArticleRevision, error)
+	FindRevision(ctx context.Context, id, aid, uid int64) (ArticleRevision, error)
+
+	// ScanPairs 按 id 升序成对遍历制作库和线上库中 id 大于 startId 的文章，包括回收站中的，
+	// 每边最多查 limit 条；不包括标签和渲染结果
+	ScanPairs(ctx context.Context, startId int64, limit int) ([]ArticlePair, error)
+	// RepairPub 以制作库为准修复线上库，render 不为 nil 时一并覆盖渲染结果。
+	// 制作库的版本号不是 version 时返回 ErrVersionConflict，制作库中已经没有这篇文章时彻底删除
+	RepairPub(ctx context.Context, id, version int64, render *PublishArticleRender) error
 }
@@ -0,0 +1,130 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+var ErrCollectionNotFound = gorm.ErrRecordNotFound
+
+type CollectionDAO interface {
+	Insert(ctx context.Context, c Collection) (int64, error)
+	UpdateName(ctx context.Context, id, uid int64, name string) error
+	// Delete 删除收藏夹以及里面的收藏，同时减少被收藏对象的收藏数，返回被删除的收藏
+	Delete(ctx context.Context, id, uid int64) ([]UserCollectBiz, error)
+	FindById(ctx context.Context, id, uid int64) (Collection, error)
+	// FindByUid 按创建时间倒序
+	FindByUid(ctx context.Context, uid int64, offset, limit int) ([]Collection, error)
+	// FindItems 按收藏时间倒序
+	FindItems(ctx context.Context, cid, uid int64, offset, limit int) ([]UserCollectBiz, error)
+	// MoveItem 没有收藏过时返回 ErrDataNotFound
+	MoveItem(ctx context.Context, biz string, bizId, uid, cid int64) error
+}
+
+type GORMCollectionDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMCollectionDAO(db *gorm.DB) CollectionDAO {
+	return &GORMCollectionDAO{
+		db: db,
+	}
+}
+
+func (g *GORMCollectionDAO) Insert(ctx context.Context, c Collection) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	err := g.db.WithContext(ctx).Create(&c).Error
+	return c.Id, err
+}
+
+func (g *GORMCollectionDAO) UpdateName(ctx context.Context, id, uid int64, name string) error {
+	return g.db.WithContext(ctx).Model(&Collection{}).
+		Where("id = ? AND user_id = ?", id, uid).
+		Updates(map[string]any{
+			"name":  name,
+			"utime": time.Now().UnixMilli(),
+		}).Error
+}
+
+func (g *GORMCollectionDAO) Delete(ctx context.Context, id, uid int64) ([]UserCollectBiz, error) {
+	var items []UserCollectBiz
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND user_id = ?", id, uid).Delete(&Collection{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrCollectionNotFound
+		}
+		err := tx.Where("cid = ? AND user_id = ?", id, uid).Find(&items).Error
+		if err != nil || len(items) == 0 {
+			return err
+		}
+		ids := make([]int64, 0, len(items))
+		for _, item := range items {
+			ids = append(ids, item.Id)
+		}
+		err = tx.Where("id IN ?", ids).Delete(&UserCollectBiz{}).Error
+		if err != nil {
+			return err
+		}
+		now := time.Now().UnixMilli()
+		for _, item := range items {
+			err = decrCollectCnt(tx, item.Biz, item.BizId, now)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return items, err
+}
+
+func (g *GORMCollectionDAO) FindById(ctx context.Context, id, uid int64) (Collection, error) {
+	var c Collection
+	err := g.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, uid).
+		First(&c).Error
+	return c, err
+}
+
+func (g *GORMCollectionDAO) FindByUid(ctx context.Context, uid int64, offset, limit int) ([]Collection, error) {
+	var res []Collection
+	err := g.db.WithContext(ctx).
+		Where("user_id = ?", uid).
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMCollectionDAO) FindItems(ctx context.Context, cid, uid int64, offset, limit int) ([]UserCollectBiz, error) {
+	var res []UserCollectBiz
+	err := g.db.WithContext(ctx).
+		Where("cid = ? AND user_id = ?", cid, uid).
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMCollectionDAO) MoveItem(ctx context.Context, biz string, bizId, uid, cid int64) error {
+	res := g.db.WithContext(ctx).Model(&UserCollectBiz{}).
+		Where("biz_id = ? AND biz = ? AND user_id = ?", bizId, biz, uid).
+		Updates(map[string]any{
+			"cid":   cid,
+			"utime": time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrDataNotFound
+	}
+	return nil
+}
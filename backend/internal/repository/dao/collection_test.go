@@ -0,0 +1,97 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestGORMCollectionDAO_Delete(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mock      func(t *testing.T) *sql.DB
+		wantItems []UserCollectBiz
+		wantErr   error
+	}{
+		{
+			name: "删除收藏夹并减少收藏数",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `collections` WHERE .*").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT \\* FROM `user_collect_bizs` WHERE .*").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "cid", "biz_id", "biz", "user_id"}).
+						AddRow(10, 1, 100, "article", 2))
+				mock.ExpectExec("DELETE FROM `user_collect_bizs` WHERE .*").
+					WithArgs(10).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `interactives` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				return mockDB
+			},
+			wantItems: []UserCollectBiz{
+				{Id: 10, Cid: 1, BizId: 100, Biz: "article", UserId: 2},
+			},
+		},
+		{
+			name: "空收藏夹",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `collections` WHERE .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT \\* FROM `user_collect_bizs` WHERE .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectCommit()
+				return mockDB
+			},
+			wantItems: []UserCollectBiz{},
+		},
+		{
+			name: "收藏夹不存在或不属于该用户",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `collections` WHERE .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+				return mockDB
+			},
+			wantErr: ErrCollectionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB := tc.mock(t)
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      mockDB,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			d := NewGORMCollectionDAO(db)
+			items, err := d.Delete(context.Background(), 1, 2)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.wantItems, items)
+		})
+	}
+}
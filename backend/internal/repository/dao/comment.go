@@ -0,0 +1,104 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+var ErrCommentNotFound = gorm.ErrRecordNotFound
+
+type CommentDAO interface {
+	Insert(ctx context.Context, c Comment) (int64, error)
+	FindById(ctx context.Context, id int64) (Comment, error)
+	// FindRoots 根评论中 id 小于 beforeId 的，按 id 倒序，beforeId 为 0 时从最新的开始
+	FindRoots(ctx context.Context, biz string, bizId int64, beforeId int64, limit int) ([]Comment, error)
+	// FindReplies 根评论下 id 大于 afterId 的回复，按 id 升序
+	FindReplies(ctx context.Context, rootId int64, afterId int64, limit int) ([]Comment, error)
+	// Delete 删除评论以及回复它的评论，返回删除的条数
+	Delete(ctx context.Context, c Comment) (int64, error)
+}
+
+type GORMCommentDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMCommentDAO(db *gorm.DB) CommentDAO {
+	return &GORMCommentDAO{
+		db: db,
+	}
+}
+
+func (g *GORMCommentDAO) Insert(ctx context.Context, c Comment) (int64, error) {
+	err := g.db.WithContext(ctx).Create(&c).Error
+	return c.Id, err
+}
+
+func (g *GORMCommentDAO) FindById(ctx context.Context, id int64) (Comment, error) {
+	var c Comment
+	err := g.db.WithContext(ctx).Where("id = ?", id).First(&c).Error
+	return c, err
+}
+
+func (g *GORMCommentDAO) FindRoots(ctx context.Context, biz string, bizId int64, beforeId int64, limit int) ([]Comment, error) {
+	query := g.db.WithContext(ctx).
+		Where("biz = ? AND biz_id = ? AND root_id = 0", biz, bizId)
+	if beforeId > 0 {
+		query = query.Where("id < ?", beforeId)
+	}
+	var res []Comment
+	err := query.Order("id DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GORMCommentDAO) FindReplies(ctx context.Context, rootId int64, afterId int64, limit int) ([]Comment, error) {
+	var res []Comment
+	err := g.db.WithContext(ctx).
+		Where("root_id = ? AND id > ?", rootId, afterId).
+		Order("id ASC").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMCommentDAO) Delete(ctx context.Context, c Comment) (int64, error) {
+	var cnt int64
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if c.RootId == 0 {
+			// 根评论连同下面所有的回复一起删除
+			res := tx.Where("id = ? OR root_id = ?", c.Id, c.Id).Delete(&Comment{})
+			cnt = res.RowsAffected
+			return res.Error
+		}
+		// 回复只记录了直接回复的那条，逐层找出回复它的评论
+		ids := []int64{c.Id}
+		for parents := ids; len(parents) > 0; {
+			var children []int64
+			err := tx.Model(&Comment{}).
+				Where("root_id = ? AND parent_id IN ?", c.RootId, parents).
+				Pluck("id", &children).Error
+			if err != nil {
+				return err
+			}
+			ids = append(ids, children...)
+			parents = children
+		}
+		res := tx.Where("id IN ?", ids).Delete(&Comment{})
+		cnt = res.RowsAffected
+		return res.Error
+	})
+	return cnt, err
+}
+
+type Comment struct {
+	Id int64 `gorm:"primaryKey,autoIncrement"`
+	// Uid 评论的人
+	Uid   int64  `gorm:"index"`
+	Biz   string `gorm:"type:varchar(128);index:biz_root,priority:1"`
+	BizId int64  `gorm:"index:biz_root,priority:2"`
+	// RootId 根评论为 0
+	RootId   int64  `gorm:"index:biz_root,priority:3;index:root_parent,priority:1"`
+	ParentId int64  `gorm:"index:root_parent,priority:2"`
+	Content  string `gorm:"type:TEXT"`
+	Ctime    int64
+	Utime    int64
+}
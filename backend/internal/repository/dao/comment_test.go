@@ -0,0 +1,81 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestGORMCommentDAO_Delete(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(t *testing.T) *sql.DB
+		c       Comment
+		wantCnt int64
+		wantErr error
+	}{
+		{
+			name: "删除根评论",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `comments` WHERE .*").
+					WithArgs(1, 1).
+					WillReturnResult(sqlmock.NewResult(0, 4))
+				mock.ExpectCommit()
+				return mockDB
+			},
+			c:       Comment{Id: 1},
+			wantCnt: 4,
+		},
+		{
+			name: "删除回复，连带删除回复它的评论",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `id` FROM `comments` WHERE .*").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(4))
+				mock.ExpectQuery("SELECT `id` FROM `comments` WHERE .*").
+					WithArgs(1, 3, 4).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				mock.ExpectQuery("SELECT `id` FROM `comments` WHERE .*").
+					WithArgs(1, 5).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectExec("DELETE FROM `comments` WHERE .*").
+					WithArgs(2, 3, 4, 5).
+					WillReturnResult(sqlmock.NewResult(0, 4))
+				mock.ExpectCommit()
+				return mockDB
+			},
+			c:       Comment{Id: 2, RootId: 1, ParentId: 1},
+			wantCnt: 4,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB := tc.mock(t)
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      mockDB,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			d := NewGORMCommentDAO(db)
+			cnt, err := d.Delete(context.Background(), tc.c)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCnt, cnt)
+		})
+	}
+}
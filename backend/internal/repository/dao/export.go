@@ -0,0 +1,122 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+var ErrExportNotFound = gorm.ErrRecordNotFound
+
+const (
+	ExportStatusPending uint8 = iota + 1
+	ExportStatusRunning
+	ExportStatusDone
+	ExportStatusFailed
+)
+
+type ExportDAO interface {
+	Insert(ctx context.Context, e ArticleExport) (int64, error)
+	// FindById 只能查到自己的导出任务
+	FindById(ctx context.Context, id, uid int64) (ArticleExport, error)
+	// FindActive 用户等待中或执行中的导出任务，没有时返回 ErrExportNotFound
+	FindActive(ctx context.Context, uid int64) (ArticleExport, error)
+	// Preempt 抢占一个等待中的任务，执行中但超过 timeout 没有更新进度的任务也可以被抢占
+	Preempt(ctx context.Context, now int64, timeout time.Duration) (ArticleExport, error)
+	// UpdateProgress 同时刷新 utime，表示任务还在执行
+	UpdateProgress(ctx context.Context, id int64, done, total int) error
+	// Finish 结束任务，成功时 key 为归档文件在对象存储中的 key
+	Finish(ctx context.Context, id int64, status uint8, key string) error
+}
+
+type GORMExportDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMExportDAO(db *gorm.DB) ExportDAO {
+	return &GORMExportDAO{
+		db: db,
+	}
+}
+
+func (g *GORMExportDAO) Insert(ctx context.Context, e ArticleExport) (int64, error) {
+	now := time.Now().UnixMilli()
+	e.Status = ExportStatusPending
+	e.Ctime = now
+	e.Utime = now
+	err := g.db.WithContext(ctx).Create(&e).Error
+	return e.Id, err
+}
+
+func (g *GORMExportDAO) FindById(ctx context.Context, id, uid int64) (ArticleExport, error) {
+	var e ArticleExport
+	err := g.db.WithContext(ctx).Where("id = ? AND uid = ?", id, uid).First(&e).Error
+	return e, err
+}
+
+func (g *GORMExportDAO) FindActive(ctx context.Context, uid int64) (ArticleExport, error) {
+	var e ArticleExport
+	err := g.db.WithContext(ctx).
+		Where("uid = ? AND status IN ?", uid, []uint8{ExportStatusPending, ExportStatusRunning}).
+		Order("id DESC").
+		First(&e).Error
+	return e, err
+}
+
+func (g *GORMExportDAO) Preempt(ctx context.Context, now int64, timeout time.Duration) (ArticleExport, error) {
+	var e ArticleExport
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		staleTime := now - timeout.Milliseconds()
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND utime < ?)",
+				ExportStatusPending, ExportStatusRunning, staleTime).
+			Order("id ASC").
+			First(&e).Error
+		if err != nil {
+			return err
+		}
+		e.Status = ExportStatusRunning
+		e.Utime = now
+		return tx.Model(&ArticleExport{}).
+			Where("id = ?", e.Id).
+			Updates(map[string]any{
+				"status": ExportStatusRunning,
+				"utime":  now,
+			}).Error
+	})
+	return e, err
+}
+
+func (g *GORMExportDAO) UpdateProgress(ctx context.Context, id int64, done, total int) error {
+	return g.db.WithContext(ctx).Model(&ArticleExport{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"done":  done,
+			"total": total,
+			"utime": time.Now().UnixMilli(),
+		}).Error
+}
+
+func (g *GORMExportDAO) Finish(ctx context.Context, id int64, status uint8, key string) error {
+	return g.db.WithContext(ctx).Model(&ArticleExport{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status": status,
+			"key":    key,
+			"utime":  time.Now().UnixMilli(),
+		}).Error
+}
+
+// ArticleExport 作者导出全部文章的任务
+type ArticleExport struct {
+	Id     int64 `gorm:"primaryKey,autoIncrement"`
+	Uid    int64 `gorm:"index"`
+	Status uint8 `gorm:"index"`
+	Total  int
+	Done   int
+	// Key 归档文件在对象存储中的 key
+	Key   string `gorm:"type:varchar(256)"`
+	Ctime int64
+	Utime int64
+}
@@ -0,0 +1,80 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestGORMExportDAO_Preempt(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(t *testing.T) *sql.DB
+		want    ArticleExport
+		wantErr error
+	}{
+		{
+			name: "抢占等待中的任务",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				rows := sqlmock.NewRows([]string{"id", "uid", "status", "ctime", "utime"}).
+					AddRow(1, 123, ExportStatusPending, 1000, 1000)
+				mock.ExpectQuery("SELECT .* FROM `article_exports` WHERE .* FOR UPDATE SKIP LOCKED").
+					WithArgs(ExportStatusPending, ExportStatusRunning, 2000-time.Minute.Milliseconds()).
+					WillReturnRows(rows)
+				mock.ExpectExec("UPDATE `article_exports` SET .*").
+					WithArgs(ExportStatusRunning, 2000, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				return mockDB
+			},
+			want: ArticleExport{
+				Id:     1,
+				Uid:    123,
+				Status: ExportStatusRunning,
+				Ctime:  1000,
+				Utime:  2000,
+			},
+		},
+		{
+			name: "没有等待中的任务",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT .* FROM `article_exports` WHERE .* FOR UPDATE SKIP LOCKED").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+				return mockDB
+			},
+			want:    ArticleExport{},
+			wantErr: ErrExportNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      tc.mock(t),
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			d := NewGORMExportDAO(db)
+			e, err := d.Preempt(context.Background(), 2000, time.Minute)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, e)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FeedDAO 推模式下关注者的收件箱
+type FeedDAO interface {
+	// InsertPushEvents 同一篇文章重复推送给同一个人时忽略
+	InsertPushEvents(ctx context.Context, evts []FeedPushEvent) error
+	// ListPushEvents uid 收件箱中的记录，按 (ctime, article_id) 倒序返回排在游标之后的 limit 条，cursorAid 为 0 时从头开始
+	ListPushEvents(ctx context.Context, uid int64, cursorCtime, cursorAid int64, limit int) ([]FeedPushEvent, error)
+	// DeletePushEvents 取消关注后清理收件箱里该作者的文章
+	DeletePushEvents(ctx context.Context, uid, authorId int64) error
+}
+
+type GORMFeedDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMFeedDAO(db *gorm.DB) FeedDAO {
+	return &GORMFeedDAO{
+		db: db,
+	}
+}
+
+func (g *GORMFeedDAO) InsertPushEvents(ctx context.Context, evts []FeedPushEvent) error {
+	if len(evts) == 0 {
+		return nil
+	}
+	return g.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(evts, 200).Error
+}
+
+func (g *GORMFeedDAO) ListPushEvents(ctx context.Context, uid int64, cursorCtime, cursorAid int64, limit int) ([]FeedPushEvent, error) {
+	var res []FeedPushEvent
+	query := g.db.WithContext(ctx).Where("uid = ?", uid)
+	if cursorAid > 0 {
+		// 同一时间推送的多篇文章用文章 id 区分先后，翻页时不会漏掉
+		query = query.Where("ctime < ? OR (ctime = ? AND article_id < ?)", cursorCtime, cursorCtime, cursorAid)
+	}
+	err := query.Order("ctime DESC, article_id DESC").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMFeedDAO) DeletePushEvents(ctx context.Context, uid, authorId int64) error {
+	return g.db.WithContext(ctx).
+		Where("uid = ? AND author_id = ?", uid, authorId).
+		Delete(&FeedPushEvent{}).Error
+}
+
+// FeedPushEvent 作者发表文章时推送给一个粉丝的记录
+type FeedPushEvent struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	Uid       int64 `gorm:"uniqueIndex:uid_aid;index:uid_ctime,priority:1;index:uid_author,priority:1"`
+	ArticleId int64 `gorm:"uniqueIndex:uid_aid;index:uid_ctime,priority:3"`
+	AuthorId  int64 `gorm:"index:uid_author,priority:2"`
+	Ctime     int64 `gorm:"index:uid_ctime,priority:2"`
+}
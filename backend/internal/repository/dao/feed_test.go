@@ -0,0 +1,71 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestGORMFeedDAO_ListPushEvents(t *testing.T) {
+	testCases := []struct {
+		name        string
+		mock        func(t *testing.T) *sql.DB
+		cursorCtime int64
+		cursorAid   int64
+		wantAids    []int64
+	}{
+		{
+			name: "第一页",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `feed_push_events` WHERE uid = \\? ORDER BY ctime DESC, article_id DESC LIMIT 2").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"article_id", "ctime"}).AddRow(3, 100).AddRow(2, 100))
+				return mockDB
+			},
+			wantAids: []int64{3, 2},
+		},
+		{
+			name: "同一时间推送的文章用文章 id 区分",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `feed_push_events` WHERE uid = \\? AND \\(ctime < \\? OR \\(ctime = \\? AND article_id < \\?\\)\\) ORDER BY ctime DESC, article_id DESC LIMIT 2").
+					WithArgs(1, 100, 100, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"article_id", "ctime"}).AddRow(1, 100))
+				return mockDB
+			},
+			cursorCtime: 100,
+			cursorAid:   2,
+			wantAids:    []int64{1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      tc.mock(t),
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			d := NewGORMFeedDAO(db)
+			evts, err := d.ListPushEvents(context.Background(), 1, tc.cursorCtime, tc.cursorAid, 2)
+			require.NoError(t, err)
+			aids := make([]int64, 0, len(evts))
+			for _, evt := range evts {
+				aids = append(aids, evt.ArticleId)
+			}
+			assert.Equal(t, tc.wantAids, aids)
+		})
+	}
+}
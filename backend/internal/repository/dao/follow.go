@@ -0,0 +1,186 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+const (
+	FollowRelationStatusActive uint8 = iota + 1
+	FollowRelationStatusInactive
+)
+
+type FollowDAO interface {
+	// Follow 已经关注过时什么也不做
+	Follow(ctx context.Context, follower, followee int64) error
+	// Unfollow 没有关注时什么也不做
+	Unfollow(ctx context.Context, follower, followee int64) error
+	// Followees follower 关注的人，按关注时间倒序
+	Followees(ctx context.Context, follower int64, offset, limit int) ([]FollowRelation, error)
+	// Followers followee 的粉丝，按关注时间倒序
+	Followers(ctx context.Context, followee int64, offset, limit int) ([]FollowRelation, error)
+	// PopularFollowees follower 关注的人中粉丝数不少于 minFollowers 的
+	PopularFollowees(ctx context.Context, follower int64, minFollowers int64) ([]int64, error)
+	Statics(ctx context.Context, uid int64) (FollowStatics, error)
+}
+
+type GORMFollowDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMFollowDAO(db *gorm.DB) FollowDAO {
+	return &GORMFollowDAO{
+		db: db,
+	}
+}
+
+func (g *GORMFollowDAO) Follow(ctx context.Context, follower, followee int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 先尝试恢复之前取消的关注
+		res := tx.Model(&FollowRelation{}).
+			Where("follower = ? AND followee = ? AND status = ?",
+				follower, followee, FollowRelationStatusInactive).
+			Updates(map[string]any{
+				"status": FollowRelationStatusActive,
+				"utime":  now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			res = tx.Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&FollowRelation{
+					Follower: follower,
+					Followee: followee,
+					Status:   FollowRelationStatusActive,
+					Ctime:    now,
+					Utime:    now,
+				})
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				// 已经关注过了，计数不变
+				return nil
+			}
+		}
+		err := g.incrStatics(tx, follower, "followees", now)
+		if err != nil {
+			return err
+		}
+		return g.incrStatics(tx, followee, "followers", now)
+	})
+}
+
+func (g *GORMFollowDAO) incrStatics(tx *gorm.DB, uid int64, field string, now int64) error {
+	statics := FollowStatics{
+		Uid:   uid,
+		Ctime: now,
+		Utime: now,
+	}
+	if field == "followers" {
+		statics.Followers = 1
+	} else {
+		statics.Followees = 1
+	}
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			field:   gorm.Expr("`" + field + "`+1"),
+			"utime": now,
+		}),
+	}).Create(&statics).Error
+}
+
+func (g *GORMFollowDAO) Unfollow(ctx context.Context, follower, followee int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&FollowRelation{}).
+			Where("follower = ? AND followee = ? AND status = ?",
+				follower, followee, FollowRelationStatusActive).
+			Updates(map[string]any{
+				"status": FollowRelationStatusInactive,
+				"utime":  now,
+			})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		err := tx.Model(&FollowStatics{}).Where("uid = ?", follower).
+			Updates(map[string]any{
+				"followees": gorm.Expr("`followees`-1"),
+				"utime":     now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&FollowStatics{}).Where("uid = ?", followee).
+			Updates(map[string]any{
+				"followers": gorm.Expr("`followers`-1"),
+				"utime":     now,
+			}).Error
+	})
+}
+
+func (g *GORMFollowDAO) Followees(ctx context.Context, follower int64, offset, limit int) ([]FollowRelation, error) {
+	var res []FollowRelation
+	err := g.db.WithContext(ctx).
+		Where("follower = ? AND status = ?", follower, FollowRelationStatusActive).
+		Order("utime DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMFollowDAO) Followers(ctx context.Context, followee int64, offset, limit int) ([]FollowRelation, error) {
+	var res []FollowRelation
+	err := g.db.WithContext(ctx).
+		Where("followee = ? AND status = ?", followee, FollowRelationStatusActive).
+		Order("utime DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMFollowDAO) PopularFollowees(ctx context.Context, follower int64, minFollowers int64) ([]int64, error) {
+	var res []int64
+	err := g.db.WithContext(ctx).Table("follow_relations fr").
+		Select("fr.followee").
+		Joins("JOIN follow_statics fs ON fs.uid = fr.followee").
+		Where("fr.follower = ? AND fr.status = ? AND fs.followers >= ?",
+			follower, FollowRelationStatusActive, minFollowers).
+		Scan(&res).Error
+	return res, err
+}
+
+func (g *GORMFollowDAO) Statics(ctx context.Context, uid int64) (FollowStatics, error) {
+	var res FollowStatics
+	err := g.db.WithContext(ctx).Where("uid = ?", uid).First(&res).Error
+	if err == gorm.ErrRecordNotFound {
+		// 没有关注过别人，也没有被别人关注过
+		return FollowStatics{Uid: uid}, nil
+	}
+	return res, err
+}
+
+type FollowRelation struct {
+	Id       int64 `gorm:"primaryKey,autoIncrement"`
+	Follower int64 `gorm:"uniqueIndex:follower_followee"`
+	Followee int64 `gorm:"uniqueIndex:follower_followee;index"`
+	Status   uint8
+	Ctime    int64
+	Utime    int64
+}
+
+// FollowStatics 关注数和粉丝数，关注、取消关注时在同一个事务里更新
+type FollowStatics struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	Uid       int64 `gorm:"uniqueIndex"`
+	Followers int64
+	Followees int64
+	Ctime     int64
+	Utime     int64
+}
@@ -0,0 +1,57 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+var ErrImportNotFound = gorm.ErrRecordNotFound
+
+// ImportDAO 记录导入的文件对应的文章，重复导入时据此去重
+type ImportDAO interface {
+	FindByHash(ctx context.Context, uid int64, hash string) (ArticleImport, error)
+	// Upsert 同一个用户的同一个文件只保留一条记录
+	Upsert(ctx context.Context, i ArticleImport) error
+}
+
+type GORMImportDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMImportDAO(db *gorm.DB) ImportDAO {
+	return &GORMImportDAO{
+		db: db,
+	}
+}
+
+func (g *GORMImportDAO) FindByHash(ctx context.Context, uid int64, hash string) (ArticleImport, error) {
+	var res ArticleImport
+	err := g.db.WithContext(ctx).
+		Where("uid = ? AND hash = ?", uid, hash).
+		First(&res).Error
+	return res, err
+}
+
+func (g *GORMImportDAO) Upsert(ctx context.Context, i ArticleImport) error {
+	now := time.Now().UnixMilli()
+	i.Ctime = now
+	i.Utime = now
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"article_id": i.ArticleId,
+			"utime":      now,
+		}),
+	}).Create(&i).Error
+}
+
+type ArticleImport struct {
+	Id  int64 `gorm:"primaryKey,autoIncrement"`
+	Uid int64 `gorm:"uniqueIndex:uid_hash"`
+	// Hash 导入文件内容的 sha256
+	Hash      string `gorm:"type:char(64);uniqueIndex:uid_hash"`
+	ArticleId int64
+	Ctime     int64
+	Utime     int64
+}
@@ -9,10 +9,28 @@ func InitTable(db *gorm.DB) error {
 	return db.AutoMigrate(&User{},
 		&article.Article{},
 		&article.PublishArticle{},
+		&article.PublishArticleRender{},
+		&article.ArticleRevision{},
+		&article.ArticleSchedule{},
+		&article.Tag{},
+		&article.Category{},
+		&article.ArticleTag{},
+		&article.PublishArticleTag{},
 		&SMSAsyncInfo{},
 		&UserCollectBiz{},
 		&UserLikeBiz{},
 		&Collection{},
 		&Interactive{},
+		&FollowRelation{},
+		&FollowStatics{},
+		&FeedPushEvent{},
+		&Comment{},
+		&Series{},
+		&SeriesArticle{},
+		&Upload{},
+		&ArticleUpload{},
+		&ArticleExport{},
+		&ArticleImport{},
+		&ReadingHistory{},
 	)
 }
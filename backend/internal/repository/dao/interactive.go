@@ -16,9 +16,17 @@ type InteractiveDAO interface {
 	IncrLike(ctx context.Context, id int64, biz string, uid int64) error
 	DecrLike(ctx context.Context, id int64, biz string, uid int64) error
 	InsertCollectionBiz(ctx context.Context, id int64, biz string, cid int64, uid int64) error
+	// DeleteCollectionBiz 没有收藏过时返回 ErrDataNotFound
+	DeleteCollectionBiz(ctx context.Context, id int64, biz string, uid int64) error
+	// IncrCommentCnt delta 为负数时减少评论数
+	IncrCommentCnt(ctx context.Context, biz string, bizId int64, delta int64) error
 	Get(ctx context.Context, biz string, bizId int64) (Interactive, error)
+	// GetByIds 批量查询，没有记录的 bizId 不会出现在结果中
+	GetByIds(ctx context.Context, biz string, bizIds []int64) ([]Interactive, error)
 	GetLikeInfo(ctx context.Context, biz string, id int64, uid int64) (UserLikeBiz, error)
 	GetCollectInfo(ctx context.Context, biz string, id int64, uid int64) (UserCollectBiz, error)
+	// DeleteByBiz 删除计数以及点赞、收藏记录，用于彻底删除业务对象
+	DeleteByBiz(ctx context.Context, biz string, bizIds []int64) error
 }
 
 type GORMInteractiveDAO struct {
@@ -75,6 +83,14 @@ func (g *GORMInteractiveDAO) Get(ctx context.Context, biz string, bizId int64) (
 	return res, err
 }
 
+func (g *GORMInteractiveDAO) GetByIds(ctx context.Context, biz string, bizIds []int64) ([]Interactive, error) {
+	var res []Interactive
+	err := g.db.WithContext(ctx).
+		Where("biz = ? AND biz_id IN ?", biz, bizIds).
+		Find(&res).Error
+	return res, err
+}
+
 func (g *GORMInteractiveDAO) IncrLike(ctx context.Context, id int64, biz string, uid int64) error {
 	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		now := time.Now().UnixMilli()
@@ -162,6 +178,47 @@ func (g *GORMInteractiveDAO) InsertCollectionBiz(ctx context.Context, id int64,
 	})
 }
 
+func (g *GORMInteractiveDAO) DeleteCollectionBiz(ctx context.Context, id int64, biz string, uid int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("biz_id = ? AND biz = ? AND user_id = ?", id, biz, uid).
+			Delete(&UserCollectBiz{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrDataNotFound
+		}
+		return decrCollectCnt(tx, biz, id, time.Now().UnixMilli())
+	})
+}
+
+// decrCollectCnt 取消收藏时减少收藏数，不会减到负数
+func decrCollectCnt(tx *gorm.DB, biz string, bizId int64, now int64) error {
+	return tx.Model(&Interactive{}).
+		Where("biz_id = ? AND biz = ? AND collect_cnt > 0", bizId, biz).
+		Updates(map[string]any{
+			"collect_cnt": gorm.Expr("`collect_cnt`-1"),
+			"utime":       now,
+		}).Error
+}
+
+func (g *GORMInteractiveDAO) IncrCommentCnt(ctx context.Context, biz string, bizId int64, delta int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"comment_cnt": gorm.Expr("`comment_cnt`+?", delta),
+				"utime":       now,
+			}),
+		}).Create(&Interactive{
+		BizId:      bizId,
+		Biz:        biz,
+		CommentCnt: delta,
+		Utime:      now,
+		Ctime:      now,
+	}).Error
+}
+
 func (g *GORMInteractiveDAO) GetLikeInfo(ctx context.Context, biz string, id int64, uid int64) (UserLikeBiz, error) {
 	var info UserLikeBiz
 	err := g.db.WithContext(ctx).
@@ -178,6 +235,23 @@ func (g *GORMInteractiveDAO) GetCollectInfo(ctx context.Context, biz string, id
 	return info, err
 }
 
+func (g *GORMInteractiveDAO) DeleteByBiz(ctx context.Context, biz string, bizIds []int64) error {
+	if len(bizIds) == 0 {
+		return nil
+	}
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("biz = ? AND biz_id IN ?", biz, bizIds).Delete(&Interactive{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("biz = ? AND biz_id IN ?", biz, bizIds).Delete(&UserLikeBiz{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("biz = ? AND biz_id IN ?", biz, bizIds).Delete(&UserCollectBiz{}).Error
+	})
+}
+
 type UserCollectBiz struct {
 	Id int64 `gorm:"primaryKey,autoIncrement"`
 
@@ -205,7 +279,7 @@ type UserLikeBiz struct {
 type Collection struct {
 	Id     int64  `gorm:"primaryKey,autoIncrement"`
 	Name   string `gorm:"type:varchar(1024)"`
-	UserId int64
+	UserId int64  `gorm:"index"`
 
 	Utime int64
 	Ctime int64
@@ -218,6 +292,7 @@ type Interactive struct {
 	ReadCnt    int64
 	LikeCnt    int64
 	CollectCnt int64
+	CommentCnt int64
 	Utime      int64
 	Ctime      int64
 }
@@ -0,0 +1,115 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReadingHistoryDAO interface {
+	// Record 批量记录阅读，已经读过的只更新阅读时间，不改阅读进度
+	Record(ctx context.Context, hs []ReadingHistory) error
+	// UpdateProgress 没有读过时新建一条
+	UpdateProgress(ctx context.Context, h ReadingHistory) error
+	// List 按最近阅读时间倒序
+	List(ctx context.Context, uid int64, offset, limit int) ([]ReadingHistory, error)
+	// ListUnfinished 进度在 (0, maxProgress) 之间的，按最近阅读时间倒序
+	ListUnfinished(ctx context.Context, uid int64, maxProgress int, limit int) ([]ReadingHistory, error)
+	Delete(ctx context.Context, uid int64, aids []int64) error
+	Clear(ctx context.Context, uid int64) error
+	// Trim 只保留最近读过的 keep 篇
+	Trim(ctx context.Context, uid int64, keep int) error
+}
+
+type GORMReadingHistoryDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMReadingHistoryDAO(db *gorm.DB) ReadingHistoryDAO {
+	return &GORMReadingHistoryDAO{
+		db: db,
+	}
+}
+
+func (g *GORMReadingHistoryDAO) Record(ctx context.Context, hs []ReadingHistory) error {
+	if len(hs) == 0 {
+		return nil
+	}
+	// 消息可能乱序，阅读时间只往后更新
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"utime": gorm.Expr("GREATEST(`utime`, VALUES(`utime`))"),
+		}),
+	}).Create(&hs).Error
+}
+
+func (g *GORMReadingHistoryDAO) UpdateProgress(ctx context.Context, h ReadingHistory) error {
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"progress": h.Progress,
+			"utime":    h.Utime,
+		}),
+	}).Create(&h).Error
+}
+
+func (g *GORMReadingHistoryDAO) List(ctx context.Context, uid int64, offset, limit int) ([]ReadingHistory, error) {
+	var res []ReadingHistory
+	err := g.db.WithContext(ctx).
+		Where("uid = ?", uid).
+		Order("utime DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMReadingHistoryDAO) ListUnfinished(ctx context.Context, uid int64, maxProgress int, limit int) ([]ReadingHistory, error) {
+	var res []ReadingHistory
+	err := g.db.WithContext(ctx).
+		Where("uid = ? AND progress > 0 AND progress < ?", uid, maxProgress).
+		Order("utime DESC, id DESC").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMReadingHistoryDAO) Delete(ctx context.Context, uid int64, aids []int64) error {
+	if len(aids) == 0 {
+		return nil
+	}
+	return g.db.WithContext(ctx).
+		Where("uid = ? AND aid IN ?", uid, aids).
+		Delete(&ReadingHistory{}).Error
+}
+
+func (g *GORMReadingHistoryDAO) Clear(ctx context.Context, uid int64) error {
+	return g.db.WithContext(ctx).Where("uid = ?", uid).Delete(&ReadingHistory{}).Error
+}
+
+func (g *GORMReadingHistoryDAO) Trim(ctx context.Context, uid int64, keep int) error {
+	// 超出的部分一般只有几条，一次最多删这么多，剩下的下次再删
+	const batch = 1000
+	var ids []int64
+	err := g.db.WithContext(ctx).Model(&ReadingHistory{}).
+		Where("uid = ?", uid).
+		Order("utime DESC, id DESC").
+		Offset(keep).
+		Limit(batch).
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+	return g.db.WithContext(ctx).Where("id IN ?", ids).Delete(&ReadingHistory{}).Error
+}
+
+// ReadingHistory 用户读过的文章，每人每篇一条
+type ReadingHistory struct {
+	Id  int64 `gorm:"primaryKey,autoIncrement"`
+	Uid int64 `gorm:"uniqueIndex:uid_aid;index:uid_utime,priority:1"`
+	Aid int64 `gorm:"uniqueIndex:uid_aid"`
+	// Progress 阅读进度的百分比
+	Progress int
+	Ctime    int64
+	// Utime 最近一次阅读的时间
+	Utime int64 `gorm:"index:uid_utime,priority:2"`
+}
@@ -0,0 +1,73 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestGORMReadingHistoryDAO_Trim(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(t *testing.T) *sql.DB
+		wantErr error
+	}{
+		{
+			name: "超出上限，删除最早读的",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT `id` FROM `reading_histories` WHERE uid = \\? ORDER BY utime DESC, id DESC LIMIT 1000 OFFSET 3").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5).AddRow(2))
+				mock.ExpectExec("DELETE FROM `reading_histories` WHERE id IN \\(\\?,\\?\\)").
+					WithArgs(5, 2).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				return mockDB
+			},
+		},
+		{
+			name: "没有超出上限",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT `id` FROM `reading_histories` .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				return mockDB
+			},
+		},
+		{
+			name: "查询出错",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT `id` FROM `reading_histories` .*").
+					WillReturnError(sql.ErrConnDone)
+				return mockDB
+			},
+			wantErr: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      tc.mock(t),
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			d := NewGORMReadingHistoryDAO(db)
+			err = d.Trim(context.Background(), 1, 3)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
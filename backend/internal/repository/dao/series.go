@@ -0,0 +1,200 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+var (
+	ErrSeriesNotFound = gorm.ErrRecordNotFound
+	// ErrArticleInSeries 一篇文章只能属于一个专栏
+	ErrArticleInSeries = errors.New("文章已经属于某个专栏")
+	// ErrSeriesArticlesMismatch 调整顺序时提交的文章和专栏里的文章不一致
+	ErrSeriesArticlesMismatch = errors.New("专栏文章列表不一致")
+)
+
+type SeriesDAO interface {
+	Insert(ctx context.Context, s Series) (int64, error)
+	FindById(ctx context.Context, id int64) (Series, error)
+	// FindByAuthor 按创建时间倒序
+	FindByAuthor(ctx context.Context, uid int64) ([]Series, error)
+	// AddArticle 追加到专栏末尾
+	AddArticle(ctx context.Context, sid, aid int64) error
+	// RemoveArticle 文章不在专栏里时什么也不做
+	RemoveArticle(ctx context.Context, sid, aid int64) error
+	// Reorder aids 必须正好是专栏里的全部文章，按新的顺序排列
+	Reorder(ctx context.Context, sid int64, aids []int64) error
+	// FindArticles 按顺序返回专栏里的文章
+	FindArticles(ctx context.Context, sid int64) ([]SeriesArticle, error)
+	// FindByArticle 文章所属的专栏，不属于任何专栏时返回 ErrSeriesNotFound
+	FindByArticle(ctx context.Context, aid int64) (SeriesArticle, error)
+}
+
+type GORMSeriesDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMSeriesDAO(db *gorm.DB) SeriesDAO {
+	return &GORMSeriesDAO{
+		db: db,
+	}
+}
+
+func (g *GORMSeriesDAO) Insert(ctx context.Context, s Series) (int64, error) {
+	now := time.Now().UnixMilli()
+	s.Ctime = now
+	s.Utime = now
+	err := g.db.WithContext(ctx).Create(&s).Error
+	return s.Id, err
+}
+
+func (g *GORMSeriesDAO) FindById(ctx context.Context, id int64) (Series, error) {
+	var s Series
+	err := g.db.WithContext(ctx).Where("id = ?", id).First(&s).Error
+	return s, err
+}
+
+func (g *GORMSeriesDAO) FindByAuthor(ctx context.Context, uid int64) ([]Series, error) {
+	var res []Series
+	err := g.db.WithContext(ctx).
+		Where("author_id = ?", uid).
+		Order("id DESC").
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMSeriesDAO) AddArticle(ctx context.Context, sid, aid int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var pos int
+		err := tx.Model(&SeriesArticle{}).
+			Select("COALESCE(MAX(position), 0)").
+			Where("series_id = ?", sid).
+			Scan(&pos).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Create(&SeriesArticle{
+			SeriesId:  sid,
+			ArticleId: aid,
+			Position:  pos + 1,
+			Ctime:     now,
+			Utime:     now,
+		}).Error
+		if mysqlErr, ok := err.(*mysql.MySQLError); ok {
+			const uniqueConflictsErrNo uint16 = 1062
+			if mysqlErr.Number == uniqueConflictsErrNo {
+				return ErrArticleInSeries
+			}
+		}
+		if err != nil {
+			return err
+		}
+		return g.touch(tx, sid, now)
+	})
+}
+
+func (g *GORMSeriesDAO) RemoveArticle(ctx context.Context, sid, aid int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("series_id = ? AND article_id = ?", sid, aid).Delete(&SeriesArticle{})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		return g.touch(tx, sid, time.Now().UnixMilli())
+	})
+}
+
+func (g *GORMSeriesDAO) Reorder(ctx context.Context, sid int64, aids []int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current []int64
+		err := tx.Model(&SeriesArticle{}).
+			Where("series_id = ?", sid).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Pluck("article_id", &current).Error
+		if err != nil {
+			return err
+		}
+		if !sameIds(current, aids) {
+			return ErrSeriesArticlesMismatch
+		}
+		for i, aid := range aids {
+			err = tx.Model(&SeriesArticle{}).
+				Where("series_id = ? AND article_id = ?", sid, aid).
+				Updates(map[string]any{
+					"position": i + 1,
+					"utime":    now,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return g.touch(tx, sid, now)
+	})
+}
+
+// sameIds 两组 id 是否相同，不考虑顺序，也不允许重复
+func sameIds(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[int64]struct{}, len(a))
+	for _, id := range a {
+		set[id] = struct{}{}
+	}
+	for _, id := range b {
+		if _, ok := set[id]; !ok {
+			return false
+		}
+		delete(set, id)
+	}
+	return true
+}
+
+// touch 专栏里的文章有变动时更新专栏的修改时间
+func (g *GORMSeriesDAO) touch(tx *gorm.DB, sid int64, now int64) error {
+	return tx.Model(&Series{}).
+		Where("id = ?", sid).
+		Update("utime", now).Error
+}
+
+func (g *GORMSeriesDAO) FindArticles(ctx context.Context, sid int64) ([]SeriesArticle, error) {
+	var res []SeriesArticle
+	err := g.db.WithContext(ctx).
+		Where("series_id = ?", sid).
+		Order("position ASC").
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMSeriesDAO) FindByArticle(ctx context.Context, aid int64) (SeriesArticle, error) {
+	var res SeriesArticle
+	err := g.db.WithContext(ctx).
+		Where("article_id = ?", aid).
+		First(&res).Error
+	return res, err
+}
+
+// Series 作者的专栏，文章按 SeriesArticle.Position 排序
+type Series struct {
+	Id          int64  `gorm:"primaryKey,autoIncrement"`
+	AuthorId    int64  `gorm:"index"`
+	Title       string `gorm:"type:varchar(1024)"`
+	Description string `gorm:"type:varchar(4096)"`
+	Ctime       int64
+	Utime       int64
+}
+
+type SeriesArticle struct {
+	Id       int64 `gorm:"primaryKey,autoIncrement"`
+	SeriesId int64 `gorm:"index:sid_pos,priority:1"`
+	// ArticleId 一篇文章只能属于一个专栏
+	ArticleId int64 `gorm:"uniqueIndex"`
+	Position  int   `gorm:"index:sid_pos,priority:2"`
+	Ctime     int64
+	Utime     int64
+}
@@ -0,0 +1,76 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestGORMSeriesDAO_Reorder(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(t *testing.T) *sql.DB
+		aids    []int64
+		wantErr error
+	}{
+		{
+			name: "按新顺序更新位置",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `article_id` FROM `series_articles` WHERE series_id = \\? FOR UPDATE").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"article_id"}).AddRow(10).AddRow(11))
+				mock.ExpectExec("UPDATE `series_articles` SET .*").
+					WithArgs(1, sqlmock.AnyArg(), 1, 11).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `series_articles` SET .*").
+					WithArgs(2, sqlmock.AnyArg(), 1, 10).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `series` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				return mockDB
+			},
+			aids: []int64{11, 10},
+		},
+		{
+			name: "文章列表不一致",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `article_id` FROM `series_articles` .*").
+					WillReturnRows(sqlmock.NewRows([]string{"article_id"}).AddRow(10).AddRow(11))
+				mock.ExpectRollback()
+				return mockDB
+			},
+			aids:    []int64{11, 11},
+			wantErr: ErrSeriesArticlesMismatch,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB := tc.mock(t)
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      mockDB,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			d := NewGORMSeriesDAO(db)
+			err = d.Reorder(context.Background(), 1, tc.aids)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+	"time"
+)
+
+var (
+	ErrUploadNotFound = gorm.ErrRecordNotFound
+	// ErrUploadDuplicate 相同内容的图片已经存在
+	ErrUploadDuplicate = errors.New("图片已经存在")
+)
+
+type UploadDAO interface {
+	Insert(ctx context.Context, u Upload) (int64, error)
+	FindByHash(ctx context.Context, hash string) (Upload, error)
+	// FindByHashes 不存在的 hash 直接忽略
+	FindByHashes(ctx context.Context, hashes []string) ([]Upload, error)
+	// SetReferences 用 uploadIds 覆盖文章草稿（pub 为 false）或线上版本引用的图片
+	SetReferences(ctx context.Context, aid int64, pub bool, uploadIds []int64) error
+	// DeleteReferences 删除文章的全部引用
+	DeleteReferences(ctx context.Context, aids []int64) error
+	// ListUnreferenced 在 before 之前上传且没有被任何文章引用的图片，按 id 升序
+	ListUnreferenced(ctx context.Context, before int64, limit int) ([]Upload, error)
+	// DeleteUnreferenced 仍然没有被引用时才删除，返回是否删除
+	DeleteUnreferenced(ctx context.Context, id int64) (bool, error)
+}
+
+type GORMUploadDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMUploadDAO(db *gorm.DB) UploadDAO {
+	return &GORMUploadDAO{
+		db: db,
+	}
+}
+
+func (g *GORMUploadDAO) Insert(ctx context.Context, u Upload) (int64, error) {
+	now := time.Now().UnixMilli()
+	u.Ctime = now
+	u.Utime = now
+	err := g.db.WithContext(ctx).Create(&u).Error
+	if me, ok := err.(*mysql.MySQLError); ok {
+		const uniqueConflictsErrNo uint16 = 1062
+		if me.Number == uniqueConflictsErrNo {
+			return 0, ErrUploadDuplicate
+		}
+	}
+	return u.Id, err
+}
+
+func (g *GORMUploadDAO) FindByHash(ctx context.Context, hash string) (Upload, error) {
+	var u Upload
+	err := g.db.WithContext(ctx).Where("hash = ?", hash).First(&u).Error
+	return u, err
+}
+
+func (g *GORMUploadDAO) FindByHashes(ctx context.Context, hashes []string) ([]Upload, error) {
+	var res []Upload
+	if len(hashes) == 0 {
+		return res, nil
+	}
+	err := g.db.WithContext(ctx).Where("hash IN ?", hashes).Find(&res).Error
+	return res, err
+}
+
+func (g *GORMUploadDAO) SetReferences(ctx context.Context, aid int64, pub bool, uploadIds []int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("article_id = ? AND pub = ?", aid, pub).
+			Delete(&ArticleUpload{}).Error
+		if err != nil {
+			return err
+		}
+		if len(uploadIds) == 0 {
+			return nil
+		}
+		refs := make([]ArticleUpload, 0, len(uploadIds))
+		for _, id := range uploadIds {
+			refs = append(refs, ArticleUpload{
+				ArticleId: aid,
+				UploadId:  id,
+				Pub:       pub,
+				Ctime:     now,
+			})
+		}
+		return tx.Create(&refs).Error
+	})
+}
+
+func (g *GORMUploadDAO) DeleteReferences(ctx context.Context, aids []int64) error {
+	if len(aids) == 0 {
+		return nil
+	}
+	return g.db.WithContext(ctx).Where("article_id IN ?", aids).
+		Delete(&ArticleUpload{}).Error
+}
+
+func (g *GORMUploadDAO) ListUnreferenced(ctx context.Context, before int64, limit int) ([]Upload, error) {
+	var res []Upload
+	err := g.db.WithContext(ctx).
+		Where("ctime < ? AND NOT EXISTS (?)", before,
+			g.db.Model(&ArticleUpload{}).Select("1").Where("article_uploads.upload_id = uploads.id")).
+		Order("id ASC").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMUploadDAO) DeleteUnreferenced(ctx context.Context, id int64) (bool, error) {
+	res := g.db.WithContext(ctx).
+		Where("id = ? AND NOT EXISTS (?)", id,
+			g.db.Model(&ArticleUpload{}).Select("1").Where("article_uploads.upload_id = uploads.id")).
+		Delete(&Upload{})
+	return res.RowsAffected > 0, res.Error
+}
+
+type Upload struct {
+	Id          int64  `gorm:"primaryKey,autoIncrement"`
+	Uid         int64  `gorm:"index"`
+	Hash        string `gorm:"type:char(64);uniqueIndex"`
+	ContentType string `gorm:"type:varchar(64)"`
+	Size        int64
+	Width       int
+	Height      int
+	Ctime       int64 `gorm:"index"`
+	Utime       int64
+}
+
+// ArticleUpload 文章引用的图片，草稿和线上版本分开记录，任意一边还在引用就不能回收
+type ArticleUpload struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	ArticleId int64 `gorm:"uniqueIndex:aid_pub_upid"`
+	Pub       bool  `gorm:"uniqueIndex:aid_pub_upid"`
+	UploadId  int64 `gorm:"uniqueIndex:aid_pub_upid;index"`
+	Ctime     int64
+}
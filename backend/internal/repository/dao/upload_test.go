@@ -0,0 +1,117 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestGORMUploadDAO_SetReferences(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mock      func(t *testing.T) *sql.DB
+		uploadIds []int64
+		wantErr   error
+	}{
+		{
+			name: "覆盖原有引用",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `article_uploads` WHERE article_id = \\? AND pub = \\?").
+					WithArgs(1, true).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `article_uploads` .*").
+					WithArgs(1, true, 10, sqlmock.AnyArg(), 1, true, 11, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectCommit()
+				return mockDB
+			},
+			uploadIds: []int64{10, 11},
+		},
+		{
+			name: "不再引用任何图片",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `article_uploads` .*").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+				return mockDB
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      tc.mock(t),
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			d := NewGORMUploadDAO(db)
+			err = d.SetReferences(context.Background(), 1, true, tc.uploadIds)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestGORMUploadDAO_DeleteUnreferenced(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(t *testing.T) *sql.DB
+		wantOk  bool
+		wantErr error
+	}{
+		{
+			name: "没有被引用，删除",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("DELETE FROM `uploads` WHERE id = \\? AND NOT EXISTS \\(SELECT 1 FROM `article_uploads` WHERE article_uploads.upload_id = uploads.id\\)").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				return mockDB
+			},
+			wantOk: true,
+		},
+		{
+			name: "又被引用了，不删除",
+			mock: func(t *testing.T) *sql.DB {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("DELETE FROM `uploads` .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				return mockDB
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn:                      tc.mock(t),
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			d := NewGORMUploadDAO(db)
+			ok, err := d.DeleteUnreferenced(context.Background(), 1)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantOk, ok)
+		})
+	}
+}
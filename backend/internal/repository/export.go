@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository/dao"
+	"github.com/johnwongx/webook/backend/pkg/blobstore"
+	"time"
+)
+
+var ErrExportNotFound = dao.ErrExportNotFound
+
+// ExportRepository 导出任务保存在数据库，归档文件保存在对象存储
+type ExportRepository interface {
+	Create(ctx context.Context, uid int64) (domain.ArticleExport, error)
+	FindById(ctx context.Context, id, uid int64) (domain.ArticleExport, error)
+	FindActive(ctx context.Context, uid int64) (domain.ArticleExport, error)
+	Preempt(ctx context.Context, now time.Time, timeout time.Duration) (domain.ArticleExport, error)
+	UpdateProgress(ctx context.Context, id int64, done, total int) error
+	Finish(ctx context.Context, id int64, status domain.ArticleExportStatus, key string) error
+
+	PutArchive(ctx context.Context, key string, data []byte) error
+	// ArchiveURL 有效期为 expiration 的下载链接
+	ArchiveURL(ctx context.Context, key string, expiration time.Duration) (string, error)
+}
+
+type exportRepository struct {
+	d     dao.ExportDAO
+	store blobstore.BlobStore
+}
+
+func NewExportRepository(d dao.ExportDAO, store blobstore.BlobStore) ExportRepository {
+	return &exportRepository{
+		d:     d,
+		store: store,
+	}
+}
+
+func (e *exportRepository) Create(ctx context.Context, uid int64) (domain.ArticleExport, error) {
+	id, err := e.d.Insert(ctx, dao.ArticleExport{Uid: uid})
+	if err != nil {
+		return domain.ArticleExport{}, err
+	}
+	now := time.Now()
+	return domain.ArticleExport{
+		Id:     id,
+		Uid:    uid,
+		Status: domain.ArticleExportStatusPending,
+		Ctime:  now,
+		Utime:  now,
+	}, nil
+}
+
+func (e *exportRepository) FindById(ctx context.Context, id, uid int64) (domain.ArticleExport, error) {
+	res, err := e.d.FindById(ctx, id, uid)
+	if err != nil {
+		return domain.ArticleExport{}, err
+	}
+	return e.toDomain(res), nil
+}
+
+func (e *exportRepository) FindActive(ctx context.Context, uid int64) (domain.ArticleExport, error) {
+	res, err := e.d.FindActive(ctx, uid)
+	if err != nil {
+		return domain.ArticleExport{}, err
+	}
+	return e.toDomain(res), nil
+}
+
+func (e *exportRepository) Preempt(ctx context.Context, now time.Time, timeout time.Duration) (domain.ArticleExport, error) {
+	res, err := e.d.Preempt(ctx, now.UnixMilli(), timeout)
+	if err != nil {
+		return domain.ArticleExport{}, err
+	}
+	return e.toDomain(res), nil
+}
+
+func (e *exportRepository) UpdateProgress(ctx context.Context, id int64, done, total int) error {
+	return e.d.UpdateProgress(ctx, id, done, total)
+}
+
+func (e *exportRepository) Finish(ctx context.Context, id int64, status domain.ArticleExportStatus, key string) error {
+	return e.d.Finish(ctx, id, status.ToUint8(), key)
+}
+
+func (e *exportRepository) PutArchive(ctx context.Context, key string, data []byte) error {
+	return e.store.Put(ctx, key, data, "application/zip")
+}
+
+func (e *exportRepository) ArchiveURL(ctx context.Context, key string, expiration time.Duration) (string, error) {
+	return e.store.SignedURL(ctx, key, expiration)
+}
+
+func (e *exportRepository) toDomain(export dao.ArticleExport) domain.ArticleExport {
+	return domain.ArticleExport{
+		Id:     export.Id,
+		Uid:    export.Uid,
+		Status: domain.ArticleExportStatus(export.Status),
+		Total:  export.Total,
+		Done:   export.Done,
+		Key:    export.Key,
+		Ctime:  time.UnixMilli(export.Ctime),
+		Utime:  time.UnixMilli(export.Utime),
+	}
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository/dao"
+	"time"
+)
+
+type FeedRepository interface {
+	// Push 把文章推送到 uids 的收件箱
+	Push(ctx context.Context, aid, authorId int64, uids []int64, ctime time.Time) error
+	// ListPush uid 收件箱中的记录，按 (时间, 文章 id) 倒序返回游标之后的 limit 条，文章只有 Id 和作者 Id
+	ListPush(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.FeedItem, error)
+	DeletePush(ctx context.Context, uid, authorId int64) error
+}
+
+type feedRepository struct {
+	d dao.FeedDAO
+}
+
+func NewFeedRepository(d dao.FeedDAO) FeedRepository {
+	return &feedRepository{
+		d: d,
+	}
+}
+
+func (f *feedRepository) Push(ctx context.Context, aid, authorId int64, uids []int64, ctime time.Time) error {
+	evts := make([]dao.FeedPushEvent, 0, len(uids))
+	for _, uid := range uids {
+		evts = append(evts, dao.FeedPushEvent{
+			Uid:       uid,
+			ArticleId: aid,
+			AuthorId:  authorId,
+			Ctime:     ctime.UnixMilli(),
+		})
+	}
+	return f.d.InsertPushEvents(ctx, evts)
+}
+
+func (f *feedRepository) ListPush(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.FeedItem, error) {
+	evts, err := f.d.ListPushEvents(ctx, uid, cursor.Utime.UnixMilli(), cursor.Id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.FeedPushEvent, domain.FeedItem](evts, func(idx int, src dao.FeedPushEvent) domain.FeedItem {
+		return domain.FeedItem{
+			Article: domain.Article{
+				Id: src.ArticleId,
+				Author: domain.Author{
+					Id: src.AuthorId,
+				},
+			},
+			Ctime: time.UnixMilli(src.Ctime),
+		}
+	}), nil
+}
+
+func (f *feedRepository) DeletePush(ctx context.Context, uid, authorId int64) error {
+	return f.d.DeletePushEvents(ctx, uid, authorId)
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository/dao"
+	"time"
+)
+
+type FollowRepository interface {
+	Follow(ctx context.Context, follower, followee int64) error
+	Unfollow(ctx context.Context, follower, followee int64) error
+	Followees(ctx context.Context, follower int64, offset, limit int) ([]domain.FollowRelation, error)
+	Followers(ctx context.Context, followee int64, offset, limit int) ([]domain.FollowRelation, error)
+	// PopularFollowees follower 关注的人中粉丝数不少于 minFollowers 的
+	PopularFollowees(ctx context.Context, follower int64, minFollowers int64) ([]int64, error)
+	Statics(ctx context.Context, uid int64) (domain.FollowStatics, error)
+}
+
+type followRepository struct {
+	d dao.FollowDAO
+}
+
+func NewFollowRepository(d dao.FollowDAO) FollowRepository {
+	return &followRepository{
+		d: d,
+	}
+}
+
+func (f *followRepository) Follow(ctx context.Context, follower, followee int64) error {
+	return f.d.Follow(ctx, follower, followee)
+}
+
+func (f *followRepository) Unfollow(ctx context.Context, follower, followee int64) error {
+	return f.d.Unfollow(ctx, follower, followee)
+}
+
+func (f *followRepository) Followees(ctx context.Context, follower int64, offset, limit int) ([]domain.FollowRelation, error) {
+	res, err := f.d.Followees(ctx, follower, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.FollowRelation, domain.FollowRelation](res, func(idx int, src dao.FollowRelation) domain.FollowRelation {
+		return f.toDomain(src)
+	}), nil
+}
+
+func (f *followRepository) Followers(ctx context.Context, followee int64, offset, limit int) ([]domain.FollowRelation, error) {
+	res, err := f.d.Followers(ctx, followee, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.FollowRelation, domain.FollowRelation](res, func(idx int, src dao.FollowRelation) domain.FollowRelation {
+		return f.toDomain(src)
+	}), nil
+}
+
+func (f *followRepository) PopularFollowees(ctx context.Context, follower int64, minFollowers int64) ([]int64, error) {
+	return f.d.PopularFollowees(ctx, follower, minFollowers)
+}
+
+func (f *followRepository) Statics(ctx context.Context, uid int64) (domain.FollowStatics, error) {
+	res, err := f.d.Statics(ctx, uid)
+	if err != nil {
+		return domain.FollowStatics{}, err
+	}
+	return domain.FollowStatics{
+		Uid:       res.Uid,
+		Followers: res.Followers,
+		Followees: res.Followees,
+	}, nil
+}
+
+func (f *followRepository) toDomain(src dao.FollowRelation) domain.FollowRelation {
+	return domain.FollowRelation{
+		Follower: src.Follower,
+		Followee: src.Followee,
+		// 重新关注时以最近一次关注的时间为准
+		Ctime: time.UnixMilli(src.Utime),
+	}
+}
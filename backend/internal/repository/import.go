@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"github.com/johnwongx/webook/backend/internal/repository/dao"
+)
+
+var ErrImportNotFound = dao.ErrImportNotFound
+
+type ImportRepository interface {
+	// FindArticleId 之前导入过相同文件时创建的文章，没有导入过返回 ErrImportNotFound
+	FindArticleId(ctx context.Context, uid int64, hash string) (int64, error)
+	Save(ctx context.Context, uid int64, hash string, aid int64) error
+}
+
+type importRepository struct {
+	d dao.ImportDAO
+}
+
+func NewImportRepository(d dao.ImportDAO) ImportRepository {
+	return &importRepository{
+		d: d,
+	}
+}
+
+func (i *importRepository) FindArticleId(ctx context.Context, uid int64, hash string) (int64, error) {
+	res, err := i.d.FindByHash(ctx, uid, hash)
+	return res.ArticleId, err
+}
+
+func (i *importRepository) Save(ctx context.Context, uid int64, hash string, aid int64) error {
+	return i.d.Upsert(ctx, dao.ArticleImport{
+		Uid:       uid,
+		Hash:      hash,
+		ArticleId: aid,
+	})
+}
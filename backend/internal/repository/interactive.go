@@ -14,9 +14,17 @@ type InteractiveRepository interface {
 	IncrLike(ctx context.Context, id int64, biz string, uid int64) error
 	DecrLike(ctx context.Context, id int64, biz string, uid int64) error
 	Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error)
+	// GetByIds 批量获取计数，结果以 bizId 为键，没有计数的 bizId 对应零值
+	GetByIds(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error)
 	AddCollectionItem(ctx context.Context, id int64, biz string, cid, uid int64) error
+	// RemoveCollectionItem 没有收藏过时什么也不做
+	RemoveCollectionItem(ctx context.Context, id int64, biz string, uid int64) error
+	// IncrCommentCnt delta 为负数时减少评论数
+	IncrCommentCnt(ctx context.Context, biz string, bizId int64, delta int64) error
 	Liked(ctx context.Context, biz string, id int64, uid int64) (bool, error)
 	Collected(ctx context.Context, biz string, id int64, uid int64) (bool, error)
+	// DeleteByBiz 彻底删除业务对象时，删除计数以及点赞、收藏记录
+	DeleteByBiz(ctx context.Context, biz string, bizIds []int64) error
 }
 
 type interactiveRepository struct {
@@ -70,6 +78,51 @@ func (i *interactiveRepository) Get(ctx context.Context, biz string, bizId int64
 	return res, nil
 }
 
+func (i *interactiveRepository) GetByIds(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error) {
+	res := make(map[int64]domain.Interactive, len(bizIds))
+	var missed []int64
+	for _, id := range bizIds {
+		intr, err := i.cache.Get(ctx, biz, id)
+		if err == nil {
+			res[id] = intr
+			continue
+		}
+		if err != cache.ErrKeyNotExisted {
+			i.l.Error("获取计数缓存失败",
+				logger.Int64("bizId", id),
+				logger.String("biz", biz),
+				logger.Error(err))
+		}
+		missed = append(missed, id)
+	}
+	if len(missed) == 0 {
+		return res, nil
+	}
+
+	data, err := i.d.GetByIds(ctx, biz, missed)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range data {
+		res[d.BizId] = i.toDomain(d)
+	}
+	for _, id := range missed {
+		intr, ok := res[id]
+		if !ok {
+			// 还没有人阅读、点赞过
+			intr = domain.Interactive{BizId: id, Biz: biz}
+			res[id] = intr
+		}
+		if er := i.cache.Set(ctx, biz, id, intr); er != nil {
+			i.l.Error("回写缓存失败",
+				logger.Int64("bizId", id),
+				logger.String("biz", biz),
+				logger.Error(er))
+		}
+	}
+	return res, nil
+}
+
 func (i *interactiveRepository) IncrLike(ctx context.Context, id int64, biz string, uid int64) error {
 	err := i.d.IncrLike(ctx, id, biz, uid)
 	if err != nil {
@@ -94,6 +147,26 @@ func (i *interactiveRepository) AddCollectionItem(ctx context.Context, id int64,
 	return i.cache.IncrCollectCntIfPresent(ctx, biz, id)
 }
 
+func (i *interactiveRepository) RemoveCollectionItem(ctx context.Context, id int64, biz string, uid int64) error {
+	err := i.d.DeleteCollectionBiz(ctx, id, biz, uid)
+	switch err {
+	case nil:
+		return i.cache.DecrCollectCntIfPresent(ctx, biz, id)
+	case dao.ErrDataNotFound:
+		return nil
+	default:
+		return err
+	}
+}
+
+func (i *interactiveRepository) IncrCommentCnt(ctx context.Context, biz string, bizId int64, delta int64) error {
+	err := i.d.IncrCommentCnt(ctx, biz, bizId, delta)
+	if err != nil {
+		return err
+	}
+	return i.cache.IncrCommentCntIfPresent(ctx, biz, bizId, delta)
+}
+
 func (i *interactiveRepository) Liked(ctx context.Context, biz string, id int64, uid int64) (bool, error) {
 	info, err := i.d.GetLikeInfo(ctx, biz, id, uid)
 	switch err {
@@ -118,10 +191,30 @@ func (i *interactiveRepository) Collected(ctx context.Context, biz string, id in
 	}
 }
 
+func (i *interactiveRepository) DeleteByBiz(ctx context.Context, biz string, bizIds []int64) error {
+	err := i.d.DeleteByBiz(ctx, biz, bizIds)
+	if err != nil {
+		return err
+	}
+	for _, id := range bizIds {
+		er := i.cache.Delete(ctx, biz, id)
+		if er != nil {
+			i.l.Error("删除计数缓存失败",
+				logger.Int64("bizId", id),
+				logger.String("biz", biz),
+				logger.Error(er))
+		}
+	}
+	return nil
+}
+
 func (i *interactiveRepository) toDomain(data dao.Interactive) domain.Interactive {
 	return domain.Interactive{
-		BizId:   data.BizId,
-		Biz:     data.Biz,
-		ReadCnt: data.ReadCnt,
+		BizId:      data.BizId,
+		Biz:        data.Biz,
+		ReadCnt:    data.ReadCnt,
+		LikeCnt:    data.LikeCnt,
+		CollectCnt: data.CollectCnt,
+		CommentCnt: data.CommentCnt,
 	}
 }
@@ -35,6 +35,23 @@ func (m *MockReaderArticleRepository) EXPECT() *MockReaderArticleRepositoryMockR
 	return m.recorder
 }
 
+// Replay mocks base method.
+func (m *MockReaderArticleRepository) Replay(ctx context.Context, startId int64, limit int) (int64, int, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replay", ctx, startId, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// Replay indicates an expected call of Replay.
+func (mr *MockReaderArticleRepositoryMockRecorder) Replay(ctx, startId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replay", reflect.TypeOf((*MockReaderArticleRepository)(nil).Replay), ctx, startId, limit)
+}
+
 // Save mocks base method.
 func (m *MockReaderArticleRepository) Save(ctx context.Context, art domain.Article) error {
 	m.ctrl.T.Helper()
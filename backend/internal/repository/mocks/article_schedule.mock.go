@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backend/internal/repository/article_schedule.go
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/johnwongx/webook/backend/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleScheduleRepository is a mock of ArticleScheduleRepository interface.
+type MockArticleScheduleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleScheduleRepositoryMockRecorder
+}
+
+// MockArticleScheduleRepositoryMockRecorder is the mock recorder for MockArticleScheduleRepository.
+type MockArticleScheduleRepositoryMockRecorder struct {
+	mock *MockArticleScheduleRepository
+}
+
+// NewMockArticleScheduleRepository creates a new mock instance.
+func NewMockArticleScheduleRepository(ctrl *gomock.Controller) *MockArticleScheduleRepository {
+	mock := &MockArticleScheduleRepository{ctrl: ctrl}
+	mock.recorder = &MockArticleScheduleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleScheduleRepository) EXPECT() *MockArticleScheduleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockArticleScheduleRepository) Cancel(ctx context.Context, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockArticleScheduleRepositoryMockRecorder) Cancel(ctx, id, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockArticleScheduleRepository)(nil).Cancel), ctx, id, uid)
+}
+
+// CancelByArticle mocks base method.
+func (m *MockArticleScheduleRepository) CancelByArticle(ctx context.Context, aid, uid int64, action domain.ArticleScheduleAction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelByArticle", ctx, aid, uid, action)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelByArticle indicates an expected call of CancelByArticle.
+func (mr *MockArticleScheduleRepositoryMockRecorder) CancelByArticle(ctx, aid, uid, action interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelByArticle", reflect.TypeOf((*MockArticleScheduleRepository)(nil).CancelByArticle), ctx, aid, uid, action)
+}
+
+// Create mocks base method.
+func (m *MockArticleScheduleRepository) Create(ctx context.Context, s domain.ArticleSchedule) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, s)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockArticleScheduleRepositoryMockRecorder) Create(ctx, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockArticleScheduleRepository)(nil).Create), ctx, s)
+}
+
+// Finish mocks base method.
+func (m *MockArticleScheduleRepository) Finish(ctx context.Context, s domain.ArticleSchedule, status domain.ArticleScheduleStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", ctx, s, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockArticleScheduleRepositoryMockRecorder) Finish(ctx, s, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockArticleScheduleRepository)(nil).Finish), ctx, s, status)
+}
+
+// ListPending mocks base method.
+func (m *MockArticleScheduleRepository) ListPending(ctx context.Context, uid int64) ([]domain.ArticleSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPending", ctx, uid)
+	ret0, _ := ret[0].([]domain.ArticleSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPending indicates an expected call of ListPending.
+func (mr *MockArticleScheduleRepositoryMockRecorder) ListPending(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPending", reflect.TypeOf((*MockArticleScheduleRepository)(nil).ListPending), ctx, uid)
+}
+
+// Preempt mocks base method.
+func (m *MockArticleScheduleRepository) Preempt(ctx context.Context, now time.Time, timeout time.Duration) (domain.ArticleSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preempt", ctx, now, timeout)
+	ret0, _ := ret[0].(domain.ArticleSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preempt indicates an expected call of Preempt.
+func (mr *MockArticleScheduleRepositoryMockRecorder) Preempt(ctx, now, timeout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preempt", reflect.TypeOf((*MockArticleScheduleRepository)(nil).Preempt), ctx, now, timeout)
+}
+
+// Retry mocks base method.
+func (m *MockArticleScheduleRepository) Retry(ctx context.Context, s domain.ArticleSchedule, executeAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", ctx, s, executeAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retry indicates an expected call of Retry.
+func (mr *MockArticleScheduleRepositoryMockRecorder) Retry(ctx, s, executeAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MockArticleScheduleRepository)(nil).Retry), ctx, s, executeAt)
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository/cache"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+)
+
+type RankingRepository interface {
+	ReplaceTopN(ctx context.Context, arts []domain.HotArticle) error
+	GetTopN(ctx context.Context) ([]domain.HotArticle, error)
+}
+
+// CachedRankingRepository 热榜只存在缓存里，先查本地缓存，再查 Redis，
+// Redis 出错时退回到本地已过期的数据
+type CachedRankingRepository struct {
+	redis cache.RankingCache
+	local *cache.LocalRankingCache
+	l     logger.Logger
+}
+
+func NewCachedRankingRepository(redis cache.RankingCache, local *cache.LocalRankingCache,
+	l logger.Logger) RankingRepository {
+	return &CachedRankingRepository{
+		redis: redis,
+		local: local,
+		l:     l,
+	}
+}
+
+func (c *CachedRankingRepository) ReplaceTopN(ctx context.Context, arts []domain.HotArticle) error {
+	_ = c.local.Set(ctx, arts)
+	return c.redis.Set(ctx, arts)
+}
+
+func (c *CachedRankingRepository) GetTopN(ctx context.Context) ([]domain.HotArticle, error) {
+	arts, err := c.local.Get(ctx)
+	if err == nil {
+		return arts, nil
+	}
+	arts, err = c.redis.Get(ctx)
+	if err == nil {
+		_ = c.local.Set(ctx, arts)
+		return arts, nil
+	}
+	if err != cache.ErrKeyNotExisted {
+		c.l.Error("获取热榜缓存失败", logger.Error(err))
+	}
+	res, er := c.local.ForceGet(ctx)
+	if er == nil {
+		return res, nil
+	}
+	if err == cache.ErrKeyNotExisted {
+		// 热榜还没有算出来
+		return []domain.HotArticle{}, nil
+	}
+	return nil, err
+}
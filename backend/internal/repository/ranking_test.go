@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository/cache"
+	cachemocks "github.com/johnwongx/webook/backend/internal/repository/cache/mocks"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCachedRankingRepository_GetTopN(t *testing.T) {
+	hot := []domain.HotArticle{{Article: domain.Article{Id: 1}, Score: 10}}
+	testCases := []struct {
+		name      string
+		redisMock func(ctrl *gomock.Controller) cache.RankingCache
+		// local 本地缓存里已有的数据，nil 表示没有
+		local    []domain.HotArticle
+		wantArts []domain.HotArticle
+		wantErr  error
+	}{
+		{
+			name: "命中本地缓存",
+			redisMock: func(ctrl *gomock.Controller) cache.RankingCache {
+				return cachemocks.NewMockRankingCache(ctrl)
+			},
+			local:    hot,
+			wantArts: hot,
+		},
+		{
+			name: "命中 Redis",
+			redisMock: func(ctrl *gomock.Controller) cache.RankingCache {
+				rc := cachemocks.NewMockRankingCache(ctrl)
+				rc.EXPECT().Get(gomock.Any()).Return(hot, nil)
+				return rc
+			},
+			wantArts: hot,
+		},
+		{
+			name: "还没有热榜",
+			redisMock: func(ctrl *gomock.Controller) cache.RankingCache {
+				rc := cachemocks.NewMockRankingCache(ctrl)
+				rc.EXPECT().Get(gomock.Any()).Return(nil, cache.ErrKeyNotExisted)
+				return rc
+			},
+			wantArts: []domain.HotArticle{},
+		},
+		{
+			name: "Redis 出错",
+			redisMock: func(ctrl *gomock.Controller) cache.RankingCache {
+				rc := cachemocks.NewMockRankingCache(ctrl)
+				rc.EXPECT().Get(gomock.Any()).Return(nil, errors.New("redis error"))
+				return rc
+			},
+			wantErr: errors.New("redis error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			local := cache.NewLocalRankingCache()
+			if tc.local != nil {
+				_ = local.Set(context.Background(), tc.local)
+			}
+			repo := NewCachedRankingRepository(tc.redisMock(ctrl), local, logger.NewNopLogger())
+			arts, err := repo.GetTopN(context.Background())
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantArts, arts)
+		})
+	}
+}
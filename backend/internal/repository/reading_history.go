@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository/dao"
+	"time"
+)
+
+type ReadingHistoryRepository interface {
+	// Record 已经读过的只更新最近阅读时间
+	Record(ctx context.Context, hs []domain.ReadingHistory) error
+	UpdateProgress(ctx context.Context, h domain.ReadingHistory) error
+	List(ctx context.Context, uid int64, offset, limit int) ([]domain.ReadingHistory, error)
+	// ListUnfinished 上报过进度但是还没有读完的
+	ListUnfinished(ctx context.Context, uid int64, limit int) ([]domain.ReadingHistory, error)
+	Delete(ctx context.Context, uid int64, aids []int64) error
+	Clear(ctx context.Context, uid int64) error
+	// Trim 只保留最近读过的 keep 篇
+	Trim(ctx context.Context, uid int64, keep int) error
+}
+
+type readingHistoryRepository struct {
+	d dao.ReadingHistoryDAO
+}
+
+func NewReadingHistoryRepository(d dao.ReadingHistoryDAO) ReadingHistoryRepository {
+	return &readingHistoryRepository{
+		d: d,
+	}
+}
+
+func (r *readingHistoryRepository) Record(ctx context.Context, hs []domain.ReadingHistory) error {
+	return r.d.Record(ctx, slice.Map[domain.ReadingHistory, dao.ReadingHistory](hs,
+		func(idx int, src domain.ReadingHistory) dao.ReadingHistory {
+			return r.toEntity(src)
+		}))
+}
+
+func (r *readingHistoryRepository) UpdateProgress(ctx context.Context, h domain.ReadingHistory) error {
+	return r.d.UpdateProgress(ctx, r.toEntity(h))
+}
+
+func (r *readingHistoryRepository) List(ctx context.Context, uid int64, offset, limit int) ([]domain.ReadingHistory, error) {
+	res, err := r.d.List(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.ReadingHistory, domain.ReadingHistory](res, func(idx int, src dao.ReadingHistory) domain.ReadingHistory {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *readingHistoryRepository) ListUnfinished(ctx context.Context, uid int64, limit int) ([]domain.ReadingHistory, error) {
+	res, err := r.d.ListUnfinished(ctx, uid, domain.ReadingFinishedProgress, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.ReadingHistory, domain.ReadingHistory](res, func(idx int, src dao.ReadingHistory) domain.ReadingHistory {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *readingHistoryRepository) Delete(ctx context.Context, uid int64, aids []int64) error {
+	return r.d.Delete(ctx, uid, aids)
+}
+
+func (r *readingHistoryRepository) Clear(ctx context.Context, uid int64) error {
+	return r.d.Clear(ctx, uid)
+}
+
+func (r *readingHistoryRepository) Trim(ctx context.Context, uid int64, keep int) error {
+	return r.d.Trim(ctx, uid, keep)
+}
+
+func (r *readingHistoryRepository) toEntity(h domain.ReadingHistory) dao.ReadingHistory {
+	return dao.ReadingHistory{
+		Uid:      h.Uid,
+		Aid:      h.Aid,
+		Progress: h.Progress,
+		Ctime:    h.Ctime.UnixMilli(),
+		Utime:    h.Utime.UnixMilli(),
+	}
+}
+
+func (r *readingHistoryRepository) toDomain(h dao.ReadingHistory) domain.ReadingHistory {
+	return domain.ReadingHistory{
+		Uid:      h.Uid,
+		Aid:      h.Aid,
+		Progress: h.Progress,
+		Article:  domain.Article{Id: h.Aid},
+		Ctime:    time.UnixMilli(h.Ctime),
+		Utime:    time.UnixMilli(h.Utime),
+	}
+}
@@ -0,0 +1,101 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	highlightPre  = "<em>"
+	highlightPost = "</em>"
+	// snippetSize 正文摘要的长度，以 rune 计
+	snippetSize = 120
+)
+
+// matchRanges 命中的词在原文中的位置，相邻或重叠的位置会被合并
+func matchRanges(text string, terms map[string]struct{}) [][2]int {
+	var res [][2]int
+	for _, t := range tokenize(text, true) {
+		if _, ok := terms[t.term]; !ok {
+			continue
+		}
+		if n := len(res); n > 0 && t.start <= res[n-1][1] {
+			if t.end > res[n-1][1] {
+				res[n-1][1] = t.end
+			}
+			continue
+		}
+		res = append(res, [2]int{t.start, t.end})
+	}
+	return res
+}
+
+// render 输出 rs[from:to]，命中的部分用 <em></em> 包裹，其余部分做 HTML 转义
+func render(rs []rune, ranges [][2]int, from, to int) string {
+	var sb strings.Builder
+	pos := from
+	for _, r := range ranges {
+		start, end := r[0], r[1]
+		if end <= from || start >= to {
+			continue
+		}
+		if start < from {
+			start = from
+		}
+		if end > to {
+			end = to
+		}
+		sb.WriteString(html.EscapeString(string(rs[pos:start])))
+		sb.WriteString(highlightPre)
+		sb.WriteString(html.EscapeString(string(rs[start:end])))
+		sb.WriteString(highlightPost)
+		pos = end
+	}
+	sb.WriteString(html.EscapeString(string(rs[pos:to])))
+	return sb.String()
+}
+
+// highlight 高亮整段文本，用于标题
+func highlight(text string, terms map[string]struct{}) string {
+	rs := []rune(text)
+	return render(rs, matchRanges(text, terms), 0, len(rs))
+}
+
+// snippet 截取第一个命中位置附近的一段正文并高亮，没有命中时取开头
+func snippet(text string, terms map[string]struct{}) string {
+	// 换行、制表符等统一换成空格，摘要只展示一行
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return ' '
+		}
+		return r
+	}, text)
+	rs := []rune(text)
+	ranges := matchRanges(text, terms)
+	start := 0
+	if len(ranges) > 0 {
+		// 命中位置前保留一点上下文
+		start = ranges[0][0] - snippetSize/4
+		if start < 0 {
+			start = 0
+		}
+	}
+	end := start + snippetSize
+	if end > len(rs) {
+		end = len(rs)
+		// 命中位置靠近结尾时，往前多取一些，保证摘要长度
+		start = end - snippetSize
+		if start < 0 {
+			start = 0
+		}
+	}
+	res := render(rs, ranges, start, end)
+	if start > 0 {
+		res = "..." + res
+	}
+	if end < len(rs) {
+		res += "..."
+	}
+	return res
+}
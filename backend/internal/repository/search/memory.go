@@ -0,0 +1,194 @@
+package search
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+)
+
+var _ ArticleIndex = &MemoryArticleIndex{}
+
+type field int
+
+const (
+	fieldTitle field = iota
+	fieldAuthor
+	fieldContent
+	fieldCount
+)
+
+// fieldWeights 标题命中比作者命中重要，作者命中比正文命中重要
+var fieldWeights = [fieldCount]float64{3, 2, 1}
+
+// BM25 参数
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+type memDoc struct {
+	doc  ArticleDoc
+	lens [fieldCount]int
+	// terms 每个字段出现过的词，删除时用来清理倒排表
+	terms [fieldCount]map[string]int
+}
+
+// MemoryArticleIndex 进程内的倒排索引，按字段加权的 BM25 打分。
+// 索引只在本进程内可见，重启后需要重建
+type MemoryArticleIndex struct {
+	mu   sync.RWMutex
+	docs map[int64]*memDoc
+	// postings 字段 -> 词 -> 文章 id -> 词频
+	postings  [fieldCount]map[string]map[int64]int
+	totalLens [fieldCount]int
+}
+
+func NewMemoryArticleIndex() ArticleIndex {
+	idx := &MemoryArticleIndex{
+		docs: make(map[int64]*memDoc),
+	}
+	for f := range idx.postings {
+		idx.postings[f] = make(map[string]map[int64]int)
+	}
+	return idx
+}
+
+func (m *MemoryArticleIndex) Upsert(ctx context.Context, doc ArticleDoc) error {
+	md := &memDoc{doc: doc}
+	texts := [fieldCount]string{doc.Title, doc.Author, doc.Content}
+	for f, text := range texts {
+		tokens := tokenize(text, true)
+		md.lens[f] = len(tokens)
+		md.terms[f] = make(map[string]int, len(tokens))
+		for _, t := range tokens {
+			md.terms[f][t.term]++
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.remove(doc.Id)
+	m.docs[doc.Id] = md
+	for f := range md.terms {
+		m.totalLens[f] += md.lens[f]
+		for term, tf := range md.terms[f] {
+			posting, ok := m.postings[f][term]
+			if !ok {
+				posting = make(map[int64]int)
+				m.postings[f][term] = posting
+			}
+			posting[doc.Id] = tf
+		}
+	}
+	return nil
+}
+
+func (m *MemoryArticleIndex) Delete(ctx context.Context, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.remove(id)
+	return nil
+}
+
+// remove 调用方需要持有写锁
+func (m *MemoryArticleIndex) remove(id int64) {
+	md, ok := m.docs[id]
+	if !ok {
+		return
+	}
+	delete(m.docs, id)
+	for f := range md.terms {
+		m.totalLens[f] -= md.lens[f]
+		for term := range md.terms[f] {
+			posting := m.postings[f][term]
+			delete(posting, id)
+			if len(posting) == 0 {
+				delete(m.postings[f], term)
+			}
+		}
+	}
+}
+
+func (m *MemoryArticleIndex) Search(ctx context.Context, q string, offset, limit int) (ArticleSearchResult, error) {
+	terms := queryTerms(q)
+	if len(terms) == 0 {
+		return ArticleSearchResult{}, nil
+	}
+
+	m.mu.RLock()
+	scores := make(map[int64]float64)
+	// matched 每篇文章命中了多少个不同的查询词
+	matched := make(map[int64]int)
+	n := float64(len(m.docs))
+	for _, term := range terms {
+		hit := make(map[int64]struct{})
+		for f := field(0); f < fieldCount; f++ {
+			posting := m.postings[f][term]
+			if len(posting) == 0 {
+				continue
+			}
+			df := float64(len(posting))
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			avgLen := float64(m.totalLens[f]) / n
+			for id, tf := range posting {
+				dl := float64(m.docs[id].lens[f])
+				ftf := float64(tf)
+				scores[id] += fieldWeights[f] * idf * ftf * (bm25K1 + 1) /
+					(ftf + bm25K1*(1-bm25B+bm25B*dl/avgLen))
+				hit[id] = struct{}{}
+			}
+		}
+		for id := range hit {
+			matched[id]++
+		}
+	}
+	docs := make([]*memDoc, 0, len(scores))
+	for id := range scores {
+		// 命中的查询词越多越靠前
+		scores[id] *= float64(matched[id]) / float64(len(terms))
+		docs = append(docs, m.docs[id])
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(docs, func(i, j int) bool {
+		si, sj := scores[docs[i].doc.Id], scores[docs[j].doc.Id]
+		if si != sj {
+			return si > sj
+		}
+		if docs[i].doc.Utime != docs[j].doc.Utime {
+			return docs[i].doc.Utime > docs[j].doc.Utime
+		}
+		return docs[i].doc.Id > docs[j].doc.Id
+	})
+
+	res := ArticleSearchResult{Total: len(docs)}
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(docs) {
+		return res, nil
+	}
+	end := offset + limit
+	if end > len(docs) {
+		end = len(docs)
+	}
+	termSet := make(map[string]struct{}, len(terms))
+	for _, t := range terms {
+		termSet[t] = struct{}{}
+	}
+	res.Hits = make([]ArticleHit, 0, end-offset)
+	for _, md := range docs[offset:end] {
+		res.Hits = append(res.Hits, ArticleHit{
+			Id:               md.doc.Id,
+			Title:            md.doc.Title,
+			AuthorId:         md.doc.AuthorId,
+			Author:           md.doc.Author,
+			Utime:            md.doc.Utime,
+			Score:            scores[md.doc.Id],
+			TitleHighlight:   highlight(md.doc.Title, termSet),
+			ContentHighlight: snippet(md.doc.Content, termSet),
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,112 @@
+package search
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMemoryArticleIndex_Search(t *testing.T) {
+	docs := []ArticleDoc{
+		{Id: 1, Title: "Go 并发编程", Content: "goroutine 和 channel 的使用", Author: "Tom", Utime: 100},
+		{Id: 2, Title: "MySQL 索引", Content: "B+ 树索引，以及在 Go 中使用 GORM", Author: "Jerry", Utime: 200},
+		{Id: 3, Title: "Redis 缓存", Content: "缓存穿透、缓存击穿 <script>", Author: "Go 语言爱好者", Utime: 300},
+	}
+	testCases := []struct {
+		name    string
+		q       string
+		offset  int
+		limit   int
+		wantIds []int64
+		total   int
+	}{
+		{
+			name:    "标题命中排在正文命中前面",
+			q:       "go",
+			limit:   10,
+			wantIds: []int64{1, 3, 2},
+			total:   3,
+		},
+		{
+			name:    "中文二元组",
+			q:       "索引",
+			limit:   10,
+			wantIds: []int64{2},
+			total:   1,
+		},
+		{
+			name:    "单个汉字",
+			q:       "缓",
+			limit:   10,
+			wantIds: []int64{3},
+			total:   1,
+		},
+		{
+			name:    "分页",
+			q:       "go",
+			offset:  1,
+			limit:   1,
+			wantIds: []int64{3},
+			total:   3,
+		},
+		{
+			name:    "超出范围",
+			q:       "go",
+			offset:  10,
+			limit:   10,
+			wantIds: []int64{},
+			total:   3,
+		},
+		{
+			name:    "没有命中",
+			q:       "kafka",
+			limit:   10,
+			wantIds: []int64{},
+		},
+	}
+
+	idx := NewMemoryArticleIndex()
+	for _, doc := range docs {
+		require.NoError(t, idx.Upsert(context.Background(), doc))
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := idx.Search(context.Background(), tc.q, tc.offset, tc.limit)
+			require.NoError(t, err)
+			assert.Equal(t, tc.total, res.Total)
+			ids := make([]int64, 0, len(res.Hits))
+			for _, h := range res.Hits {
+				ids = append(ids, h.Id)
+			}
+			assert.Equal(t, tc.wantIds, ids)
+		})
+	}
+}
+
+func TestMemoryArticleIndex_UpsertDelete(t *testing.T) {
+	ctx := context.Background()
+	idx := NewMemoryArticleIndex()
+	require.NoError(t, idx.Upsert(ctx, ArticleDoc{Id: 1, Title: "old title"}))
+	require.NoError(t, idx.Upsert(ctx, ArticleDoc{Id: 1, Title: "new title"}))
+
+	res, err := idx.Search(ctx, "old", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Total)
+	res, err = idx.Search(ctx, "new", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Total)
+
+	require.NoError(t, idx.Delete(ctx, 1))
+	res, err = idx.Search(ctx, "title", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Total)
+}
+
+func TestHighlight(t *testing.T) {
+	terms := map[string]struct{}{"缓存": {}, "go": {}}
+	assert.Equal(t, "Redis <em>缓存</em>", highlight("Redis 缓存", terms))
+	assert.Equal(t, "<em>Go</em> &lt;b&gt;", highlight("Go <b>", terms))
+	assert.Equal(t, "<em>缓存</em>穿透、<em>缓存</em>击穿 &lt;script&gt;",
+		snippet("缓存穿透、缓存击穿 <script>", terms))
+}
@@ -0,0 +1,84 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// token 一个词以及它在原文中的位置，位置以 rune 计
+type token struct {
+	term  string
+	start int
+	end   int
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// tokenize 拉丁字母、数字按单词切分并转小写；中日韩文字没有分隔符，按二元组切分。
+// 建索引时额外输出单字，这样只搜一个汉字也能命中；查询时连续的汉字只用二元组，避免单字带来的噪音
+func tokenize(text string, forIndex bool) []token {
+	rs := []rune(text)
+	var res []token
+	for i := 0; i < len(rs); {
+		switch {
+		case isCJK(rs[i]):
+			j := i
+			for j < len(rs) && isCJK(rs[j]) {
+				j++
+			}
+			res = append(res, cjkTokens(rs, i, j, forIndex)...)
+			i = j
+		case isWordRune(rs[i]):
+			j := i
+			for j < len(rs) && isWordRune(rs[j]) && !isCJK(rs[j]) {
+				j++
+			}
+			res = append(res, token{
+				term:  strings.ToLower(string(rs[i:j])),
+				start: i,
+				end:   j,
+			})
+			i = j
+		default:
+			i++
+		}
+	}
+	return res
+}
+
+func cjkTokens(rs []rune, start, end int, forIndex bool) []token {
+	if end-start == 1 {
+		return []token{{term: string(rs[start]), start: start, end: end}}
+	}
+	var res []token
+	for i := start; i < end; i++ {
+		if forIndex {
+			res = append(res, token{term: string(rs[i]), start: i, end: i + 1})
+		}
+		if i+1 < end {
+			res = append(res, token{term: string(rs[i : i+2]), start: i, end: i + 2})
+		}
+	}
+	return res
+}
+
+// queryTerms 查询中去重后的词
+func queryTerms(q string) []string {
+	tokens := tokenize(q, false)
+	seen := make(map[string]struct{}, len(tokens))
+	res := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		if _, ok := seen[t.term]; ok {
+			continue
+		}
+		seen[t.term] = struct{}{}
+		res = append(res, t.term)
+	}
+	return res
+}
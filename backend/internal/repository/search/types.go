@@ -0,0 +1,40 @@
+package search
+
+import "context"
+
+// ArticleIndex 已发表文章的全文索引
+// 默认使用进程内的 MemoryArticleIndex，接入 ES 等搜索引擎时实现该接口即可
+type ArticleIndex interface {
+	// Upsert 文章不存在则加入索引，存在则覆盖
+	Upsert(ctx context.Context, doc ArticleDoc) error
+	Delete(ctx context.Context, id int64) error
+	// Search 按相关度倒序返回 [offset, offset+limit) 的结果
+	Search(ctx context.Context, q string, offset, limit int) (ArticleSearchResult, error)
+}
+
+type ArticleDoc struct {
+	Id       int64
+	Title    string
+	Content  string
+	AuthorId int64
+	// Author 作者昵称
+	Author string
+	Utime  int64
+}
+
+type ArticleHit struct {
+	Id       int64
+	Title    string
+	AuthorId int64
+	Author   string
+	Utime    int64
+
+	Score            float64
+	TitleHighlight   string
+	ContentHighlight string
+}
+
+type ArticleSearchResult struct {
+	Total int
+	Hits  []ArticleHit
+}
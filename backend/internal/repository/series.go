@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository/dao"
+	"time"
+)
+
+var (
+	ErrSeriesNotFound         = dao.ErrSeriesNotFound
+	ErrArticleInSeries        = dao.ErrArticleInSeries
+	ErrSeriesArticlesMismatch = dao.ErrSeriesArticlesMismatch
+)
+
+type SeriesRepository interface {
+	Create(ctx context.Context, s domain.Series) (int64, error)
+	// FindById 不包含专栏里的文章
+	FindById(ctx context.Context, id int64) (domain.Series, error)
+	FindByAuthor(ctx context.Context, uid int64) ([]domain.Series, error)
+	AddArticle(ctx context.Context, sid, aid int64) error
+	RemoveArticle(ctx context.Context, sid, aid int64) error
+	Reorder(ctx context.Context, sid int64, aids []int64) error
+	// ArticleIds 按顺序返回专栏里的文章 id
+	ArticleIds(ctx context.Context, sid int64) ([]int64, error)
+	// FindIdByArticle 文章所属专栏的 id，不属于任何专栏时返回 ErrSeriesNotFound
+	FindIdByArticle(ctx context.Context, aid int64) (int64, error)
+}
+
+type seriesRepository struct {
+	d dao.SeriesDAO
+}
+
+func NewSeriesRepository(d dao.SeriesDAO) SeriesRepository {
+	return &seriesRepository{
+		d: d,
+	}
+}
+
+func (s *seriesRepository) Create(ctx context.Context, series domain.Series) (int64, error) {
+	return s.d.Insert(ctx, dao.Series{
+		AuthorId:    series.Author.Id,
+		Title:       series.Title,
+		Description: series.Description,
+	})
+}
+
+func (s *seriesRepository) FindById(ctx context.Context, id int64) (domain.Series, error) {
+	series, err := s.d.FindById(ctx, id)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	return s.toDomain(series), nil
+}
+
+func (s *seriesRepository) FindByAuthor(ctx context.Context, uid int64) ([]domain.Series, error) {
+	res, err := s.d.FindByAuthor(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Series, domain.Series](res, func(idx int, src dao.Series) domain.Series {
+		return s.toDomain(src)
+	}), nil
+}
+
+func (s *seriesRepository) AddArticle(ctx context.Context, sid, aid int64) error {
+	return s.d.AddArticle(ctx, sid, aid)
+}
+
+func (s *seriesRepository) RemoveArticle(ctx context.Context, sid, aid int64) error {
+	return s.d.RemoveArticle(ctx, sid, aid)
+}
+
+func (s *seriesRepository) Reorder(ctx context.Context, sid int64, aids []int64) error {
+	return s.d.Reorder(ctx, sid, aids)
+}
+
+func (s *seriesRepository) ArticleIds(ctx context.Context, sid int64) ([]int64, error) {
+	res, err := s.d.FindArticles(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.SeriesArticle, int64](res, func(idx int, src dao.SeriesArticle) int64 {
+		return src.ArticleId
+	}), nil
+}
+
+func (s *seriesRepository) FindIdByArticle(ctx context.Context, aid int64) (int64, error) {
+	res, err := s.d.FindByArticle(ctx, aid)
+	return res.SeriesId, err
+}
+
+func (s *seriesRepository) toDomain(series dao.Series) domain.Series {
+	return domain.Series{
+		Id: series.Id,
+		Author: domain.Author{
+			Id: series.AuthorId,
+		},
+		Title:       series.Title,
+		Description: series.Description,
+		Ctime:       time.UnixMilli(series.Ctime),
+		Utime:       time.UnixMilli(series.Utime),
+	}
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"github.com/johnwongx/webook/backend/internal/repository/cache"
+	"github.com/johnwongx/webook/backend/pkg/blobstore"
+)
+
+var ErrSitemapNotFound = blobstore.ErrNotFound
+
+// SitemapRepository 生成好的 sitemap 文件存放在对象存储中，所有实例共享
+type SitemapRepository interface {
+	Save(ctx context.Context, name string, data []byte) error
+	// Get 文件不存在时返回 ErrSitemapNotFound
+	Get(ctx context.Context, name string) ([]byte, error)
+	Delete(ctx context.Context, name string) error
+	// TakeChanged 上次调用之后是否有文章发表或下线
+	TakeChanged(ctx context.Context) (bool, error)
+	MarkChanged(ctx context.Context) error
+}
+
+type sitemapRepository struct {
+	store blobstore.BlobStore
+	cache cache.SitemapCache
+}
+
+func NewSitemapRepository(store blobstore.BlobStore, c cache.SitemapCache) SitemapRepository {
+	return &sitemapRepository{
+		store: store,
+		cache: c,
+	}
+}
+
+func (s *sitemapRepository) Save(ctx context.Context, name string, data []byte) error {
+	return s.store.Put(ctx, s.key(name), data, "application/xml")
+}
+
+func (s *sitemapRepository) Get(ctx context.Context, name string) ([]byte, error) {
+	return s.store.Get(ctx, s.key(name))
+}
+
+func (s *sitemapRepository) Delete(ctx context.Context, name string) error {
+	return s.store.Delete(ctx, s.key(name))
+}
+
+func (s *sitemapRepository) TakeChanged(ctx context.Context) (bool, error) {
+	return s.cache.TakeChanged(ctx)
+}
+
+func (s *sitemapRepository) MarkChanged(ctx context.Context) error {
+	return s.cache.MarkChanged(ctx)
+}
+
+func (s *sitemapRepository) key(name string) string {
+	return "sitemaps/" + name
+}
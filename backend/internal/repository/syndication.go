@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository/cache"
+)
+
+var ErrSyndicationNotCached = cache.ErrKeyNotExisted
+
+// SyndicationRepository 订阅源只存在缓存里，由 ArticleRepository 在发表状态变化时清除
+type SyndicationRepository interface {
+	// Get uid 为 0 表示全站，没有缓存时返回 ErrSyndicationNotCached
+	Get(ctx context.Context, uid int64, format domain.SyndicationFormat) (domain.SyndicationFeed, error)
+	Save(ctx context.Context, uid int64, feed domain.SyndicationFeed) error
+}
+
+type CachedSyndicationRepository struct {
+	cache cache.SyndicationCache
+}
+
+func NewCachedSyndicationRepository(c cache.SyndicationCache) SyndicationRepository {
+	return &CachedSyndicationRepository{
+		cache: c,
+	}
+}
+
+func (c *CachedSyndicationRepository) Get(ctx context.Context, uid int64, format domain.SyndicationFormat) (domain.SyndicationFeed, error) {
+	return c.cache.Get(ctx, uid, format)
+}
+
+func (c *CachedSyndicationRepository) Save(ctx context.Context, uid int64, feed domain.SyndicationFeed) error {
+	return c.cache.Set(ctx, uid, feed)
+}
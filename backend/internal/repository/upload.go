@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository/dao"
+	"github.com/johnwongx/webook/backend/pkg/blobstore"
+	"time"
+)
+
+var (
+	ErrUploadNotFound  = dao.ErrUploadNotFound
+	ErrUploadDuplicate = dao.ErrUploadDuplicate
+	// ErrUploadContentNotFound 对象存储里没有对应的文件
+	ErrUploadContentNotFound = blobstore.ErrNotFound
+)
+
+// UploadRepository 图片的元数据保存在数据库，文件保存在对象存储
+type UploadRepository interface {
+	Create(ctx context.Context, u domain.Upload) (int64, error)
+	FindByHash(ctx context.Context, hash string) (domain.Upload, error)
+	FindByHashes(ctx context.Context, hashes []string) ([]domain.Upload, error)
+
+	PutContent(ctx context.Context, hash string, variant domain.UploadVariant, data []byte, contentType string) error
+	GetContent(ctx context.Context, hash string, variant domain.UploadVariant) ([]byte, error)
+	// DeleteContent 删除所有尺寸的文件
+	DeleteContent(ctx context.Context, hash string) error
+
+	SetReferences(ctx context.Context, aid int64, pub bool, uploadIds []int64) error
+	DeleteReferences(ctx context.Context, aids []int64) error
+	ListUnreferenced(ctx context.Context, before time.Time, limit int) ([]domain.Upload, error)
+	DeleteUnreferenced(ctx context.Context, id int64) (bool, error)
+}
+
+type uploadRepository struct {
+	d     dao.UploadDAO
+	store blobstore.BlobStore
+}
+
+func NewUploadRepository(d dao.UploadDAO, store blobstore.BlobStore) UploadRepository {
+	return &uploadRepository{
+		d:     d,
+		store: store,
+	}
+}
+
+func (u *uploadRepository) Create(ctx context.Context, upload domain.Upload) (int64, error) {
+	return u.d.Insert(ctx, dao.Upload{
+		Uid:         upload.Uid,
+		Hash:        upload.Hash,
+		ContentType: upload.ContentType,
+		Size:        upload.Size,
+		Width:       upload.Width,
+		Height:      upload.Height,
+	})
+}
+
+func (u *uploadRepository) FindByHash(ctx context.Context, hash string) (domain.Upload, error) {
+	res, err := u.d.FindByHash(ctx, hash)
+	if err != nil {
+		return domain.Upload{}, err
+	}
+	return u.toDomain(res), nil
+}
+
+func (u *uploadRepository) FindByHashes(ctx context.Context, hashes []string) ([]domain.Upload, error) {
+	res, err := u.d.FindByHashes(ctx, hashes)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Upload, domain.Upload](res, func(idx int, src dao.Upload) domain.Upload {
+		return u.toDomain(src)
+	}), nil
+}
+
+func (u *uploadRepository) PutContent(ctx context.Context, hash string, variant domain.UploadVariant,
+	data []byte, contentType string) error {
+	return u.store.Put(ctx, u.key(hash, variant), data, contentType)
+}
+
+func (u *uploadRepository) GetContent(ctx context.Context, hash string, variant domain.UploadVariant) ([]byte, error) {
+	return u.store.Get(ctx, u.key(hash, variant))
+}
+
+func (u *uploadRepository) DeleteContent(ctx context.Context, hash string) error {
+	for _, v := range []domain.UploadVariant{domain.UploadVariantOriginal,
+		domain.UploadVariantWeb, domain.UploadVariantThumb} {
+		err := u.store.Delete(ctx, u.key(hash, v))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *uploadRepository) SetReferences(ctx context.Context, aid int64, pub bool, uploadIds []int64) error {
+	return u.d.SetReferences(ctx, aid, pub, uploadIds)
+}
+
+func (u *uploadRepository) DeleteReferences(ctx context.Context, aids []int64) error {
+	return u.d.DeleteReferences(ctx, aids)
+}
+
+func (u *uploadRepository) ListUnreferenced(ctx context.Context, before time.Time, limit int) ([]domain.Upload, error) {
+	res, err := u.d.ListUnreferenced(ctx, before.UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Upload, domain.Upload](res, func(idx int, src dao.Upload) domain.Upload {
+		return u.toDomain(src)
+	}), nil
+}
+
+func (u *uploadRepository) DeleteUnreferenced(ctx context.Context, id int64) (bool, error) {
+	return u.d.DeleteUnreferenced(ctx, id)
+}
+
+func (u *uploadRepository) key(hash string, variant domain.UploadVariant) string {
+	return "images/" + hash + "/" + string(variant)
+}
+
+func (u *uploadRepository) toDomain(upload dao.Upload) domain.Upload {
+	return domain.Upload{
+		Id:          upload.Id,
+		Uid:         upload.Uid,
+		Hash:        upload.Hash,
+		ContentType: upload.ContentType,
+		Size:        upload.Size,
+		Width:       upload.Width,
+		Height:      upload.Height,
+		Ctime:       time.UnixMilli(upload.Ctime),
+	}
+}
@@ -2,52 +2,332 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"github.com/ecodeclub/ekit/slice"
 	"github.com/johnwongx/webook/backend/internal/domain"
 	"github.com/johnwongx/webook/backend/internal/repository"
+	"github.com/johnwongx/webook/backend/pkg/diffx"
 	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/johnwongx/webook/backend/pkg/markdown"
+	"github.com/johnwongx/webook/backend/pkg/sensitive"
+	"strings"
+	"time"
 )
 
+var (
+	ErrScheduleNotPending     = repository.ErrScheduleNotPending
+	ErrArticleVersionConflict = repository.ErrArticleVersionConflict
+	ErrArticleNotFound        = repository.ErrArticleNotFound
+	// ErrArticleSensitive 命中了禁止发表的敏感词
+	ErrArticleSensitive = errors.New("文章包含违规内容")
+	// ErrArticlePendingReview 命中了需要审核的敏感词，文章已保存，审核通过后发表
+	ErrArticlePendingReview = errors.New("文章等待审核")
+)
+
+// TrashRetention 回收站里的文章保留的时间，超过后不能恢复，由后台任务彻底删除
+const TrashRetention = time.Hour * 24 * 30
+
 type ArticleService interface {
 	Save(ctx context.Context, art domain.Article) (int64, error)
-	Publish(ctx context.Context, art domain.Article) (int64, error)
-	Withdraw(ctx context.Context, id, usrId int64) error
-	List(ctx context.Context, id int64, offset, limit int) ([]domain.Article, error)
+	// Publish 命中禁止的敏感词返回 ErrArticleSensitive；
+	// 命中需要审核的敏感词时保存草稿，返回文章 id 和 ErrArticlePendingReview
+	Publish(ctx context.Context, art domain.Article, opts ...PublishOption) (int64, error)
+	Withdraw(ctx context.Context, id, usrId int64, opts ...WithdrawOption) error
+	// List 返回作者的一页文章和下一页的游标，没有下一页时游标为零值
+	List(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, domain.ArticleCursor, error)
 	GetById(ctx context.Context, id, uid int64) (domain.Article, error)
 	GetPubById(ctx context.Context, id int64) (domain.Article, error)
+
+	ListRevisions(ctx context.Context, id, uid int64, offset, limit int) ([]domain.ArticleRevision, error)
+	GetRevision(ctx context.Context, id, rid, uid int64) (domain.ArticleRevision, error)
+	DiffRevisions(ctx context.Context, id, uid, from, to int64) (domain.ArticleRevisionDiff, error)
+	// RestoreRevision 把历史版本恢复为当前草稿
+	RestoreRevision(ctx context.Context, id, rid, uid int64) (int64, error)
+
+	// Delete 移入回收站，同时取消未执行的定时任务
+	Delete(ctx context.Context, id, uid int64) error
+	// Restore 从回收站恢复为未发表的草稿，超过保留时间返回 ErrArticleNotFound
+	Restore(ctx context.Context, id, uid int64) error
+	// ListTrash 回收站里还能恢复的文章，按删除时间倒序
+	ListTrash(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error)
+	// PurgeExpired 彻底删除超过保留时间的文章及其互动数据，由后台任务周期调用
+	PurgeExpired(ctx context.Context) error
+
+	ListSchedules(ctx context.Context, uid int64) ([]domain.ArticleSchedule, error)
+	CancelSchedule(ctx context.Context, id, uid int64) error
+	// ExecuteDueSchedules 执行所有到期的定时任务，由后台任务周期调用
+	ExecuteDueSchedules(ctx context.Context) error
+
+	// ListPubByAuthor 作者主页上展示的已发表文章，返回下一页的游标，没有下一页时为零值
+	ListPubByAuthor(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, domain.ArticleCursor, error)
+	ListPubByTag(ctx context.Context, tag string, offset, limit int) ([]domain.Article, error)
+	TagCounts(ctx context.Context) ([]domain.TagCount, error)
+
+	// ListPendingReview 等待审核的文章，先提交的排在前面，供管理员使用
+	ListPendingReview(ctx context.Context, offset, limit int) ([]domain.ArticleReview, error)
+	// ApproveReview 审核通过并发表，文章不在等待审核时返回 ErrArticleNotFound；
+	// 按当前词库命中禁止的敏感词时返回 ErrArticleSensitive，只能驳回
+	ApproveReview(ctx context.Context, id int64) error
+	// RejectReview 审核不通过，退回为未发表的草稿
+	RejectReview(ctx context.Context, id int64) error
+}
+
+type publishOptions struct {
+	at time.Time
 }
 
+type PublishOption func(opts *publishOptions)
+
+// PublishAt 在指定时间发表，时间已过则立即发表
+func PublishAt(t time.Time) PublishOption {
+	return func(opts *publishOptions) {
+		opts.at = t
+	}
+}
+
+type withdrawOptions struct {
+	at time.Time
+}
+
+type WithdrawOption func(opts *withdrawOptions)
+
+// WithdrawAt 在指定时间撤回，时间已过则立即撤回
+func WithdrawAt(t time.Time) WithdrawOption {
+	return func(opts *withdrawOptions) {
+		opts.at = t
+	}
+}
+
+const (
+	// scheduleRunningTimeout 任务被抢占后超过该时间仍未结束，允许其他实例重新执行
+	scheduleRunningTimeout = time.Minute * 5
+	// scheduleMaxRetries 执行失败后最多重试的次数，之后任务标记为失败
+	scheduleMaxRetries = 5
+	// scheduleRetryInterval 第一次重试的间隔，之后每次翻倍
+	scheduleRetryInterval = time.Minute
+)
+
 type articleService struct {
-	r      repository.ArticleRepository
-	logger logger.Logger
+	r         repository.ArticleRepository
+	schedRepo repository.ArticleScheduleRepository
+	intrRepo  repository.InteractiveRepository
+	feedSvc   FeedService
+	uploadSvc UploadService
+	matcher   *sensitive.Matcher
+	logger    logger.Logger
+	biz       string
 }
 
-func NewArticleService(r repository.ArticleRepository, logger logger.Logger) ArticleService {
+func NewArticleService(r repository.ArticleRepository, schedRepo repository.ArticleScheduleRepository,
+	intrRepo repository.InteractiveRepository, feedSvc FeedService, uploadSvc UploadService,
+	matcher *sensitive.Matcher, logger logger.Logger) ArticleService {
 	return &articleService{
-		r:      r,
-		logger: logger,
+		r:         r,
+		schedRepo: schedRepo,
+		intrRepo:  intrRepo,
+		feedSvc:   feedSvc,
+		uploadSvc: uploadSvc,
+		matcher:   matcher,
+		logger:    logger,
+		biz:       bizArticle,
 	}
 }
 
 func (a *articleService) Save(ctx context.Context, art domain.Article) (int64, error) {
 	art.Status = domain.ArticleStatusUnpublished
-	if art.Id > 0 {
-		err := a.r.Update(ctx, art)
-		return art.Id, err
+	art.Tags = normalizeTags(art.Tags)
+	art.Category = strings.TrimSpace(art.Category)
+	if art.Id == 0 {
+		id, err := a.r.Create(ctx, art)
+		if err != nil {
+			return 0, err
+		}
+		a.syncUploads(ctx, id, art.Content, false)
+		return id, nil
 	}
-	return a.r.Create(ctx, art)
+	err := a.r.Update(ctx, art)
+	if err != nil {
+		return 0, err
+	}
+	// 定时发表的草稿改回了普通草稿，之前设置的定时发表不再执行
+	a.cancelSchedules(ctx, art.Id, art.Author.Id, domain.ArticleScheduleActionPublish)
+	a.syncUploads(ctx, art.Id, art.Content, false)
+	return art.Id, nil
 }
 
-func (a *articleService) Publish(ctx context.Context, art domain.Article) (int64, error) {
+func (a *articleService) Publish(ctx context.Context, art domain.Article, opts ...PublishOption) (int64, error) {
+	var opt publishOptions
+	for _, o := range opts {
+		o(&opt)
+	}
+	art.Tags = normalizeTags(art.Tags)
+	art.Category = strings.TrimSpace(art.Category)
+	hits := a.moderate(art)
+	if hits.Level == sensitive.LevelBlock {
+		return 0, ErrArticleSensitive
+	}
+	// 定时发表到点时会再检查一次
+	if opt.at.After(time.Now()) {
+		return a.schedulePublish(ctx, art, opt.at)
+	}
+	if hits.Level == sensitive.LevelReview {
+		return a.submitReview(ctx, art, hits.Words)
+	}
+
 	art.Status = domain.ArticleStatusPublished
-	return a.r.Sync(ctx, art)
+	art.Rendered = renderContent(art.Content)
+	id, err := a.r.Sync(ctx, art)
+	if err != nil {
+		return 0, err
+	}
+	// 已经直接发表，之前设置的定时发表不再需要
+	a.cancelSchedules(ctx, id, art.Author.Id, domain.ArticleScheduleActionPublish)
+	a.syncUploads(ctx, id, art.Content, false)
+	a.syncUploads(ctx, id, art.Content, true)
+	art.Id = id
+	a.pushFeed(art)
+	return id, nil
+}
+
+// pushFeed 异步推送到粉丝的关注流，推送失败不影响发表
+func (a *articleService) pushFeed(art domain.Article) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		err := a.feedSvc.PushArticle(ctx, art)
+		if err != nil {
+			a.logger.Error("推送关注流失败",
+				logger.Int64("id", art.Id), logger.Int64("author", art.Author.Id), logger.Error(err))
+		}
+	}()
+}
+
+func (a *articleService) schedulePublish(ctx context.Context, art domain.Article, at time.Time) (int64, error) {
+	// 先保存草稿，到点后再把草稿同步到线上库
+	art.Status = domain.ArticleStatusScheduled
+	var (
+		id  = art.Id
+		err error
+	)
+	if id > 0 {
+		err = a.r.Update(ctx, art)
+	} else {
+		id, err = a.r.Create(ctx, art)
+	}
+	if err != nil {
+		return 0, err
+	}
+	a.syncUploads(ctx, id, art.Content, false)
+	_, err = a.schedRepo.Create(ctx, domain.ArticleSchedule{
+		ArticleId: id,
+		Author:    art.Author,
+		Action:    domain.ArticleScheduleActionPublish,
+		ExecuteAt: at,
+	})
+	return id, err
+}
+
+func (a *articleService) Withdraw(ctx context.Context, id, usrId int64, opts ...WithdrawOption) error {
+	var opt withdrawOptions
+	for _, o := range opts {
+		o(&opt)
+	}
+	if opt.at.After(time.Now()) {
+		// 确认是自己的文章
+		_, err := a.r.GetById(ctx, id, usrId)
+		if err != nil {
+			return err
+		}
+		_, err = a.schedRepo.Create(ctx, domain.ArticleSchedule{
+			ArticleId: id,
+			Author: domain.Author{
+				Id: usrId,
+			},
+			Action:    domain.ArticleScheduleActionWithdraw,
+			ExecuteAt: opt.at,
+		})
+		return err
+	}
+
+	err := a.r.SyncStatus(ctx, id, usrId, domain.ArticleStatusPrivate)
+	if err != nil {
+		return err
+	}
+	a.cancelSchedules(ctx, id, usrId, domain.ArticleScheduleActionWithdraw)
+	return nil
+}
+
+// syncUploads 记录文章引用的图片，失败只记录日志，未被引用的图片要过一段时间才会回收
+func (a *articleService) syncUploads(ctx context.Context, id int64, content string, pub bool) {
+	err := a.uploadSvc.SyncReferences(ctx, id, pub, content)
+	if err != nil {
+		a.logger.Error("记录文章引用的图片失败",
+			logger.Int64("id", id), logger.Error(err))
+	}
+}
+
+func (a *articleService) cancelSchedules(ctx context.Context, id, uid int64, action domain.ArticleScheduleAction) {
+	err := a.schedRepo.CancelByArticle(ctx, id, uid, action)
+	if err != nil {
+		a.logger.Error("取消定时任务失败",
+			logger.Int64("id", id), logger.Int64("author", uid), logger.Error(err))
+	}
+}
+
+// normalizeTags 去掉首尾空白、空标签和重复标签，保持原有顺序；nil 表示不修改标签
+func normalizeTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+	res := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, t := range tags {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		res = append(res, t)
+	}
+	return res
 }
 
-func (a *articleService) Withdraw(ctx context.Context, id, usrId int64) error {
-	return a.r.SyncStatus(ctx, id, usrId, domain.ArticleStatusPrivate)
+func (a *articleService) ListPubByAuthor(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, domain.ArticleCursor, error) {
+	// 多查一篇，用来判断还有没有下一页
+	arts, err := a.r.ListPubByAuthor(ctx, uid, cursor, limit+1)
+	if err != nil {
+		return nil, domain.ArticleCursor{}, err
+	}
+	if len(arts) <= limit {
+		return arts, domain.ArticleCursor{}, nil
+	}
+	arts = arts[:limit]
+	return arts, domain.NewArticleCursor(arts[limit-1]), nil
 }
 
-func (a *articleService) List(ctx context.Context, id int64, offset, limit int) ([]domain.Article, error) {
-	return a.r.List(ctx, id, offset, limit)
+func (a *articleService) ListPubByTag(ctx context.Context, tag string, offset, limit int) ([]domain.Article, error) {
+	return a.r.ListPubByTag(ctx, strings.TrimSpace(tag), offset, limit)
+}
+
+func (a *articleService) TagCounts(ctx context.Context) ([]domain.TagCount, error) {
+	return a.r.TagCounts(ctx)
+}
+
+func (a *articleService) List(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, domain.ArticleCursor, error) {
+	// 多查一篇，用来判断还有没有下一页
+	arts, err := a.r.List(ctx, uid, cursor, limit+1)
+	if err != nil {
+		return nil, domain.ArticleCursor{}, err
+	}
+	if len(arts) <= limit {
+		return arts, domain.ArticleCursor{}, nil
+	}
+	arts = arts[:limit]
+	return arts, domain.NewArticleCursor(arts[limit-1]), nil
 }
 
 func (a *articleService) GetById(ctx context.Context, id, uid int64) (domain.Article, error) {
@@ -55,5 +335,217 @@ func (a *articleService) GetById(ctx context.Context, id, uid int64) (domain.Art
 }
 
 func (a *articleService) GetPubById(ctx context.Context, id int64) (domain.Article, error) {
-	return a.r.GetPubById(ctx, id)
+	art, err := a.r.GetPubById(ctx, id)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	// 上线渲染之前发表的文章没有渲染结果，现场渲染
+	if art.Rendered.HTML == "" && art.Content != "" {
+		art.Rendered = renderContent(art.Content)
+	}
+	return art, nil
+}
+
+// renderContent 把 Markdown 渲染成过滤后的 HTML，同时生成目录和摘要
+func renderContent(content string) domain.RenderedContent {
+	doc := markdown.Render(content)
+	return domain.RenderedContent{
+		HTML: doc.HTML,
+		TOC: slice.Map[markdown.Heading, domain.TOCItem](doc.TOC, func(idx int, src markdown.Heading) domain.TOCItem {
+			return domain.TOCItem{
+				Level:  src.Level,
+				Title:  src.Text,
+				Anchor: src.Anchor,
+			}
+		}),
+		FirstImage: doc.FirstImage,
+		Text:       doc.Text,
+		Abstract:   domain.AbstractOf(doc.Text),
+	}
+}
+
+func (a *articleService) ListRevisions(ctx context.Context, id, uid int64, offset, limit int) ([]domain.ArticleRevision, error) {
+	return a.r.ListRevisions(ctx, id, uid, offset, limit)
+}
+
+func (a *articleService) GetRevision(ctx context.Context, id, rid, uid int64) (domain.ArticleRevision, error) {
+	return a.r.GetRevision(ctx, id, rid, uid)
+}
+
+func (a *articleService) DiffRevisions(ctx context.Context, id, uid, from, to int64) (domain.ArticleRevisionDiff, error) {
+	fromRev, err := a.r.GetRevision(ctx, id, from, uid)
+	if err != nil {
+		return domain.ArticleRevisionDiff{}, err
+	}
+	toRev, err := a.r.GetRevision(ctx, id, to, uid)
+	if err != nil {
+		return domain.ArticleRevisionDiff{}, err
+	}
+	lines := diffx.Lines(fromRev.Content, toRev.Content)
+	return domain.ArticleRevisionDiff{
+		From: fromRev,
+		To:   toRev,
+		Lines: slice.Map[diffx.Line, domain.DiffLine](lines, func(idx int, src diffx.Line) domain.DiffLine {
+			return domain.DiffLine{
+				Op:   domain.DiffOp(src.Op),
+				Text: src.Text,
+			}
+		}),
+	}, nil
+}
+
+func (a *articleService) RestoreRevision(ctx context.Context, id, rid, uid int64) (int64, error) {
+	rev, err := a.r.GetRevision(ctx, id, rid, uid)
+	if err != nil {
+		return 0, err
+	}
+	// 恢复本身也是一次保存，会生成新的历史版本
+	return a.Save(ctx, domain.Article{
+		Id:      id,
+		Title:   rev.Title,
+		Content: rev.Content,
+		Author: domain.Author{
+			Id: uid,
+		},
+	})
+}
+
+func (a *articleService) Delete(ctx context.Context, id, uid int64) error {
+	err := a.r.Delete(ctx, id, uid)
+	if err != nil {
+		return err
+	}
+	a.cancelSchedules(ctx, id, uid, domain.ArticleScheduleActionPublish)
+	a.cancelSchedules(ctx, id, uid, domain.ArticleScheduleActionWithdraw)
+	return nil
+}
+
+func (a *articleService) Restore(ctx context.Context, id, uid int64) error {
+	return a.r.Restore(ctx, id, uid, time.Now().Add(-TrashRetention))
+}
+
+func (a *articleService) ListTrash(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error) {
+	return a.r.ListDeleted(ctx, uid, time.Now().Add(-TrashRetention), offset, limit)
+}
+
+func (a *articleService) PurgeExpired(ctx context.Context) error {
+	const batchSize = 100
+	before := time.Now().Add(-TrashRetention)
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		arts, err := a.r.ListExpired(ctx, before, batchSize)
+		if err != nil {
+			return err
+		}
+		if len(arts) == 0 {
+			return nil
+		}
+		ids := slice.Map[domain.Article, int64](arts, func(idx int, src domain.Article) int64 {
+			return src.Id
+		})
+		// 先删互动数据，失败时文章还在，下一轮还能找到
+		err = a.intrRepo.DeleteByBiz(ctx, a.biz, ids)
+		if err != nil {
+			return err
+		}
+		err = a.uploadSvc.DeleteReferences(ctx, ids)
+		if err != nil {
+			return err
+		}
+		err = a.r.Purge(ctx, ids)
+		if err != nil {
+			return err
+		}
+		if len(arts) < batchSize {
+			return nil
+		}
+	}
+}
+
+func (a *articleService) ListSchedules(ctx context.Context, uid int64) ([]domain.ArticleSchedule, error) {
+	return a.schedRepo.ListPending(ctx, uid)
+}
+
+func (a *articleService) CancelSchedule(ctx context.Context, id, uid int64) error {
+	return a.schedRepo.Cancel(ctx, id, uid)
+}
+
+func (a *articleService) ExecuteDueSchedules(ctx context.Context) error {
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		s, err := a.schedRepo.Preempt(ctx, time.Now(), scheduleRunningTimeout)
+		switch err {
+		case nil:
+		case repository.ErrScheduleNotFound:
+			// 没有到期的任务了
+			return nil
+		default:
+			return err
+		}
+
+		err = a.finishSchedule(ctx, s, a.executeSchedule(ctx, s))
+		switch err {
+		case nil:
+		case repository.ErrScheduleNotOwned:
+			a.logger.Warn("定时任务执行超时，已经被重新抢占", logger.Int64("schedule", s.Id))
+		default:
+			a.logger.Error("更新定时任务状态失败",
+				logger.Int64("schedule", s.Id), logger.Error(err))
+		}
+	}
+}
+
+// finishSchedule 执行失败的任务推迟一段时间再重试，避免一直失败的任务占住队列
+func (a *articleService) finishSchedule(ctx context.Context, s domain.ArticleSchedule, execErr error) error {
+	if execErr == nil {
+		return a.schedRepo.Finish(ctx, s, domain.ArticleScheduleStatusDone)
+	}
+	a.logger.Error("执行定时任务失败",
+		logger.Int64("schedule", s.Id),
+		logger.Int64("id", s.ArticleId),
+		logger.Int64("retries", int64(s.Retries)),
+		logger.Error(execErr))
+	// 文章被删除或者不再处于定时状态，重试也不会成功
+	if execErr == repository.ErrArticleNotFound || s.Retries >= scheduleMaxRetries {
+		return a.schedRepo.Finish(ctx, s, domain.ArticleScheduleStatusFailed)
+	}
+	return a.schedRepo.Retry(ctx, s, time.Now().Add(scheduleRetryInterval<<s.Retries))
+}
+
+func (a *articleService) executeSchedule(ctx context.Context, s domain.ArticleSchedule) error {
+	switch s.Action {
+	case domain.ArticleScheduleActionPublish:
+		// 作者期间把草稿改回了普通草稿，或者已经手动发表，不能再按定时任务发表
+		art, err := a.r.GetByStatus(ctx, s.ArticleId, domain.ArticleStatusScheduled)
+		if err != nil {
+			return err
+		}
+		if art.Author.Id != s.Author.Id {
+			return ErrArticleNotFound
+		}
+		// 作者不在场，命中任何敏感词都交给管理员审核
+		if hits := a.moderate(art); hits.Level != sensitive.LevelNone {
+			a.logger.Info("定时发表的文章需要审核",
+				logger.Int64("id", art.Id), logger.Field{Key: "words", Value: hits.Words})
+			return a.r.TransitStatus(ctx, art.Id, art.Author.Id,
+				domain.ArticleStatusScheduled, domain.ArticleStatusPendingReview)
+		}
+		art.Status = domain.ArticleStatusPublished
+		art.Rendered = renderContent(art.Content)
+		_, err = a.r.Sync(ctx, art)
+		if err != nil {
+			return err
+		}
+		a.syncUploads(ctx, art.Id, art.Content, true)
+		a.pushFeed(art)
+		return nil
+	case domain.ArticleScheduleActionWithdraw:
+		return a.r.SyncStatus(ctx, s.ArticleId, s.Author.Id, domain.ArticleStatusPrivate)
+	default:
+		return fmt.Errorf("未知的定时任务类型 %d", s.Action)
+	}
 }
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	ErrArticleConsistencyNoReport = repository.ErrArticleConsistencyReportNotFound
+	// ErrArticleConsistencyRunning 本实例已经有检查在运行
+	ErrArticleConsistencyRunning = errors.New("一致性检查正在运行")
+)
+
+const (
+	articleConsistencyBatchSize = 200
+	// articleConsistencyMaxRecords 报告里最多保留的不一致文章数，数量照常统计
+	articleConsistencyMaxRecords = 1000
+)
+
+// ArticleConsistencyService 检查线上库和制作库是否一致。
+// MongoDB 和对象存储没有和制作库在同一个事务里写入，中途失败会留下不一致的数据
+type ArticleConsistencyService interface {
+	// Check 全量检查一遍，repair 为 true 时以制作库为准修复，报告保存为最近一次的结果
+	Check(ctx context.Context, repair bool) (domain.ArticleConsistencyReport, error)
+	// StartCheck 在后台全量检查
+	StartCheck(ctx context.Context, repair bool) error
+	// LatestReport 还没有检查过时返回 ErrArticleConsistencyNoReport
+	LatestReport(ctx context.Context) (domain.ArticleConsistencyReport, error)
+}
+
+type articleConsistencyService struct {
+	artRepo    repository.ArticleRepository
+	reportRepo repository.ArticleConsistencyRepository
+	running    atomic.Bool
+	l          logger.Logger
+
+	drifts   *prometheus.GaugeVec
+	repaired *prometheus.CounterVec
+	scanned  prometheus.Gauge
+	lastRun  prometheus.Gauge
+}
+
+func NewArticleConsistencyService(artRepo repository.ArticleRepository,
+	reportRepo repository.ArticleConsistencyRepository, l logger.Logger) ArticleConsistencyService {
+	const (
+		namespace = "john_server"
+		subsystem = "webook"
+	)
+	svc := &articleConsistencyService{
+		artRepo:    artRepo,
+		reportRepo: reportRepo,
+		l:          l,
+		drifts: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "article_consistency_drifts",
+			Help:      "最近一次一致性检查中各类不一致的文章数",
+		}, []string{"drift"}),
+		repaired: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "article_consistency_repaired_total",
+			Help:      "一致性检查修复的文章数",
+		}, []string{"drift"}),
+		scanned: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "article_consistency_scanned",
+			Help:      "最近一次一致性检查的文章数",
+		}),
+		lastRun: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "article_consistency_last_run_timestamp_seconds",
+			Help:      "最近一次完整的一致性检查结束的时间",
+		}),
+	}
+	prometheus.MustRegister(svc.drifts, svc.repaired, svc.scanned, svc.lastRun)
+	return svc
+}
+
+func (a *articleConsistencyService) Check(ctx context.Context, repair bool) (domain.ArticleConsistencyReport, error) {
+	if !a.running.CompareAndSwap(false, true) {
+		return domain.ArticleConsistencyReport{}, ErrArticleConsistencyRunning
+	}
+	defer a.running.Store(false)
+	return a.check(ctx, repair)
+}
+
+func (a *articleConsistencyService) StartCheck(ctx context.Context, repair bool) error {
+	if !a.running.CompareAndSwap(false, true) {
+		return ErrArticleConsistencyRunning
+	}
+	// 全量检查耗时很长，不受请求的超时控制，结果看报告
+	go func() {
+		defer a.running.Store(false)
+		_, err := a.check(context.Background(), repair)
+		if err != nil {
+			a.l.Error("一致性检查失败", logger.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (a *articleConsistencyService) LatestReport(ctx context.Context) (domain.ArticleConsistencyReport, error) {
+	return a.reportRepo.LatestReport(ctx)
+}
+
+// check 中途失败时也保存已经检查的部分，但不更新指标，避免把没查完的结果当成全量
+func (a *articleConsistencyService) check(ctx context.Context, repair bool) (domain.ArticleConsistencyReport, error) {
+	report := domain.ArticleConsistencyReport{
+		Start:  time.Now(),
+		Repair: repair,
+		Counts: make(map[domain.ArticleDrift]int, len(domain.ArticleDrifts)),
+	}
+	var startId int64
+	var err error
+	for {
+		var pairs []domain.ArticlePair
+		pairs, err = a.artRepo.ScanPairs(ctx, startId, articleConsistencyBatchSize)
+		if err != nil || len(pairs) == 0 {
+			break
+		}
+		for _, p := range pairs {
+			a.checkPair(ctx, p, repair, &report)
+		}
+		report.Scanned += len(pairs)
+		startId = pairs[len(pairs)-1].Id
+	}
+	report.End = time.Now()
+	if err != nil {
+		report.Error = err.Error()
+	} else {
+		a.report(report)
+	}
+	if er := a.reportRepo.SaveReport(ctx, report); er != nil {
+		a.l.Error("保存一致性检查报告失败", logger.Error(er))
+	}
+	return report, err
+}
+
+func (a *articleConsistencyService) checkPair(ctx context.Context, p domain.ArticlePair, repair bool,
+	report *domain.ArticleConsistencyReport) {
+	drifts := p.Drifts()
+	if len(drifts) == 0 {
+		return
+	}
+	report.Drifted++
+	for _, d := range drifts {
+		report.Counts[d]++
+	}
+	record := domain.ArticleDriftRecord{
+		ArticleId: p.Id,
+		Drifts:    drifts,
+	}
+	if p.Draft != nil {
+		record.AuthorId = p.Draft.Author.Id
+		record.DraftStatus = p.Draft.Status
+		record.DraftHash = domain.ContentHash(p.Draft.Content)
+	}
+	if p.Pub != nil {
+		record.AuthorId = p.Pub.Author.Id
+		record.PubStatus = p.Pub.Status
+		if p.PubContentStored {
+			record.PubHash = domain.ContentHash(p.Pub.Content)
+		}
+	}
+
+	if repair {
+		a.repair(ctx, p, &record)
+		if record.Repaired {
+			report.Repaired++
+			for _, d := range drifts {
+				a.repaired.WithLabelValues(string(d)).Inc()
+			}
+		}
+	}
+	if len(report.Records) >= articleConsistencyMaxRecords {
+		report.Truncated = true
+		return
+	}
+	report.Records = append(report.Records, record)
+}
+
+func (a *articleConsistencyService) repair(ctx context.Context, p domain.ArticlePair, record *domain.ArticleDriftRecord) {
+	if !p.Repairable() {
+		record.Error = "草稿正在修改，线上内容无法从制作库恢复，需要作者重新发表"
+		return
+	}
+	var rendered domain.RenderedContent
+	if p.Draft != nil && p.Draft.Dtime.IsZero() && p.Draft.Status.Synced() {
+		rendered = renderContent(p.Draft.Content)
+	}
+	err := a.artRepo.RepairPub(ctx, p, rendered)
+	switch err {
+	case nil:
+		record.Repaired = true
+		a.l.Warn("修复了不一致的文章", logger.Int64("id", p.Id),
+			logger.Field{Key: "drifts", Value: record.Drifts})
+	case repository.ErrArticleVersionConflict:
+		record.Error = "检查之后作者修改了草稿，下次检查时再处理"
+	default:
+		record.Error = err.Error()
+		a.l.Error("修复不一致的文章失败", logger.Int64("id", p.Id), logger.Error(err))
+	}
+}
+
+// report 更新指标，没有出现的类型清零
+func (a *articleConsistencyService) report(r domain.ArticleConsistencyReport) {
+	for _, d := range domain.ArticleDrifts {
+		a.drifts.WithLabelValues(string(d)).Set(float64(r.Counts[d]))
+	}
+	a.scanned.Set(float64(r.Scanned))
+	a.lastRun.Set(float64(r.End.Unix()))
+	if r.Drifted > 0 {
+		a.l.Warn("线上库和制作库不一致", logger.Int64("count", int64(r.Drifted)),
+			logger.Int64("repaired", int64(r.Repaired)))
+	}
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	ErrArticleMigrationDisabled = repository.ErrArticleMigrationDisabled
+	// ErrArticleMigrationStageSkip 阶段只能逐级前进或回退，避免跳过双写
+	ErrArticleMigrationStageSkip = errors.New("只能切换到相邻的迁移阶段")
+	// ErrArticleMigrationBadStage 目标存储已经是主存储时不能再全量复制
+	ErrArticleMigrationBadStage = errors.New("当前阶段不能执行该操作")
+	// ErrArticleMigrationRunning 本实例已经有全量复制或校验在运行
+	ErrArticleMigrationRunning = errors.New("迁移任务正在运行")
+)
+
+const (
+	articleMigrationBatchSize = 100
+	// articleVerifyOverlap 增量校验的时间窗口往前多取一点，覆盖正在进行的写入
+	articleVerifyOverlap = time.Minute
+	// articleVerifyInitWindow 实例启动后第一次增量校验的时间窗口
+	articleVerifyInitWindow = time.Minute * 10
+)
+
+// ArticleMigrationService 文章存储在线迁移。一般的步骤是：
+// 切换到 src_first 开始双写，全量复制，全量校验，
+// 切换到 dst_first 以目标存储为准，观察没有问题后切换到 dst_only
+type ArticleMigrationService interface {
+	Stage(ctx context.Context) (domain.ArticleMigrationStage, error)
+	// SetStage 只能切换到相邻的阶段，所有实例在 SyncStage 后生效
+	SetStage(ctx context.Context, stage domain.ArticleMigrationStage) error
+	// SyncStage 由后台任务周期调用，同步其他实例设置的阶段
+	SyncStage(ctx context.Context) error
+	// StartBackfill 在后台把源存储全量复制到目标存储，只能在源存储为主时执行
+	StartBackfill(ctx context.Context) error
+	// StartVerify 在后台全量校验并修复
+	StartVerify(ctx context.Context) error
+	// VerifyIncremental 校验上次校验之后修改过的文章，由后台任务周期调用，只在双写阶段执行
+	VerifyIncremental(ctx context.Context) error
+}
+
+type articleMigrationService struct {
+	r repository.ArticleMigrationRepository
+	// running 本实例是否有全量复制或校验在后台运行
+	running atomic.Bool
+	// verifiedAt 上次增量校验开始的时间
+	verifiedAt atomic.Int64
+	l          logger.Logger
+}
+
+func NewArticleMigrationService(r repository.ArticleMigrationRepository, l logger.Logger) ArticleMigrationService {
+	return &articleMigrationService{
+		r: r,
+		l: l,
+	}
+}
+
+func (a *articleMigrationService) Stage(ctx context.Context) (domain.ArticleMigrationStage, error) {
+	return a.r.Stage(ctx)
+}
+
+func (a *articleMigrationService) SetStage(ctx context.Context, stage domain.ArticleMigrationStage) error {
+	cur, err := a.r.Stage(ctx)
+	if err != nil {
+		return err
+	}
+	if stage > cur+1 || cur > stage+1 {
+		return ErrArticleMigrationStageSkip
+	}
+	return a.r.SetStage(ctx, stage)
+}
+
+func (a *articleMigrationService) SyncStage(ctx context.Context) error {
+	err := a.r.SyncStage(ctx)
+	if err == repository.ErrArticleMigrationDisabled {
+		return nil
+	}
+	return err
+}
+
+func (a *articleMigrationService) StartBackfill(ctx context.Context) error {
+	stage, err := a.r.Stage(ctx)
+	if err != nil {
+		return err
+	}
+	if !stage.SrcPrimary() {
+		return ErrArticleMigrationBadStage
+	}
+	return a.runInBackground("全量复制", func(ctx context.Context) (int, error) {
+		var (
+			startId int64
+			cnt     int
+		)
+		for {
+			lastId, n, done, err := a.r.Backfill(ctx, startId, articleMigrationBatchSize)
+			cnt += n
+			if err != nil {
+				return cnt, err
+			}
+			startId = lastId
+			if done {
+				return cnt, nil
+			}
+			a.l.Info("全量复制进度", logger.Int64("id", startId))
+		}
+	})
+}
+
+func (a *articleMigrationService) StartVerify(ctx context.Context) error {
+	if _, err := a.r.Stage(ctx); err != nil {
+		return err
+	}
+	return a.runInBackground("全量校验", func(ctx context.Context) (int, error) {
+		return a.verify(ctx, time.Time{})
+	})
+}
+
+func (a *articleMigrationService) VerifyIncremental(ctx context.Context) error {
+	stage, err := a.r.Stage(ctx)
+	if err == repository.ErrArticleMigrationDisabled || err == nil && !stage.DoubleWrite() {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	since := now.Add(-articleVerifyInitWindow)
+	if last := a.verifiedAt.Load(); last > 0 {
+		since = time.UnixMilli(last).Add(-articleVerifyOverlap)
+	}
+	repaired, err := a.verify(ctx, since)
+	if err != nil {
+		return err
+	}
+	if repaired > 0 {
+		a.l.Warn("增量校验修复了不一致的文章", logger.Int64("count", int64(repaired)))
+	}
+	a.verifiedAt.Store(now.UnixMilli())
+	return nil
+}
+
+// verify 校验 since 之后修改过的文章，返回修复的数量
+func (a *articleMigrationService) verify(ctx context.Context, since time.Time) (int, error) {
+	var (
+		startId  int64
+		repaired int
+	)
+	for {
+		lastId, n, done, err := a.r.Verify(ctx, startId, since, articleMigrationBatchSize)
+		repaired += n
+		if err != nil || done {
+			return repaired, err
+		}
+		startId = lastId
+	}
+}
+
+// runInBackground 全量任务耗时很长，不受请求的超时控制，结果只记录日志
+func (a *articleMigrationService) runInBackground(name string, fn func(ctx context.Context) (int, error)) error {
+	if !a.running.CompareAndSwap(false, true) {
+		return ErrArticleMigrationRunning
+	}
+	go func() {
+		defer a.running.Store(false)
+		start := time.Now()
+		a.l.Info("开始" + name)
+		n, err := fn(context.Background())
+		if err != nil {
+			a.l.Error(name+"失败", logger.Int64("count", int64(n)), logger.Error(err))
+			return
+		}
+		a.l.Info(name+"完成", logger.Int64("count", int64(n)),
+			logger.String("cost", time.Since(start).String()))
+	}()
+	return nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/johnwongx/webook/backend/internal/repository"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"sync/atomic"
+	"time"
+)
+
+// ErrArticleReplayRunning 本实例已经在重放
+var ErrArticleReplayRunning = errors.New("读者侧正在重放")
+
+const articleReplayBatchSize = 100
+
+// ArticleReaderService 维护读者侧的读模型。读者侧丢了数据或者换了库时，
+// 用作者侧的线上库整体重放一遍
+type ArticleReaderService interface {
+	// Replay 用作者侧重建读者侧，返回写入的文章数
+	Replay(ctx context.Context) (int, error)
+	// StartReplay 在后台重放，结果只记录日志
+	StartReplay(ctx context.Context) error
+}
+
+type articleReaderService struct {
+	r       repository.ReaderArticleRepository
+	running atomic.Bool
+	l       logger.Logger
+}
+
+func NewArticleReaderService(r repository.ReaderArticleRepository, l logger.Logger) ArticleReaderService {
+	return &articleReaderService{
+		r: r,
+		l: l,
+	}
+}
+
+func (a *articleReaderService) Replay(ctx context.Context) (int, error) {
+	if !a.running.CompareAndSwap(false, true) {
+		return 0, ErrArticleReplayRunning
+	}
+	defer a.running.Store(false)
+	return a.replay(ctx)
+}
+
+func (a *articleReaderService) StartReplay(ctx context.Context) error {
+	if !a.running.CompareAndSwap(false, true) {
+		return ErrArticleReplayRunning
+	}
+	go func() {
+		defer a.running.Store(false)
+		start := time.Now()
+		n, err := a.replay(context.Background())
+		if err != nil {
+			a.l.Error("重放读者侧失败", logger.Int64("count", int64(n)), logger.Error(err))
+			return
+		}
+		a.l.Info("重放读者侧完成", logger.Int64("count", int64(n)),
+			logger.String("cost", time.Since(start).String()))
+	}()
+	return nil
+}
+
+func (a *articleReaderService) replay(ctx context.Context) (int, error) {
+	var (
+		startId int64
+		cnt     int
+	)
+	for {
+		lastId, n, done, err := a.r.Replay(ctx, startId, articleReplayBatchSize)
+		cnt += n
+		if err != nil || done {
+			return cnt, err
+		}
+		startId = lastId
+		a.l.Info("重放读者侧进度", logger.Int64("id", startId))
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/johnwongx/webook/backend/pkg/sensitive"
+)
+
+// moderate 标题和正文分别匹配，取最严重的级别
+func (a *articleService) moderate(art domain.Article) sensitive.Result {
+	res := a.matcher.Match(art.Title)
+	content := a.matcher.Match(art.Content)
+	if content.Level > res.Level {
+		res.Level = content.Level
+	}
+	for _, w := range content.Words {
+		if !slice.Contains[string](res.Words, w) {
+			res.Words = append(res.Words, w)
+		}
+	}
+	return res
+}
+
+// submitReview 保存为等待审核的草稿，线上的旧版本不受影响
+func (a *articleService) submitReview(ctx context.Context, art domain.Article, words []string) (int64, error) {
+	art.Status = domain.ArticleStatusPendingReview
+	var err error
+	if art.Id > 0 {
+		err = a.r.Update(ctx, art)
+	} else {
+		art.Id, err = a.r.Create(ctx, art)
+	}
+	if err != nil {
+		return 0, err
+	}
+	a.syncUploads(ctx, art.Id, art.Content, false)
+	a.logger.Info("文章需要审核",
+		logger.Int64("id", art.Id), logger.Field{Key: "words", Value: words})
+	return art.Id, ErrArticlePendingReview
+}
+
+func (a *articleService) ListPendingReview(ctx context.Context, offset, limit int) ([]domain.ArticleReview, error) {
+	arts, err := a.r.ListByStatus(ctx, domain.ArticleStatusPendingReview, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	// 词库可能已经更新，按当前词库重新匹配
+	return slice.Map[domain.Article, domain.ArticleReview](arts, func(idx int, src domain.Article) domain.ArticleReview {
+		return domain.ArticleReview{
+			Article: src,
+			Words:   a.moderate(src).Words,
+		}
+	}), nil
+}
+
+func (a *articleService) ApproveReview(ctx context.Context, id int64) error {
+	art, err := a.r.GetByStatus(ctx, id, domain.ArticleStatusPendingReview)
+	if err != nil {
+		return err
+	}
+	// 提交审核之后词库可能更新了，禁止发表的内容不能因为审核通过而上线
+	if a.moderate(art).Level == sensitive.LevelBlock {
+		return ErrArticleSensitive
+	}
+	// 带上版本号，审核期间作者修改过草稿时返回 ErrArticleVersionConflict
+	art.Status = domain.ArticleStatusPublished
+	art.Rendered = renderContent(art.Content)
+	_, err = a.r.Sync(ctx, art)
+	if err != nil {
+		return err
+	}
+	a.syncUploads(ctx, id, art.Content, true)
+	a.pushFeed(art)
+	return nil
+}
+
+func (a *articleService) RejectReview(ctx context.Context, id int64) error {
+	art, err := a.r.GetByStatus(ctx, id, domain.ArticleStatusPendingReview)
+	if err != nil {
+		return err
+	}
+	return a.r.TransitStatus(ctx, id, art.Author.Id,
+		domain.ArticleStatusPendingReview, domain.ArticleStatusUnpublished)
+}
@@ -1,4 +1,4 @@
-package service
+package service_test
 
 import (
 	"context"
@@ -7,41 +7,50 @@ import (
 	"github.com/johnwongx/webook/backend/internal/domain"
 	"github.com/johnwongx/webook/backend/internal/repository"
 	repomocks "github.com/johnwongx/webook/backend/internal/repository/mocks"
+	"github.com/johnwongx/webook/backend/internal/service"
+	svcmocks "github.com/johnwongx/webook/backend/internal/service/mocks"
 	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/johnwongx/webook/backend/pkg/sensitive"
 	"go.uber.org/mock/gomock"
+	"strings"
 	"testing"
+	"time"
 )
 
 func Test_articleService_Publish(t *testing.T) {
 	testCases := []struct {
-		name    string
-		mock    func(ctrl *gomock.Controller) (repository.AuthorArticleRepository, repository.ReaderArticleRepository)
+		name string
+		// mock 返回的 channel 在异步推送关注流后关闭，不推送时为 nil
+		mock    func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleScheduleRepository, service.FeedService, service.UploadService, chan struct{})
 		art     domain.Article
+		opts    []service.PublishOption
 		wantId  int64
 		wantErr error
 	}{
 		{
 			name: "直接发表成功",
-			mock: func(ctrl *gomock.Controller) (repository.AuthorArticleRepository, repository.ReaderArticleRepository) {
-				authorRepo := repomocks.NewMockAuthorArticleRepository(ctrl)
-				authorRepo.EXPECT().Create(gomock.Any(), domain.Article{
-					Title:   "tittle",
-					Content: "content",
-					Author: domain.Author{
-						Id: 123,
-					},
-				}).Return(int64(1), nil)
-
-				readerRepo := repomocks.NewMockReaderArticleRepository(ctrl)
-				readerRepo.EXPECT().Save(gomock.Any(), domain.Article{
-					Id:      1,
-					Title:   "tittle",
-					Content: "content",
-					Author: domain.Author{
-						Id: 123,
-					},
-				}).Return(nil)
-				return authorRepo, readerRepo
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleScheduleRepository, service.FeedService, service.UploadService, chan struct{}) {
+				r := repomocks.NewMockArticleRepository(ctrl)
+				r.EXPECT().Sync(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, art domain.Article) (int64, error) {
+						if art.Status != domain.ArticleStatusPublished || art.Rendered.Text != "content" {
+							return 0, errors.New("发表的文章不对")
+						}
+						return 1, nil
+					})
+				sr := repomocks.NewMockArticleScheduleRepository(ctrl)
+				sr.EXPECT().CancelByArticle(gomock.Any(), int64(1), int64(123), domain.ArticleScheduleActionPublish).Return(nil)
+				us := svcmocks.NewMockUploadService(ctrl)
+				us.EXPECT().SyncReferences(gomock.Any(), int64(1), false, "content").Return(nil)
+				us.EXPECT().SyncReferences(gomock.Any(), int64(1), true, "content").Return(nil)
+				done := make(chan struct{})
+				fs := svcmocks.NewMockFeedService(ctrl)
+				fs.EXPECT().PushArticle(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, art domain.Article) error {
+						close(done)
+						return nil
+					})
+				return r, sr, fs, us, done
 			},
 			art: domain.Article{
 				Title:   "tittle",
@@ -50,57 +59,107 @@ func Test_articleService_Publish(t *testing.T) {
 					Id: 123,
 				},
 			},
-			wantId:  1,
-			wantErr: nil,
+			wantId: 1,
 		},
 		{
-			name: "草稿箱已存在，未发表",
-			mock: func(ctrl *gomock.Controller) (repository.AuthorArticleRepository, repository.ReaderArticleRepository) {
-				authorRepo := repomocks.NewMockAuthorArticleRepository(ctrl)
-				authorRepo.EXPECT().Update(gomock.Any(), domain.Article{
-					Id:      2,
-					Title:   "tittle",
-					Content: "content",
-					Author: domain.Author{
-						Id: 123,
-					},
-				}).Return(nil)
-
-				readerRepo := repomocks.NewMockReaderArticleRepository(ctrl)
-				readerRepo.EXPECT().Save(gomock.Any(), domain.Article{
+			name: "发表时生成摘要，去掉 Markdown 标记",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleScheduleRepository, service.FeedService, service.UploadService, chan struct{}) {
+				r := repomocks.NewMockArticleRepository(ctrl)
+				r.EXPECT().Sync(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, art domain.Article) (int64, error) {
+						if art.Rendered.Abstract != "加粗"+strings.Repeat("字", 98) {
+							return 0, errors.New("摘要不对")
+						}
+						return 1, nil
+					})
+				sr := repomocks.NewMockArticleScheduleRepository(ctrl)
+				sr.EXPECT().CancelByArticle(gomock.Any(), int64(1), int64(123), domain.ArticleScheduleActionPublish).Return(nil)
+				us := svcmocks.NewMockUploadService(ctrl)
+				us.EXPECT().SyncReferences(gomock.Any(), int64(1), false, gomock.Any()).Return(nil)
+				us.EXPECT().SyncReferences(gomock.Any(), int64(1), true, gomock.Any()).Return(nil)
+				done := make(chan struct{})
+				fs := svcmocks.NewMockFeedService(ctrl)
+				fs.EXPECT().PushArticle(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, art domain.Article) error {
+						close(done)
+						return nil
+					})
+				return r, sr, fs, us, done
+			},
+			art: domain.Article{
+				Title:   "tittle",
+				Content: "**加粗**" + strings.Repeat("字", 120),
+				Author: domain.Author{
+					Id: 123,
+				},
+			},
+			wantId: 1,
+		},
+		{
+			name: "命中屏蔽词",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleScheduleRepository, service.FeedService, service.UploadService, chan struct{}) {
+				return repomocks.NewMockArticleRepository(ctrl), repomocks.NewMockArticleScheduleRepository(ctrl),
+					svcmocks.NewMockFeedService(ctrl), svcmocks.NewMockUploadService(ctrl), nil
+			},
+			art: domain.Article{
+				Title:   "tittle",
+				Content: "blocked content",
+				Author: domain.Author{
+					Id: 123,
+				},
+			},
+			wantErr: service.ErrArticleSensitive,
+		},
+		{
+			name: "命中审核词，保存为等待审核的草稿",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleScheduleRepository, service.FeedService, service.UploadService, chan struct{}) {
+				r := repomocks.NewMockArticleRepository(ctrl)
+				r.EXPECT().Update(gomock.Any(), domain.Article{
 					Id:      2,
 					Title:   "tittle",
-					Content: "content",
+					Content: "review content",
 					Author: domain.Author{
 						Id: 123,
 					},
+					Status: domain.ArticleStatusPendingReview,
 				}).Return(nil)
-				return authorRepo, readerRepo
+				us := svcmocks.NewMockUploadService(ctrl)
+				us.EXPECT().SyncReferences(gomock.Any(), int64(2), false, "review content").Return(nil)
+				return r, repomocks.NewMockArticleScheduleRepository(ctrl), svcmocks.NewMockFeedService(ctrl), us, nil
 			},
 			art: domain.Article{
 				Id:      2,
 				Title:   "tittle",
-				Content: "content",
+				Content: "review content",
 				Author: domain.Author{
 					Id: 123,
 				},
 			},
-			wantId:  2,
-			wantErr: nil,
+			wantErr: service.ErrArticlePendingReview,
 		},
 		{
-			name: "草稿箱更新失败",
-			mock: func(ctrl *gomock.Controller) (repository.AuthorArticleRepository, repository.ReaderArticleRepository) {
-				authorRepo := repomocks.NewMockAuthorArticleRepository(ctrl)
-				authorRepo.EXPECT().Create(gomock.Any(), domain.Article{
+			name: "定时发表，先保存草稿",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleScheduleRepository, service.FeedService, service.UploadService, chan struct{}) {
+				r := repomocks.NewMockArticleRepository(ctrl)
+				r.EXPECT().Create(gomock.Any(), domain.Article{
 					Title:   "tittle",
 					Content: "content",
 					Author: domain.Author{
 						Id: 123,
 					},
-				}).Return(int64(0), errors.New("update failed!"))
-
-				return authorRepo, nil
+					Status: domain.ArticleStatusScheduled,
+				}).Return(int64(3), nil)
+				sr := repomocks.NewMockArticleScheduleRepository(ctrl)
+				sr.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, s domain.ArticleSchedule) (int64, error) {
+						if s.ArticleId != 3 || s.Action != domain.ArticleScheduleActionPublish {
+							return 0, errors.New("定时任务不对")
+						}
+						return 1, nil
+					})
+				us := svcmocks.NewMockUploadService(ctrl)
+				us.EXPECT().SyncReferences(gomock.Any(), int64(3), false, "content").Return(nil)
+				return r, sr, svcmocks.NewMockFeedService(ctrl), us, nil
 			},
 			art: domain.Article{
 				Title:   "tittle",
@@ -109,30 +168,16 @@ func Test_articleService_Publish(t *testing.T) {
 					Id: 123,
 				},
 			},
-			wantErr: errors.New("update failed!"),
+			opts:   []service.PublishOption{service.PublishAt(time.Now().Add(time.Hour))},
+			wantId: 3,
 		},
 		{
-			name: "线上库重试后成功",
-			mock: func(ctrl *gomock.Controller) (repository.AuthorArticleRepository, repository.ReaderArticleRepository) {
-				authorRepo := repomocks.NewMockAuthorArticleRepository(ctrl)
-				authorRepo.EXPECT().Create(gomock.Any(), domain.Article{
-					Title:   "tittle",
-					Content: "content",
-					Author: domain.Author{
-						Id: 123,
-					},
-				}).Return(int64(4), nil)
-
-				readerRepo := repomocks.NewMockReaderArticleRepository(ctrl)
-				readerRepo.EXPECT().Save(gomock.Any(), domain.Article{
-					Id:      4,
-					Title:   "tittle",
-					Content: "content",
-					Author: domain.Author{
-						Id: 123,
-					},
-				}).Return(errors.New("update failed!")).Return(nil)
-				return authorRepo, readerRepo
+			name: "同步到线上库失败",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleScheduleRepository, service.FeedService, service.UploadService, chan struct{}) {
+				r := repomocks.NewMockArticleRepository(ctrl)
+				r.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("sync failed!"))
+				return r, repomocks.NewMockArticleScheduleRepository(ctrl),
+					svcmocks.NewMockFeedService(ctrl), svcmocks.NewMockUploadService(ctrl), nil
 			},
 			art: domain.Article{
 				Title:   "tittle",
@@ -141,54 +186,287 @@ func Test_articleService_Publish(t *testing.T) {
 					Id: 123,
 				},
 			},
-			wantId:  4,
-			wantErr: nil,
+			wantErr: errors.New("sync failed!"),
 		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			r, sr, fs, us, done := tc.mock(ctrl)
+			svc := service.NewArticleService(r, sr, nil, fs, us, newTestMatcher(), &logger.NopLogger{})
+			id, err := svc.Publish(context.Background(), tc.art, tc.opts...)
+			if done != nil {
+				select {
+				case <-done:
+				case <-time.After(time.Second):
+					t.Fatal("没有推送关注流")
+				}
+			}
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
+
+// newTestMatcher 命中 blocked 拒绝发表，命中 review 进入审核
+func newTestMatcher() *sensitive.Matcher {
+	m := sensitive.NewMatcher()
+	m.Load(map[string]sensitive.Level{
+		"blocked": sensitive.LevelBlock,
+		"review":  sensitive.LevelReview,
+	})
+	return m
+}
+
+func Test_articleService_ExecuteDueSchedules(t *testing.T) {
+	s := domain.ArticleSchedule{
+		Id:        1,
+		ArticleId: 2,
+		Author: domain.Author{
+			Id: 123,
+		},
+		Action: domain.ArticleScheduleActionPublish,
+	}
+	testCases := []struct {
+		name string
+		// mock 返回的 channel 在异步推送关注流后关闭，不推送时为 nil
+		mock func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleScheduleRepository, service.FeedService, service.UploadService, chan struct{})
+	}{
 		{
-			name: "线上库重试后失败",
-			mock: func(ctrl *gomock.Controller) (repository.AuthorArticleRepository, repository.ReaderArticleRepository) {
-				authorRepo := repomocks.NewMockAuthorArticleRepository(ctrl)
-				authorRepo.EXPECT().Create(gomock.Any(), domain.Article{
+			name: "到点发表",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleScheduleRepository, service.FeedService, service.UploadService, chan struct{}) {
+				sr := repomocks.NewMockArticleScheduleRepository(ctrl)
+				sr.EXPECT().Preempt(gomock.Any(), gomock.Any(), gomock.Any()).Return(s, nil)
+				sr.EXPECT().Finish(gomock.Any(), s, domain.ArticleScheduleStatusDone).Return(nil)
+				sr.EXPECT().Preempt(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(domain.ArticleSchedule{}, repository.ErrScheduleNotFound)
+				art := domain.Article{
+					Id:      2,
 					Title:   "tittle",
 					Content: "content",
 					Author: domain.Author{
 						Id: 123,
 					},
-				}).Return(int64(4), nil)
+					Status: domain.ArticleStatusScheduled,
+				}
+				r := repomocks.NewMockArticleRepository(ctrl)
+				r.EXPECT().GetByStatus(gomock.Any(), int64(2), domain.ArticleStatusScheduled).Return(art, nil)
+				r.EXPECT().Sync(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, art domain.Article) (int64, error) {
+						if art.Status != domain.ArticleStatusPublished {
+							return 0, errors.New("发表的文章不对")
+						}
+						return 2, nil
+					})
+				us := svcmocks.NewMockUploadService(ctrl)
+				us.EXPECT().SyncReferences(gomock.Any(), int64(2), true, "content").Return(nil)
+				done := make(chan struct{})
+				fs := svcmocks.NewMockFeedService(ctrl)
+				fs.EXPECT().PushArticle(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, art domain.Article) error {
+						close(done)
+						return nil
+					})
+				return r, sr, fs, us, done
+			},
+		},
+		{
+			name: "作者改回了普通草稿，不再发表",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, repository.ArticleScheduleRepository, service.FeedService, service.UploadService, chan struct{}) {
+				sr := repomocks.NewMockArticleScheduleRepository(ctrl)
+				sr.EXPECT().Preempt(gomock.Any(), gomock.Any(), gomock.Any()).Return(s, nil)
+				sr.EXPECT().Finish(gomock.Any(), s, domain.ArticleScheduleStatusFailed).Return(nil)
+				sr.EXPECT().Preempt(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(domain.ArticleSchedule{}, repository.ErrScheduleNotFound)
+				r := repomocks.NewMockArticleRepository(ctrl)
+				r.EXPECT().GetByStatus(gomock.Any(), int64(2), domain.ArticleStatusScheduled).
+					Return(domain.Article{}, repository.ErrArticleNotFound)
+				return r, sr, svcmocks.NewMockFeedService(ctrl), svcmocks.NewMockUploadService(ctrl), nil
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			r, sr, fs, us, done := tc.mock(ctrl)
+			svc := service.NewArticleService(r, sr, nil, fs, us, newTestMatcher(), &logger.NopLogger{})
+			err := svc.ExecuteDueSchedules(context.Background())
+			if done != nil {
+				select {
+				case <-done:
+				case <-time.After(time.Second):
+					t.Fatal("没有推送关注流")
+				}
+			}
+			assert.Equal(t, nil, err)
+		})
+	}
+}
+
+func Test_articleService_Save(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	art := domain.Article{
+		Id:      2,
+		Title:   "tittle",
+		Content: "content",
+		Author: domain.Author{
+			Id: 123,
+		},
+	}
+	r := repomocks.NewMockArticleRepository(ctrl)
+	r.EXPECT().Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, art domain.Article) error {
+			if art.Status != domain.ArticleStatusUnpublished {
+				return errors.New("保存的状态不对")
+			}
+			return nil
+		})
+	// 编辑定时发表的草稿，改回普通草稿，定时发表随之取消
+	sr := repomocks.NewMockArticleScheduleRepository(ctrl)
+	sr.EXPECT().CancelByArticle(gomock.Any(), int64(2), int64(123), domain.ArticleScheduleActionPublish).Return(nil)
+	us := svcmocks.NewMockUploadService(ctrl)
+	us.EXPECT().SyncReferences(gomock.Any(), int64(2), false, "content").Return(nil)
+	svc := service.NewArticleService(r, sr, nil, svcmocks.NewMockFeedService(ctrl), us,
+		newTestMatcher(), &logger.NopLogger{})
+	id, err := svc.Save(context.Background(), art)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(2), id)
+}
+
+func Test_articleService_ListPubByAuthor(t *testing.T) {
+	now := time.UnixMilli(1000)
+	testCases := []struct {
+		name     string
+		arts     []domain.Article
+		wantIds  []int64
+		wantNext domain.ArticleCursor
+	}{
+		{
+			name:     "还有下一页",
+			arts:     []domain.Article{{Id: 3, Utime: now}, {Id: 2, Utime: now}, {Id: 1, Utime: now}},
+			wantIds:  []int64{3, 2},
+			wantNext: domain.ArticleCursor{Utime: now, Id: 2},
+		},
+		{
+			name:    "最后一页",
+			arts:    []domain.Article{{Id: 1, Utime: now}},
+			wantIds: []int64{1},
+		},
+	}
 
-				readerRepo := repomocks.NewMockReaderArticleRepository(ctrl)
-				readerRepo.EXPECT().Save(gomock.Any(), domain.Article{
-					Id:      4,
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			r := repomocks.NewMockArticleRepository(ctrl)
+			// 多查一篇判断有没有下一页
+			r.EXPECT().ListPubByAuthor(gomock.Any(), int64(123), domain.ArticleCursor{}, 3).Return(tc.arts, nil)
+			svc := service.NewArticleService(r, nil, nil, nil, nil, newTestMatcher(), &logger.NopLogger{})
+			arts, next, err := svc.ListPubByAuthor(context.Background(), 123, domain.ArticleCursor{}, 2)
+			assert.Equal(t, nil, err)
+			ids := make([]int64, 0, len(arts))
+			for _, art := range arts {
+				ids = append(ids, art.Id)
+			}
+			assert.Equal(t, tc.wantIds, ids)
+			assert.Equal(t, tc.wantNext, next)
+		})
+	}
+}
+
+func Test_articleService_ApproveReview(t *testing.T) {
+	testCases := []struct {
+		name string
+		// mock 返回的 channel 在异步推送关注流后关闭，不推送时为 nil
+		mock    func(ctrl *gomock.Controller) (repository.ArticleRepository, service.FeedService, service.UploadService, chan struct{})
+		wantErr error
+	}{
+		{
+			name: "审核通过并发表",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, service.FeedService, service.UploadService, chan struct{}) {
+				r := repomocks.NewMockArticleRepository(ctrl)
+				r.EXPECT().GetByStatus(gomock.Any(), int64(1), domain.ArticleStatusPendingReview).Return(domain.Article{
+					Id:      1,
 					Title:   "tittle",
-					Content: "content",
-					Author: domain.Author{
-						Id: 123,
-					},
-				}).Return(errors.New("update failed!")).Times(3)
-				return authorRepo, readerRepo
+					Content: "review content",
+					Author:  domain.Author{Id: 123},
+					Status:  domain.ArticleStatusPendingReview,
+					Version: 3,
+				}, nil)
+				r.EXPECT().Sync(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, art domain.Article) (int64, error) {
+						if art.Status != domain.ArticleStatusPublished || art.Version != 3 || art.Rendered.HTML == "" {
+							return 0, errors.New("发表的文章不对")
+						}
+						return 1, nil
+					})
+				us := svcmocks.NewMockUploadService(ctrl)
+				us.EXPECT().SyncReferences(gomock.Any(), int64(1), true, "review content").Return(nil)
+				done := make(chan struct{})
+				fs := svcmocks.NewMockFeedService(ctrl)
+				fs.EXPECT().PushArticle(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, art domain.Article) error {
+						close(done)
+						return nil
+					})
+				return r, fs, us, done
 			},
-			art: domain.Article{
-				Title:   "tittle",
-				Content: "content",
-				Author: domain.Author{
-					Id: 123,
-				},
+		},
+		{
+			name: "提交审核之后词库更新，命中屏蔽词不能通过",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, service.FeedService, service.UploadService, chan struct{}) {
+				r := repomocks.NewMockArticleRepository(ctrl)
+				r.EXPECT().GetByStatus(gomock.Any(), int64(1), domain.ArticleStatusPendingReview).Return(domain.Article{
+					Id:      1,
+					Title:   "tittle",
+					Content: "review blocked content",
+					Author:  domain.Author{Id: 123},
+					Status:  domain.ArticleStatusPendingReview,
+				}, nil)
+				return r, svcmocks.NewMockFeedService(ctrl), svcmocks.NewMockUploadService(ctrl), nil
+			},
+			wantErr: service.ErrArticleSensitive,
+		},
+		{
+			name: "文章不在等待审核",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, service.FeedService, service.UploadService, chan struct{}) {
+				r := repomocks.NewMockArticleRepository(ctrl)
+				r.EXPECT().GetByStatus(gomock.Any(), int64(1), domain.ArticleStatusPendingReview).
+					Return(domain.Article{}, service.ErrArticleNotFound)
+				return r, svcmocks.NewMockFeedService(ctrl), svcmocks.NewMockUploadService(ctrl), nil
 			},
-			wantErr: errors.New("update failed!"),
+			wantErr: service.ErrArticleNotFound,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
-			authorRepo, readerRepo := tc.mock(ctrl)
-			svc := NewArticleService(authorRepo, readerRepo, &logger.NopLogger{})
-			id, err := svc.Publish(context.Background(), tc.art)
+			defer ctrl.Finish()
+
+			r, fs, us, done := tc.mock(ctrl)
+			svc := service.NewArticleService(r, nil, nil, fs, us, newTestMatcher(), &logger.NopLogger{})
+			err := svc.ApproveReview(context.Background(), 1)
 			assert.Equal(t, tc.wantErr, err)
-			if err != nil {
-				return
+			if done != nil {
+				select {
+				case <-done:
+				case <-time.After(time.Second):
+					t.Fatal("没有推送关注流")
+				}
 			}
-			assert.Equal(t, tc.wantId, id)
 		})
 	}
 }
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+)
+
+var (
+	ErrCollectionNotFound  = repository.ErrCollectionNotFound
+	ErrCollectItemNotFound = errors.New("没有收藏过")
+)
+
+type CollectionService interface {
+	Create(ctx context.Context, c domain.Collection) (int64, error)
+	Rename(ctx context.Context, id, uid int64, name string) error
+	// Delete 收藏夹里的收藏一并取消
+	Delete(ctx context.Context, id, uid int64) error
+	List(ctx context.Context, uid int64, offset, limit int) ([]domain.Collection, error)
+	// ListItems 收藏的文章带上标题、摘要，已经下线的文章只有 id
+	ListItems(ctx context.Context, cid, uid int64, offset, limit int) ([]domain.CollectionItem, error)
+	// Move 把收藏移到另一个收藏夹，cid 为 0 表示移出收藏夹
+	Move(ctx context.Context, biz string, bizId, uid, cid int64) error
+	// Uncollect 没有收藏过时什么也不做
+	Uncollect(ctx context.Context, biz string, bizId, uid int64) error
+}
+
+type collectionService struct {
+	r        repository.CollectionRepository
+	intrRepo repository.InteractiveRepository
+	artRepo  repository.ArticleRepository
+	l        logger.Logger
+}
+
+func NewCollectionService(r repository.CollectionRepository, intrRepo repository.InteractiveRepository,
+	artRepo repository.ArticleRepository, l logger.Logger) CollectionService {
+	return &collectionService{
+		r:        r,
+		intrRepo: intrRepo,
+		artRepo:  artRepo,
+		l:        l,
+	}
+}
+
+func (c *collectionService) Create(ctx context.Context, col domain.Collection) (int64, error) {
+	return c.r.Create(ctx, col)
+}
+
+func (c *collectionService) Rename(ctx context.Context, id, uid int64, name string) error {
+	// 确认收藏夹属于该用户，更新名字相同时影响行数为 0，不能据此判断
+	_, err := c.r.FindById(ctx, id, uid)
+	if err != nil {
+		return err
+	}
+	return c.r.Rename(ctx, id, uid, name)
+}
+
+func (c *collectionService) Delete(ctx context.Context, id, uid int64) error {
+	return c.r.Delete(ctx, id, uid)
+}
+
+func (c *collectionService) List(ctx context.Context, uid int64, offset, limit int) ([]domain.Collection, error) {
+	return c.r.List(ctx, uid, offset, limit)
+}
+
+func (c *collectionService) ListItems(ctx context.Context, cid, uid int64, offset, limit int) ([]domain.CollectionItem, error) {
+	if cid > 0 {
+		_, err := c.r.FindById(ctx, cid, uid)
+		if err != nil {
+			return nil, err
+		}
+	}
+	items, err := c.r.ListItems(ctx, cid, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		if items[i].Biz != bizArticle {
+			continue
+		}
+		art, er := c.artRepo.GetPubById(ctx, items[i].BizId)
+		switch er {
+		case nil:
+			if art.Status == domain.ArticleStatusPublished {
+				items[i].Article = art
+				continue
+			}
+		case repository.ErrArticleNotFound:
+		default:
+			c.l.Error("获取收藏的文章失败",
+				logger.Int64("aid", items[i].BizId), logger.Error(er))
+		}
+		// 文章已经下线或者查询失败，只保留 id
+		items[i].Article = domain.Article{Id: items[i].BizId}
+	}
+	return items, nil
+}
+
+func (c *collectionService) Move(ctx context.Context, biz string, bizId, uid, cid int64) error {
+	if cid > 0 {
+		_, err := c.r.FindById(ctx, cid, uid)
+		if err != nil {
+			return err
+		}
+	}
+	err := c.r.MoveItem(ctx, biz, bizId, uid, cid)
+	if err == repository.ErrCollectItemNotFound {
+		return ErrCollectItemNotFound
+	}
+	return err
+}
+
+func (c *collectionService) Uncollect(ctx context.Context, biz string, bizId, uid int64) error {
+	return c.intrRepo.RemoveCollectionItem(ctx, bizId, biz, uid)
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+)
+
+const bizArticle = "article"
+
+var (
+	ErrCommentNotFound = repository.ErrCommentNotFound
+	// ErrCommentTargetNotFound 评论的对象不存在，或者不允许评论
+	ErrCommentTargetNotFound = errors.New("评论对象不存在")
+	// ErrCommentPermissionDenied 只有评论人和文章作者可以删除评论
+	ErrCommentPermissionDenied = errors.New("无权删除该评论")
+)
+
+type CommentService interface {
+	// Create ParentId 不为 0 时是回复，回复必须和被回复的评论属于同一个对象
+	Create(ctx context.Context, c domain.Comment) (int64, error)
+	// Delete 评论人或者文章作者可以删除，回复它的评论一并删除
+	Delete(ctx context.Context, id, uid int64) error
+	// ListRoots 根评论按时间倒序，每条根评论内联最早的几条回复
+	ListRoots(ctx context.Context, biz string, bizId int64, beforeId int64, limit int) ([]domain.Comment, error)
+	// ListReplies 根评论下的回复，按时间正序
+	ListReplies(ctx context.Context, rootId int64, afterId int64, limit int) ([]domain.Comment, error)
+}
+
+type commentService struct {
+	r        repository.CommentRepository
+	artRepo  repository.ArticleRepository
+	intrRepo repository.InteractiveRepository
+	l        logger.Logger
+}
+
+func NewCommentService(r repository.CommentRepository, artRepo repository.ArticleRepository,
+	intrRepo repository.InteractiveRepository, l logger.Logger) CommentService {
+	return &commentService{
+		r:        r,
+		artRepo:  artRepo,
+		intrRepo: intrRepo,
+		l:        l,
+	}
+}
+
+func (s *commentService) Create(ctx context.Context, c domain.Comment) (int64, error) {
+	c.RootId = 0
+	if c.ParentId > 0 {
+		parent, err := s.r.FindById(ctx, c.ParentId)
+		if err != nil {
+			return 0, err
+		}
+		if parent.Biz != c.Biz || parent.BizId != c.BizId {
+			return 0, ErrCommentNotFound
+		}
+		c.RootId = parent.RootId
+		if parent.IsRoot() {
+			c.RootId = parent.Id
+		}
+	}
+	_, err := s.owner(ctx, c.Biz, c.BizId)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := s.r.Create(ctx, c)
+	if err != nil {
+		return 0, err
+	}
+	s.incrCnt(ctx, c.Biz, c.BizId, 1)
+	return id, nil
+}
+
+func (s *commentService) Delete(ctx context.Context, id, uid int64) error {
+	c, err := s.r.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if c.Commentator.Id != uid {
+		owner, err := s.owner(ctx, c.Biz, c.BizId)
+		if err != nil && err != ErrCommentTargetNotFound {
+			return err
+		}
+		if owner != uid {
+			return ErrCommentPermissionDenied
+		}
+	}
+	cnt, err := s.r.Delete(ctx, c)
+	if err != nil {
+		return err
+	}
+	s.incrCnt(ctx, c.Biz, c.BizId, -cnt)
+	return nil
+}
+
+func (s *commentService) ListRoots(ctx context.Context, biz string, bizId int64, beforeId int64, limit int) ([]domain.Comment, error) {
+	return s.r.FindRoots(ctx, biz, bizId, beforeId, limit)
+}
+
+func (s *commentService) ListReplies(ctx context.Context, rootId int64, afterId int64, limit int) ([]domain.Comment, error) {
+	return s.r.FindReplies(ctx, rootId, afterId, limit)
+}
+
+// owner 评论对象的作者，同时确认对象可以被评论。目前只有已发表的文章可以评论
+func (s *commentService) owner(ctx context.Context, biz string, bizId int64) (int64, error) {
+	if biz != bizArticle {
+		return 0, ErrCommentTargetNotFound
+	}
+	art, err := s.artRepo.GetPubById(ctx, bizId)
+	if err == repository.ErrArticleNotFound {
+		return 0, ErrCommentTargetNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	if art.Status != domain.ArticleStatusPublished {
+		return art.Author.Id, ErrCommentTargetNotFound
+	}
+	return art.Author.Id, nil
+}
+
+func (s *commentService) incrCnt(ctx context.Context, biz string, bizId int64, delta int64) {
+	if delta == 0 {
+		return
+	}
+	err := s.intrRepo.IncrCommentCnt(ctx, biz, bizId, delta)
+	if err != nil {
+		// 评论已经写入，计数不准可以容忍
+		s.l.Error("更新评论数失败",
+			logger.String("biz", biz), logger.Int64("bizId", bizId), logger.Error(err))
+	}
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/johnwongx/webook/backend/pkg/markdown"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	ErrExportNotFound = repository.ErrExportNotFound
+	// ErrExportNotReady 导出还没有完成或者已经失败
+	ErrExportNotReady = errors.New("导出尚未完成")
+)
+
+const (
+	// ExportLinkExpiration 下载链接的有效期
+	ExportLinkExpiration = time.Hour
+	// exportRunningTimeout 超过该时间没有更新进度，认为执行的实例已经崩溃
+	exportRunningTimeout = time.Minute * 5
+	exportPageSize       = 100
+	// exportProgressStep 每导出这么多篇更新一次进度
+	exportProgressStep = 20
+)
+
+type ExportService interface {
+	// Create 创建导出任务，已经有进行中的任务时直接返回它
+	Create(ctx context.Context, uid int64) (domain.ArticleExport, error)
+	Get(ctx context.Context, id, uid int64) (domain.ArticleExport, error)
+	// DownloadURL 导出完成后的下载链接，有效期为 ExportLinkExpiration
+	DownloadURL(ctx context.Context, id, uid int64) (string, error)
+	// RunPending 执行所有等待中的导出任务，由后台任务周期调用
+	RunPending(ctx context.Context) error
+}
+
+type exportService struct {
+	r       repository.ExportRepository
+	artRepo repository.ArticleRepository
+	l       logger.Logger
+}
+
+func NewExportService(r repository.ExportRepository, artRepo repository.ArticleRepository, l logger.Logger) ExportService {
+	return &exportService{
+		r:       r,
+		artRepo: artRepo,
+		l:       l,
+	}
+}
+
+func (s *exportService) Create(ctx context.Context, uid int64) (domain.ArticleExport, error) {
+	e, err := s.r.FindActive(ctx, uid)
+	switch err {
+	case nil:
+		return e, nil
+	case repository.ErrExportNotFound:
+		return s.r.Create(ctx, uid)
+	default:
+		return domain.ArticleExport{}, err
+	}
+}
+
+func (s *exportService) Get(ctx context.Context, id, uid int64) (domain.ArticleExport, error) {
+	return s.r.FindById(ctx, id, uid)
+}
+
+func (s *exportService) DownloadURL(ctx context.Context, id, uid int64) (string, error) {
+	e, err := s.r.FindById(ctx, id, uid)
+	if err != nil {
+		return "", err
+	}
+	if e.Status != domain.ArticleExportStatusDone {
+		return "", ErrExportNotReady
+	}
+	return s.r.ArchiveURL(ctx, e.Key, ExportLinkExpiration)
+}
+
+func (s *exportService) RunPending(ctx context.Context) error {
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		e, err := s.r.Preempt(ctx, time.Now(), exportRunningTimeout)
+		switch err {
+		case nil:
+		case repository.ErrExportNotFound:
+			return nil
+		default:
+			return err
+		}
+
+		status := domain.ArticleExportStatusDone
+		key, err := s.export(ctx, e)
+		if err != nil {
+			status = domain.ArticleExportStatusFailed
+			s.l.Error("导出文章失败",
+				logger.Int64("export", e.Id), logger.Int64("uid", e.Uid), logger.Error(err))
+		}
+		err = s.r.Finish(ctx, e.Id, status, key)
+		if err != nil {
+			s.l.Error("更新导出任务状态失败",
+				logger.Int64("export", e.Id), logger.Error(err))
+		}
+	}
+}
+
+// export 打包作者的全部文章，返回归档文件的 key
+func (s *exportService) export(ctx context.Context, e domain.ArticleExport) (string, error) {
+	ids, err := s.articleIds(ctx, e.Uid)
+	if err != nil {
+		return "", err
+	}
+	total := len(ids)
+	s.updateProgress(ctx, e.Id, 0, total)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for i, id := range ids {
+		art, err := s.artRepo.GetById(ctx, id, e.Uid)
+		switch err {
+		case nil:
+		case repository.ErrArticleNotFound:
+			// 导出期间被删除了
+			continue
+		default:
+			return "", err
+		}
+		err = s.writeArticle(zw, art)
+		if err != nil {
+			return "", err
+		}
+		if (i+1)%exportProgressStep == 0 {
+			s.updateProgress(ctx, e.Id, i+1, total)
+		}
+	}
+	err = zw.Close()
+	if err != nil {
+		return "", err
+	}
+	key := fmt.Sprintf("exports/%d/%d.zip", e.Uid, e.Id)
+	err = s.r.PutArchive(ctx, key, buf.Bytes())
+	if err != nil {
+		return "", err
+	}
+	s.updateProgress(ctx, e.Id, total, total)
+	return key, nil
+}
+
+// articleIds 草稿、已发表、仅自己可见的文章都要导出，回收站里的除外
+func (s *exportService) articleIds(ctx context.Context, uid int64) ([]int64, error) {
+	var (
+		ids    []int64
+		seen   = make(map[int64]struct{})
+		cursor domain.ArticleCursor
+	)
+	for {
+		arts, err := s.artRepo.List(ctx, uid, cursor, exportPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, art := range arts {
+			// 导出期间修改的文章会移动到列表前面，可能重复出现
+			if _, ok := seen[art.Id]; ok {
+				continue
+			}
+			seen[art.Id] = struct{}{}
+			ids = append(ids, art.Id)
+		}
+		if len(arts) < exportPageSize {
+			return ids, nil
+		}
+		cursor = domain.NewArticleCursor(arts[len(arts)-1])
+	}
+}
+
+func (s *exportService) updateProgress(ctx context.Context, id int64, done, total int) {
+	err := s.r.UpdateProgress(ctx, id, done, total)
+	if err != nil {
+		s.l.Error("更新导出进度失败", logger.Int64("export", id), logger.Error(err))
+	}
+}
+
+// articleFrontMatter 导出的 Markdown 文件开头的元数据
+type articleFrontMatter struct {
+	Title    string    `yaml:"title"`
+	Status   string    `yaml:"status"`
+	Ctime    time.Time `yaml:"ctime"`
+	Utime    time.Time `yaml:"utime"`
+	Tags     []string  `yaml:"tags,omitempty"`
+	Category string    `yaml:"category,omitempty"`
+}
+
+func (s *exportService) writeArticle(zw *zip.Writer, art domain.Article) error {
+	content, err := markdown.WithFrontMatter(articleFrontMatter{
+		Title:    art.Title,
+		Status:   exportStatusName(art.Status),
+		Ctime:    art.Ctime,
+		Utime:    art.Utime,
+		Tags:     art.Tags,
+		Category: art.Category,
+	}, art.Content)
+	if err != nil {
+		return err
+	}
+	w, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     exportFileName(art),
+		Method:   zip.Deflate,
+		Modified: art.Utime,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write([]byte(content))
+	return err
+}
+
+func exportStatusName(status domain.ArticleStatus) string {
+	switch status {
+	case domain.ArticleStatusPublished:
+		return "published"
+	case domain.ArticleStatusPrivate:
+		return "private"
+	case domain.ArticleStatusScheduled:
+		return "scheduled"
+	case domain.ArticleStatusPendingReview:
+		return "pending_review"
+	default:
+		return "draft"
+	}
+}
+
+// exportFileName 以 id 开头保证不重名，标题里不能出现在文件名中的字符替换为下划线
+func exportFileName(art domain.Article) string {
+	const maxTitleLen = 50
+	title := strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(art.Title))
+	if cs := []rune(title); len(cs) > maxTitleLen {
+		title = string(cs[:maxTitleLen])
+	}
+	if title == "" {
+		title = "untitled"
+	}
+	return fmt.Sprintf("%d-%s.md", art.Id, title)
+}
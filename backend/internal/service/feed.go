@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"sort"
+	"time"
+)
+
+// FeedService 关注流。粉丝少的作者发表文章时推送到每个粉丝的收件箱（推模式），
+// 粉丝多的作者不推送，由粉丝读取关注流时去拉取（拉模式）
+type FeedService interface {
+	// PushArticle 文章发表后调用，粉丝多的作者不做任何事
+	PushArticle(ctx context.Context, art domain.Article) error
+	// Feed 关注的作者最近发表的文章，按进入关注流的时间倒序，返回游标之后的一页和下一页的游标。
+	// 游标为零值时从最新的开始，返回的游标为零值表示没有下一页了
+	Feed(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.FeedItem, domain.ArticleCursor, error)
+	// Backfill 关注之后，把粉丝少的作者最近的文章补到收件箱里
+	Backfill(ctx context.Context, follower, followee int64) error
+	// Clear 取消关注之后，清理收件箱里该作者的文章
+	Clear(ctx context.Context, follower, followee int64) error
+}
+
+type feedService struct {
+	followRepo repository.FollowRepository
+	feedRepo   repository.FeedRepository
+	artRepo    repository.ArticleRepository
+	userRepo   repository.UserRepository
+	l          logger.Logger
+	// pushThreshold 粉丝数少于该值的作者使用推模式
+	pushThreshold int64
+	batchSize     int
+	backfillSize  int
+}
+
+func NewFeedService(followRepo repository.FollowRepository, feedRepo repository.FeedRepository,
+	artRepo repository.ArticleRepository, userRepo repository.UserRepository, l logger.Logger) FeedService {
+	return &feedService{
+		followRepo:    followRepo,
+		feedRepo:      feedRepo,
+		artRepo:       artRepo,
+		userRepo:      userRepo,
+		l:             l,
+		pushThreshold: 1000,
+		batchSize:     500,
+		backfillSize:  20,
+	}
+}
+
+func (f *feedService) PushArticle(ctx context.Context, art domain.Article) error {
+	uid := art.Author.Id
+	popular, err := f.popular(ctx, uid)
+	if err != nil || popular {
+		return err
+	}
+	now := time.Now()
+	offset := 0
+	for {
+		rels, err := f.followRepo.Followers(ctx, uid, offset, f.batchSize)
+		if err != nil {
+			return err
+		}
+		uids := slice.Map[domain.FollowRelation, int64](rels, func(idx int, src domain.FollowRelation) int64 {
+			return src.Follower
+		})
+		if len(uids) > 0 {
+			err = f.feedRepo.Push(ctx, art.Id, uid, uids, now)
+			if err != nil {
+				return err
+			}
+		}
+		if len(rels) < f.batchSize {
+			return nil
+		}
+		offset += len(rels)
+	}
+}
+
+func (f *feedService) Backfill(ctx context.Context, follower, followee int64) error {
+	popular, err := f.popular(ctx, followee)
+	if err != nil || popular {
+		return err
+	}
+	arts, err := f.artRepo.ListPubByAuthor(ctx, followee, domain.ArticleCursor{}, f.backfillSize)
+	if err != nil {
+		return err
+	}
+	for _, art := range arts {
+		// 用文章的更新时间，补进来的文章按原来的先后顺序排在关注流里
+		err = f.feedRepo.Push(ctx, art.Id, followee, []int64{follower}, art.Utime)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *feedService) Clear(ctx context.Context, follower, followee int64) error {
+	return f.feedRepo.DeletePush(ctx, follower, followee)
+}
+
+func (f *feedService) popular(ctx context.Context, uid int64) (bool, error) {
+	statics, err := f.followRepo.Statics(ctx, uid)
+	if err != nil {
+		return false, err
+	}
+	return statics.Followers >= f.pushThreshold, nil
+}
+
+func (f *feedService) Feed(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.FeedItem, domain.ArticleCursor, error) {
+	// 推模式：自己的收件箱
+	pushed, err := f.feedRepo.ListPush(ctx, uid, cursor, limit)
+	if err != nil {
+		return nil, domain.ArticleCursor{}, err
+	}
+	// 拉模式：粉丝多的作者直接查线上库
+	authors, err := f.followRepo.PopularFollowees(ctx, uid, f.pushThreshold)
+	if err != nil {
+		return nil, domain.ArticleCursor{}, err
+	}
+	pulled, err := f.artRepo.ListPubByAuthors(ctx, authors, cursor, limit)
+	if err != nil {
+		return nil, domain.ArticleCursor{}, err
+	}
+
+	candidates := make([]domain.FeedItem, 0, len(pushed)+len(pulled))
+	candidates = append(candidates, pushed...)
+	pulledArts := make(map[int64]domain.Article, len(pulled))
+	for _, art := range pulled {
+		candidates = append(candidates, domain.FeedItem{Article: art, Ctime: art.Utime})
+		pulledArts[art.Id] = art
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[j].Cursor().Less(candidates[i].Cursor())
+	})
+	// 某一边取满了 limit 条时，比它最后一条更旧的记录还没有取出来，这一页最多到这里
+	var bound domain.ArticleCursor
+	if len(pushed) == limit {
+		bound = pushed[len(pushed)-1].Cursor()
+	}
+	if len(pulled) == limit {
+		last := domain.NewArticleCursor(pulled[len(pulled)-1])
+		if bound.IsZero() || bound.Less(last) {
+			bound = last
+		}
+	}
+
+	res := make([]domain.FeedItem, 0, limit)
+	seen := make(map[int64]struct{}, len(candidates))
+	names := make(map[int64]string)
+	var next domain.ArticleCursor
+	consumed := 0
+	for _, item := range candidates {
+		if len(res) >= limit || (!bound.IsZero() && item.Cursor().Less(bound)) {
+			break
+		}
+		consumed++
+		next = item.Cursor()
+		// 作者粉丝数跨过阈值前后，同一篇文章可能既被推送过又被拉取到
+		if _, ok := seen[item.Article.Id]; ok {
+			continue
+		}
+		seen[item.Article.Id] = struct{}{}
+		art, ok := f.load(ctx, item.Article.Id, pulledArts, names)
+		if !ok {
+			continue
+		}
+		res = append(res, domain.FeedItem{Article: art, Ctime: item.Ctime})
+	}
+	if bound.IsZero() && consumed == len(candidates) {
+		// 两边都取完了
+		next = domain.ArticleCursor{}
+	}
+	return res, next, nil
+}
+
+// load 推送过来的只有 id，要查出文章；拉取的文章要补上作者昵称。
+// 推送之后撤回的文章不再展示
+func (f *feedService) load(ctx context.Context, id int64, pulledArts map[int64]domain.Article,
+	names map[int64]string) (domain.Article, bool) {
+	art, ok := pulledArts[id]
+	if !ok {
+		res, err := f.artRepo.GetPubById(ctx, id)
+		if err != nil {
+			f.l.Error("获取关注流中的文章失败",
+				logger.Int64("id", id), logger.Error(err))
+			return domain.Article{}, false
+		}
+		if res.Status != domain.ArticleStatusPublished {
+			return domain.Article{}, false
+		}
+		names[res.Author.Id] = res.Author.Name
+		return res, true
+	}
+	name, ok := names[art.Author.Id]
+	if !ok {
+		user, err := f.userRepo.FindById(ctx, art.Author.Id)
+		if err != nil {
+			f.l.Error("获取用户信息失败",
+				logger.Int64("author", art.Author.Id), logger.Error(err))
+		}
+		name = user.NickName
+		names[art.Author.Id] = name
+	}
+	art.Author.Name = name
+	return art, true
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"time"
+)
+
+var ErrFollowSelf = errors.New("不能关注自己")
+
+type FollowService interface {
+	Follow(ctx context.Context, follower, followee int64) error
+	Unfollow(ctx context.Context, follower, followee int64) error
+	// Followees uid 关注的人
+	Followees(ctx context.Context, uid int64, offset, limit int) ([]domain.FollowRelation, error)
+	// Followers uid 的粉丝
+	Followers(ctx context.Context, uid int64, offset, limit int) ([]domain.FollowRelation, error)
+	Statics(ctx context.Context, uid int64) (domain.FollowStatics, error)
+}
+
+type followService struct {
+	r       repository.FollowRepository
+	userSvc UserService
+	feedSvc FeedService
+	l       logger.Logger
+}
+
+func NewFollowService(r repository.FollowRepository, userSvc UserService,
+	feedSvc FeedService, l logger.Logger) FollowService {
+	return &followService{
+		r:       r,
+		userSvc: userSvc,
+		feedSvc: feedSvc,
+		l:       l,
+	}
+}
+
+func (f *followService) Follow(ctx context.Context, follower, followee int64) error {
+	if follower == followee {
+		return ErrFollowSelf
+	}
+	// 确认被关注的人存在
+	_, err := f.userSvc.Profile(ctx, followee)
+	if err != nil {
+		return err
+	}
+	err = f.r.Follow(ctx, follower, followee)
+	if err != nil {
+		return err
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancel()
+		er := f.feedSvc.Backfill(ctx, follower, followee)
+		if er != nil {
+			f.l.Error("补充关注流失败",
+				logger.Int64("follower", follower), logger.Int64("followee", followee), logger.Error(er))
+		}
+	}()
+	return nil
+}
+
+func (f *followService) Unfollow(ctx context.Context, follower, followee int64) error {
+	err := f.r.Unfollow(ctx, follower, followee)
+	if err != nil {
+		return err
+	}
+	err = f.feedSvc.Clear(ctx, follower, followee)
+	if err != nil {
+		// 收件箱里残留的文章不影响关注关系
+		f.l.Error("清理关注流失败",
+			logger.Int64("follower", follower), logger.Int64("followee", followee), logger.Error(err))
+	}
+	return nil
+}
+
+func (f *followService) Followees(ctx context.Context, uid int64, offset, limit int) ([]domain.FollowRelation, error) {
+	return f.r.Followees(ctx, uid, offset, limit)
+}
+
+func (f *followService) Followers(ctx context.Context, uid int64, offset, limit int) ([]domain.FollowRelation, error) {
+	return f.r.Followers(ctx, uid, offset, limit)
+}
+
+func (f *followService) Statics(ctx context.Context, uid int64) (domain.FollowStatics, error) {
+	return f.r.Statics(ctx, uid)
+}
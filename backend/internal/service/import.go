@@ -0,0 +1,270 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/johnwongx/webook/backend/pkg/markdown"
+	"io"
+	"path"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrImportTooManyFiles 展开 ZIP 后的 Markdown 文件太多
+var ErrImportTooManyFiles = errors.New("导入的文件太多")
+
+const (
+	// MaxImportSize 一次上传的文件总大小上限
+	MaxImportSize = 20 << 20
+	// MaxImportFiles 一次最多导入的 Markdown 文件数，ZIP 里的文件也计算在内
+	MaxImportFiles = 200
+	// maxImportFileSize 单个 Markdown 文件的大小上限，也用来防止 ZIP 炸弹
+	maxImportFileSize = 2 << 20
+	maxImportTags     = 10
+)
+
+type ImportService interface {
+	// Import 导入 Markdown 文件或包含 Markdown 的 ZIP，每个 Markdown 文件一个结果；
+	// publish 为 true 时 front matter 中 status 为 published 的文章直接发表，其余导入为草稿
+	Import(ctx context.Context, uid int64, files []domain.ImportFile, publish bool) ([]domain.ArticleImportResult, error)
+}
+
+type importService struct {
+	artSvc ArticleService
+	r      repository.ImportRepository
+	l      logger.Logger
+}
+
+func NewImportService(artSvc ArticleService, r repository.ImportRepository, l logger.Logger) ImportService {
+	return &importService{
+		artSvc: artSvc,
+		r:      r,
+		l:      l,
+	}
+}
+
+// importFrontMatter 和导出时写入的 front matter 格式相同
+type importFrontMatter struct {
+	Title    string   `yaml:"title"`
+	Status   string   `yaml:"status"`
+	Tags     []string `yaml:"tags"`
+	Category string   `yaml:"category"`
+}
+
+// importItem 待导入的 Markdown 文件，reason 不为空表示展开时就已经失败
+type importItem struct {
+	file   domain.ImportFile
+	reason string
+}
+
+func (s *importService) Import(ctx context.Context, uid int64, files []domain.ImportFile,
+	publish bool) ([]domain.ArticleImportResult, error) {
+	var items []importItem
+	for _, f := range files {
+		switch strings.ToLower(path.Ext(f.Name)) {
+		case ".zip":
+			zipItems, err := s.unzip(f, MaxImportFiles-len(items))
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, zipItems...)
+		case ".md", ".markdown":
+			items = append(items, importItem{file: f})
+		default:
+			items = append(items, importItem{file: f, reason: "只支持 Markdown 和 ZIP 文件"})
+		}
+		if len(items) > MaxImportFiles {
+			return nil, ErrImportTooManyFiles
+		}
+	}
+
+	res := make([]domain.ArticleImportResult, 0, len(items))
+	for _, item := range items {
+		if item.reason != "" {
+			res = append(res, domain.ArticleImportResult{
+				Name:   item.file.Name,
+				Status: domain.ArticleImportStatusFailed,
+				Reason: item.reason,
+			})
+			continue
+		}
+		res = append(res, s.importOne(ctx, uid, item.file, publish))
+	}
+	return res, nil
+}
+
+// unzip 只取出 Markdown 文件，图片等其他文件直接忽略；Markdown 文件超过 limit 个时不再解压
+func (s *importService) unzip(f domain.ImportFile, limit int) ([]importItem, error) {
+	zr, err := zip.NewReader(bytes.NewReader(f.Data), int64(len(f.Data)))
+	if err != nil {
+		return []importItem{{file: f, reason: "无法解析 ZIP 文件"}}, nil
+	}
+	var files []*zip.File
+	for _, zf := range zr.File {
+		ext := strings.ToLower(path.Ext(zf.Name))
+		if zf.FileInfo().IsDir() || (ext != ".md" && ext != ".markdown") ||
+			strings.HasPrefix(zf.Name, "__MACOSX/") || strings.HasPrefix(path.Base(zf.Name), ".") {
+			continue
+		}
+		files = append(files, zf)
+	}
+	if len(files) > limit {
+		return nil, ErrImportTooManyFiles
+	}
+	items := make([]importItem, 0, len(files))
+	for _, zf := range files {
+		name := f.Name + "/" + zf.Name
+		if zf.UncompressedSize64 > maxImportFileSize {
+			items = append(items, importItem{file: domain.ImportFile{Name: name}, reason: "文件太大"})
+			continue
+		}
+		data, err := s.readZipFile(zf)
+		if err != nil {
+			items = append(items, importItem{file: domain.ImportFile{Name: name}, reason: "无法解压文件"})
+			continue
+		}
+		items = append(items, importItem{file: domain.ImportFile{Name: name, Data: data}})
+	}
+	return items, nil
+}
+
+func (s *importService) readZipFile(zf *zip.File) ([]byte, error) {
+	rc, err := zf.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	// 头部记录的大小可以伪造，读取时再限制一次
+	data, err := io.ReadAll(io.LimitReader(rc, maxImportFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxImportFileSize {
+		return nil, errors.New("文件太大")
+	}
+	return data, nil
+}
+
+func (s *importService) importOne(ctx context.Context, uid int64, f domain.ImportFile,
+	publish bool) domain.ArticleImportResult {
+	res := domain.ArticleImportResult{
+		Name:   f.Name,
+		Status: domain.ArticleImportStatusFailed,
+	}
+	if len(f.Data) > maxImportFileSize {
+		res.Reason = "文件太大"
+		return res
+	}
+	if !utf8.Valid(f.Data) {
+		res.Reason = "文件不是 UTF-8 编码"
+		return res
+	}
+
+	sum := sha256.Sum256(f.Data)
+	hash := hex.EncodeToString(sum[:])
+	aid, err := s.findImported(ctx, uid, hash)
+	if err != nil {
+		s.l.Error("查询导入记录失败", logger.Int64("uid", uid), logger.Error(err))
+		res.Reason = "系统错误"
+		return res
+	}
+	if aid > 0 {
+		res.ArticleId = aid
+		res.Status = domain.ArticleImportStatusDuplicate
+		return res
+	}
+
+	var meta importFrontMatter
+	body, err := markdown.ParseFrontMatter(string(f.Data), &meta)
+	if err != nil {
+		res.Reason = "front matter 格式错误"
+		return res
+	}
+	if len(meta.Tags) > maxImportTags {
+		res.Reason = "标签数量不能超过10个"
+		return res
+	}
+	art := domain.Article{
+		Title:    importTitle(meta.Title, body, f.Name),
+		Content:  body,
+		Author:   domain.Author{Id: uid},
+		Tags:     meta.Tags,
+		Category: meta.Category,
+	}
+
+	res.Status = domain.ArticleImportStatusDraft
+	if publish && meta.Status == exportStatusName(domain.ArticleStatusPublished) {
+		res.Status = domain.ArticleImportStatusPublished
+		res.ArticleId, err = s.artSvc.Publish(ctx, art)
+	} else {
+		res.ArticleId, err = s.artSvc.Save(ctx, art)
+	}
+	switch err {
+	case nil:
+	case ErrArticlePendingReview:
+		res.Status = domain.ArticleImportStatusPendingReview
+	case ErrArticleSensitive:
+		res.Status = domain.ArticleImportStatusFailed
+		res.Reason = "文章包含违规内容"
+		return res
+	default:
+		s.l.Error("导入文章失败",
+			logger.Int64("uid", uid), logger.String("file", f.Name), logger.Error(err))
+		res.Status = domain.ArticleImportStatusFailed
+		res.Reason = "系统错误"
+		return res
+	}
+
+	err = s.r.Save(ctx, uid, hash, res.ArticleId)
+	if err != nil {
+		// 文章已经创建，只是下次导入时不能去重
+		s.l.Error("保存导入记录失败",
+			logger.Int64("uid", uid), logger.Int64("id", res.ArticleId), logger.Error(err))
+	}
+	return res
+}
+
+// findImported 之前导入的文章还在时返回它的 id，否则返回 0
+func (s *importService) findImported(ctx context.Context, uid int64, hash string) (int64, error) {
+	aid, err := s.r.FindArticleId(ctx, uid, hash)
+	switch err {
+	case nil:
+	case repository.ErrImportNotFound:
+		return 0, nil
+	default:
+		return 0, err
+	}
+	// 文章已经删除的，允许重新导入
+	_, err = s.artSvc.GetById(ctx, aid, uid)
+	switch err {
+	case nil:
+		return aid, nil
+	case ErrArticleNotFound:
+		return 0, nil
+	default:
+		return 0, err
+	}
+}
+
+// importTitle 依次使用 front matter 中的标题、正文的第一个一级标题、文件名
+func importTitle(title, body, name string) string {
+	if title = strings.TrimSpace(title); title != "" {
+		return title
+	}
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "# ") {
+			if t := strings.TrimSpace(line[2:]); t != "" {
+				return t
+			}
+		}
+	}
+	return strings.TrimSuffix(path.Base(name), path.Ext(name))
+}
@@ -12,6 +12,8 @@ type InteractiveService interface {
 	Liked(ctx context.Context, id int64, biz string, uid int64) (bool, error)
 	CancelLike(ctx context.Context, id int64, biz string, uid int64) error
 	Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error)
+	// GetByIds 批量获取计数，结果以 bizId 为键
+	GetByIds(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error)
 	Collect(ctx context.Context, id int64, biz string, cid int64, uid int64) error
 	Collected(ctx context.Context, id int64, biz string, uid int64) (bool, error)
 }
@@ -43,6 +45,13 @@ func (i *interactiveService) Get(
 	return i.r.Get(ctx, biz, bizId)
 }
 
+func (i *interactiveService) GetByIds(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error) {
+	if len(bizIds) == 0 {
+		return map[int64]domain.Interactive{}, nil
+	}
+	return i.r.GetByIds(ctx, biz, bizIds)
+}
+
 func (i *interactiveService) Collect(ctx context.Context, id int64, biz string, cid, uid int64) error {
 	return i.r.AddCollectionItem(ctx, id, biz, cid, uid)
 }
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backend/internal/service/article_consistency.go
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/johnwongx/webook/backend/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleConsistencyService is a mock of ArticleConsistencyService interface.
+type MockArticleConsistencyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleConsistencyServiceMockRecorder
+}
+
+// MockArticleConsistencyServiceMockRecorder is the mock recorder for MockArticleConsistencyService.
+type MockArticleConsistencyServiceMockRecorder struct {
+	mock *MockArticleConsistencyService
+}
+
+// NewMockArticleConsistencyService creates a new mock instance.
+func NewMockArticleConsistencyService(ctrl *gomock.Controller) *MockArticleConsistencyService {
+	mock := &MockArticleConsistencyService{ctrl: ctrl}
+	mock.recorder = &MockArticleConsistencyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleConsistencyService) EXPECT() *MockArticleConsistencyServiceMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockArticleConsistencyService) Check(ctx context.Context, repair bool) (domain.ArticleConsistencyReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, repair)
+	ret0, _ := ret[0].(domain.ArticleConsistencyReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockArticleConsistencyServiceMockRecorder) Check(ctx, repair interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockArticleConsistencyService)(nil).Check), ctx, repair)
+}
+
+// LatestReport mocks base method.
+func (m *MockArticleConsistencyService) LatestReport(ctx context.Context) (domain.ArticleConsistencyReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestReport", ctx)
+	ret0, _ := ret[0].(domain.ArticleConsistencyReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestReport indicates an expected call of LatestReport.
+func (mr *MockArticleConsistencyServiceMockRecorder) LatestReport(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestReport", reflect.TypeOf((*MockArticleConsistencyService)(nil).LatestReport), ctx)
+}
+
+// StartCheck mocks base method.
+func (m *MockArticleConsistencyService) StartCheck(ctx context.Context, repair bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartCheck", ctx, repair)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartCheck indicates an expected call of StartCheck.
+func (mr *MockArticleConsistencyServiceMockRecorder) StartCheck(ctx, repair interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartCheck", reflect.TypeOf((*MockArticleConsistencyService)(nil).StartCheck), ctx, repair)
+}
@@ -0,0 +1,121 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backend/internal/service/article_migration.go
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/johnwongx/webook/backend/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleMigrationService is a mock of ArticleMigrationService interface.
+type MockArticleMigrationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleMigrationServiceMockRecorder
+}
+
+// MockArticleMigrationServiceMockRecorder is the mock recorder for MockArticleMigrationService.
+type MockArticleMigrationServiceMockRecorder struct {
+	mock *MockArticleMigrationService
+}
+
+// NewMockArticleMigrationService creates a new mock instance.
+func NewMockArticleMigrationService(ctrl *gomock.Controller) *MockArticleMigrationService {
+	mock := &MockArticleMigrationService{ctrl: ctrl}
+	mock.recorder = &MockArticleMigrationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleMigrationService) EXPECT() *MockArticleMigrationServiceMockRecorder {
+	return m.recorder
+}
+
+// SetStage mocks base method.
+func (m *MockArticleMigrationService) SetStage(ctx context.Context, stage domain.ArticleMigrationStage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStage", ctx, stage)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStage indicates an expected call of SetStage.
+func (mr *MockArticleMigrationServiceMockRecorder) SetStage(ctx, stage interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStage", reflect.TypeOf((*MockArticleMigrationService)(nil).SetStage), ctx, stage)
+}
+
+// Stage mocks base method.
+func (m *MockArticleMigrationService) Stage(ctx context.Context) (domain.ArticleMigrationStage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stage", ctx)
+	ret0, _ := ret[0].(domain.ArticleMigrationStage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stage indicates an expected call of Stage.
+func (mr *MockArticleMigrationServiceMockRecorder) Stage(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stage", reflect.TypeOf((*MockArticleMigrationService)(nil).Stage), ctx)
+}
+
+// StartBackfill mocks base method.
+func (m *MockArticleMigrationService) StartBackfill(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartBackfill", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartBackfill indicates an expected call of StartBackfill.
+func (mr *MockArticleMigrationServiceMockRecorder) StartBackfill(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartBackfill", reflect.TypeOf((*MockArticleMigrationService)(nil).StartBackfill), ctx)
+}
+
+// StartVerify mocks base method.
+func (m *MockArticleMigrationService) StartVerify(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartVerify", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartVerify indicates an expected call of StartVerify.
+func (mr *MockArticleMigrationServiceMockRecorder) StartVerify(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartVerify", reflect.TypeOf((*MockArticleMigrationService)(nil).StartVerify), ctx)
+}
+
+// SyncStage mocks base method.
+func (m *MockArticleMigrationService) SyncStage(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncStage", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncStage indicates an expected call of SyncStage.
+func (mr *MockArticleMigrationServiceMockRecorder) SyncStage(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncStage", reflect.TypeOf((*MockArticleMigrationService)(nil).SyncStage), ctx)
+}
+
+// VerifyIncremental mocks base method.
+func (m *MockArticleMigrationService) VerifyIncremental(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyIncremental", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyIncremental indicates an expected call of VerifyIncremental.
+func (mr *MockArticleMigrationServiceMockRecorder) VerifyIncremental(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyIncremental", reflect.TypeOf((*MockArticleMigrationService)(nil).VerifyIncremental), ctx)
+}
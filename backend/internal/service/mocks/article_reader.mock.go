@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backend/internal/service/article_reader.go
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleReaderService is a mock of ArticleReaderService interface.
+type MockArticleReaderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleReaderServiceMockRecorder
+}
+
+// MockArticleReaderServiceMockRecorder is the mock recorder for MockArticleReaderService.
+type MockArticleReaderServiceMockRecorder struct {
+	mock *MockArticleReaderService
+}
+
+// NewMockArticleReaderService creates a new mock instance.
+func NewMockArticleReaderService(ctrl *gomock.Controller) *MockArticleReaderService {
+	mock := &MockArticleReaderService{ctrl: ctrl}
+	mock.recorder = &MockArticleReaderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleReaderService) EXPECT() *MockArticleReaderServiceMockRecorder {
+	return m.recorder
+}
+
+// Replay mocks base method.
+func (m *MockArticleReaderService) Replay(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replay", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replay indicates an expected call of Replay.
+func (mr *MockArticleReaderServiceMockRecorder) Replay(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replay", reflect.TypeOf((*MockArticleReaderService)(nil).Replay), ctx)
+}
+
+// StartReplay mocks base method.
+func (m *MockArticleReaderService) StartReplay(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartReplay", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartReplay indicates an expected call of StartReplay.
+func (mr *MockArticleReaderServiceMockRecorder) StartReplay(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartReplay", reflect.TypeOf((*MockArticleReaderService)(nil).StartReplay), ctx)
+}
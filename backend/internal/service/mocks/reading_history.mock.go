@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backend/internal/service/reading_history.go
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/johnwongx/webook/backend/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReadingHistoryService is a mock of ReadingHistoryService interface.
+type MockReadingHistoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadingHistoryServiceMockRecorder
+}
+
+// MockReadingHistoryServiceMockRecorder is the mock recorder for MockReadingHistoryService.
+type MockReadingHistoryServiceMockRecorder struct {
+	mock *MockReadingHistoryService
+}
+
+// NewMockReadingHistoryService creates a new mock instance.
+func NewMockReadingHistoryService(ctrl *gomock.Controller) *MockReadingHistoryService {
+	mock := &MockReadingHistoryService{ctrl: ctrl}
+	mock.recorder = &MockReadingHistoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadingHistoryService) EXPECT() *MockReadingHistoryServiceMockRecorder {
+	return m.recorder
+}
+
+// Clear mocks base method.
+func (m *MockReadingHistoryService) Clear(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockReadingHistoryServiceMockRecorder) Clear(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockReadingHistoryService)(nil).Clear), ctx, uid)
+}
+
+// Delete mocks base method.
+func (m *MockReadingHistoryService) Delete(ctx context.Context, uid int64, aids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, uid, aids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockReadingHistoryServiceMockRecorder) Delete(ctx, uid, aids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockReadingHistoryService)(nil).Delete), ctx, uid, aids)
+}
+
+// ListContinue mocks base method.
+func (m *MockReadingHistoryService) ListContinue(ctx context.Context, uid int64, limit int) ([]domain.ReadingHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListContinue", ctx, uid, limit)
+	ret0, _ := ret[0].([]domain.ReadingHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListContinue indicates an expected call of ListContinue.
+func (mr *MockReadingHistoryServiceMockRecorder) ListContinue(ctx, uid, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListContinue", reflect.TypeOf((*MockReadingHistoryService)(nil).ListContinue), ctx, uid, limit)
+}
+
+// ListRecent mocks base method.
+func (m *MockReadingHistoryService) ListRecent(ctx context.Context, uid int64, offset, limit int) ([]domain.ReadingHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecent", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.ReadingHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecent indicates an expected call of ListRecent.
+func (mr *MockReadingHistoryServiceMockRecorder) ListRecent(ctx, uid, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecent", reflect.TypeOf((*MockReadingHistoryService)(nil).ListRecent), ctx, uid, offset, limit)
+}
+
+// Record mocks base method.
+func (m *MockReadingHistoryService) Record(ctx context.Context, hs []domain.ReadingHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, hs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockReadingHistoryServiceMockRecorder) Record(ctx, hs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockReadingHistoryService)(nil).Record), ctx, hs)
+}
+
+// ReportProgress mocks base method.
+func (m *MockReadingHistoryService) ReportProgress(ctx context.Context, uid, aid int64, progress int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportProgress", ctx, uid, aid, progress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReportProgress indicates an expected call of ReportProgress.
+func (mr *MockReadingHistoryServiceMockRecorder) ReportProgress(ctx, uid, aid, progress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportProgress", reflect.TypeOf((*MockReadingHistoryService)(nil).ReportProgress), ctx, uid, aid, progress)
+}
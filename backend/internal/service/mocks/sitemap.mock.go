@@ -0,0 +1,92 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backend/internal/service/sitemap.go
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSitemapService is a mock of SitemapService interface.
+type MockSitemapService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSitemapServiceMockRecorder
+}
+
+// MockSitemapServiceMockRecorder is the mock recorder for MockSitemapService.
+type MockSitemapServiceMockRecorder struct {
+	mock *MockSitemapService
+}
+
+// NewMockSitemapService creates a new mock instance.
+func NewMockSitemapService(ctrl *gomock.Controller) *MockSitemapService {
+	mock := &MockSitemapService{ctrl: ctrl}
+	mock.recorder = &MockSitemapServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSitemapService) EXPECT() *MockSitemapServiceMockRecorder {
+	return m.recorder
+}
+
+// Generate mocks base method.
+func (m *MockSitemapService) Generate(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generate", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockSitemapServiceMockRecorder) Generate(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockSitemapService)(nil).Generate), ctx)
+}
+
+// Get mocks base method.
+func (m *MockSitemapService) Get(ctx context.Context, name string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, name)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSitemapServiceMockRecorder) Get(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSitemapService)(nil).Get), ctx, name)
+}
+
+// Refresh mocks base method.
+func (m *MockSitemapService) Refresh(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockSitemapServiceMockRecorder) Refresh(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockSitemapService)(nil).Refresh), ctx)
+}
+
+// Robots mocks base method.
+func (m *MockSitemapService) Robots() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Robots")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Robots indicates an expected call of Robots.
+func (mr *MockSitemapServiceMockRecorder) Robots() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Robots", reflect.TypeOf((*MockSitemapService)(nil).Robots))
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backend/internal/service/syndication.go
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/johnwongx/webook/backend/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSyndicationService is a mock of SyndicationService interface.
+type MockSyndicationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyndicationServiceMockRecorder
+}
+
+// MockSyndicationServiceMockRecorder is the mock recorder for MockSyndicationService.
+type MockSyndicationServiceMockRecorder struct {
+	mock *MockSyndicationService
+}
+
+// NewMockSyndicationService creates a new mock instance.
+func NewMockSyndicationService(ctrl *gomock.Controller) *MockSyndicationService {
+	mock := &MockSyndicationService{ctrl: ctrl}
+	mock.recorder = &MockSyndicationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyndicationService) EXPECT() *MockSyndicationServiceMockRecorder {
+	return m.recorder
+}
+
+// AuthorFeed mocks base method.
+func (m *MockSyndicationService) AuthorFeed(ctx context.Context, uid int64, format domain.SyndicationFormat) (domain.SyndicationFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorFeed", ctx, uid, format)
+	ret0, _ := ret[0].(domain.SyndicationFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorFeed indicates an expected call of AuthorFeed.
+func (mr *MockSyndicationServiceMockRecorder) AuthorFeed(ctx, uid, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorFeed", reflect.TypeOf((*MockSyndicationService)(nil).AuthorFeed), ctx, uid, format)
+}
+
+// SiteFeed mocks base method.
+func (m *MockSyndicationService) SiteFeed(ctx context.Context, format domain.SyndicationFormat) (domain.SyndicationFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SiteFeed", ctx, format)
+	ret0, _ := ret[0].(domain.SyndicationFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SiteFeed indicates an expected call of SiteFeed.
+func (mr *MockSyndicationServiceMockRecorder) SiteFeed(ctx, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SiteFeed", reflect.TypeOf((*MockSyndicationService)(nil).SiteFeed), ctx, format)
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backend/internal/service/upload.go
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/johnwongx/webook/backend/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUploadService is a mock of UploadService interface.
+type MockUploadService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadServiceMockRecorder
+}
+
+// MockUploadServiceMockRecorder is the mock recorder for MockUploadService.
+type MockUploadServiceMockRecorder struct {
+	mock *MockUploadService
+}
+
+// NewMockUploadService creates a new mock instance.
+func NewMockUploadService(ctrl *gomock.Controller) *MockUploadService {
+	mock := &MockUploadService{ctrl: ctrl}
+	mock.recorder = &MockUploadServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadService) EXPECT() *MockUploadServiceMockRecorder {
+	return m.recorder
+}
+
+// CollectGarbage mocks base method.
+func (m *MockUploadService) CollectGarbage(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectGarbage", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CollectGarbage indicates an expected call of CollectGarbage.
+func (mr *MockUploadServiceMockRecorder) CollectGarbage(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectGarbage", reflect.TypeOf((*MockUploadService)(nil).CollectGarbage), ctx)
+}
+
+// DeleteReferences mocks base method.
+func (m *MockUploadService) DeleteReferences(ctx context.Context, aids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReferences", ctx, aids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReferences indicates an expected call of DeleteReferences.
+func (mr *MockUploadServiceMockRecorder) DeleteReferences(ctx, aids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReferences", reflect.TypeOf((*MockUploadService)(nil).DeleteReferences), ctx, aids)
+}
+
+// GetImage mocks base method.
+func (m *MockUploadService) GetImage(ctx context.Context, hash string, variant domain.UploadVariant) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImage", ctx, hash, variant)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImage indicates an expected call of GetImage.
+func (mr *MockUploadServiceMockRecorder) GetImage(ctx, hash, variant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImage", reflect.TypeOf((*MockUploadService)(nil).GetImage), ctx, hash, variant)
+}
+
+// SyncReferences mocks base method.
+func (m *MockUploadService) SyncReferences(ctx context.Context, aid int64, pub bool, content string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncReferences", ctx, aid, pub, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncReferences indicates an expected call of SyncReferences.
+func (mr *MockUploadServiceMockRecorder) SyncReferences(ctx, aid, pub, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncReferences", reflect.TypeOf((*MockUploadService)(nil).SyncReferences), ctx, aid, pub, content)
+}
+
+// UploadImage mocks base method.
+func (m *MockUploadService) UploadImage(ctx context.Context, uid int64, data []byte) (domain.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadImage", ctx, uid, data)
+	ret0, _ := ret[0].(domain.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadImage indicates an expected call of UploadImage.
+func (mr *MockUploadServiceMockRecorder) UploadImage(ctx, uid, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadImage", reflect.TypeOf((*MockUploadService)(nil).UploadImage), ctx, uid, data)
+}
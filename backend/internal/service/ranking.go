@@ -0,0 +1,201 @@
+package service
+
+import (
+	"container/heap"
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"math"
+	"time"
+)
+
+type RankingService interface {
+	// TopN 重新计算热榜并保存，由后台任务周期调用
+	TopN(ctx context.Context) error
+	GetTopN(ctx context.Context) ([]domain.HotArticle, error)
+}
+
+// BatchRankingService 分批遍历已发表的文章，用小顶堆维护分数最高的 n 篇
+type BatchRankingService struct {
+	artRepo   repository.ArticleRepository
+	intrRepo  repository.InteractiveRepository
+	userRepo  repository.UserRepository
+	repo      repository.RankingRepository
+	l         logger.Logger
+	biz       string
+	batchSize int
+	n         int
+	// scoreFunc ptime 是第一次发表的时间，之后修改或者重新发表都不影响衰减
+	scoreFunc func(intr domain.Interactive, ptime time.Time) float64
+}
+
+func NewBatchRankingService(artRepo repository.ArticleRepository, intrRepo repository.InteractiveRepository,
+	userRepo repository.UserRepository, repo repository.RankingRepository, l logger.Logger) RankingService {
+	return &BatchRankingService{
+		artRepo:   artRepo,
+		intrRepo:  intrRepo,
+		userRepo:  userRepo,
+		repo:      repo,
+		l:         l,
+		biz:       "article",
+		batchSize: 100,
+		n:         100,
+		scoreFunc: func(intr domain.Interactive, ptime time.Time) float64 {
+			return hotScore(intr, time.Since(ptime))
+		},
+	}
+}
+
+// hotScore 参考 Hacker News 的排名算法，互动越多分数越高，发表越久分数越低
+func hotScore(intr domain.Interactive, age time.Duration) float64 {
+	const (
+		readWeight    = 1
+		likeWeight    = 5
+		collectWeight = 10
+		gravity       = 1.5
+	)
+	weight := float64(intr.ReadCnt)*readWeight +
+		float64(intr.LikeCnt)*likeWeight +
+		float64(intr.CollectCnt)*collectWeight
+	hours := age.Hours()
+	if hours < 0 {
+		hours = 0
+	}
+	return weight / math.Pow(hours+2, gravity)
+}
+
+func (b *BatchRankingService) TopN(ctx context.Context) error {
+	arts, err := b.topN(ctx)
+	if err != nil {
+		return err
+	}
+	b.fillAuthors(ctx, arts)
+	return b.repo.ReplaceTopN(ctx, arts)
+}
+
+func (b *BatchRankingService) GetTopN(ctx context.Context) ([]domain.HotArticle, error) {
+	return b.repo.GetTopN(ctx)
+}
+
+func (b *BatchRankingService) topN(ctx context.Context) ([]domain.HotArticle, error) {
+	h := &hotArticleHeap{}
+	var startId int64
+	for {
+		arts, err := b.artRepo.ListPub(ctx, startId, b.batchSize)
+		if err != nil {
+			return nil, err
+		}
+		pubs := slice.FilterMap[domain.Article, domain.Article](arts, func(idx int, src domain.Article) (domain.Article, bool) {
+			return src, src.Status == domain.ArticleStatusPublished
+		})
+		ids := slice.Map[domain.Article, int64](pubs, func(idx int, src domain.Article) int64 {
+			return src.Id
+		})
+		intrs := map[int64]domain.Interactive{}
+		if len(ids) > 0 {
+			intrs, err = b.intrRepo.GetByIds(ctx, b.biz, ids)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, art := range pubs {
+			intr := intrs[art.Id]
+			// 线上库的创建时间就是第一次发表的时间
+			score := b.scoreFunc(intr, art.Ctime)
+			if score <= 0 {
+				// 没有任何互动的文章不上榜
+				continue
+			}
+			b.push(h, domain.HotArticle{
+				Article: domain.Article{
+					Id:     art.Id,
+					Title:  art.Title,
+					Author: art.Author,
+					Status: art.Status,
+					Ctime:  art.Ctime,
+					Utime:  art.Utime,
+				},
+				Intr:  intr,
+				Score: score,
+			})
+		}
+		if len(arts) < b.batchSize {
+			break
+		}
+		startId = arts[len(arts)-1].Id
+	}
+
+	// 堆顶是分数最低的，倒着取出来就是从高到低
+	res := make([]domain.HotArticle, h.Len())
+	for i := len(res) - 1; i >= 0; i-- {
+		res[i] = heap.Pop(h).(domain.HotArticle)
+	}
+	return res, nil
+}
+
+func (b *BatchRankingService) push(h *hotArticleHeap, art domain.HotArticle) {
+	if h.Len() < b.n {
+		heap.Push(h, art)
+		return
+	}
+	if hotArticleLess((*h)[0], art) {
+		(*h)[0] = art
+		heap.Fix(h, 0)
+	}
+}
+
+// fillAuthors 补充作者昵称，同一个作者只查一次
+func (b *BatchRankingService) fillAuthors(ctx context.Context, arts []domain.HotArticle) {
+	names := make(map[int64]string)
+	for i := range arts {
+		uid := arts[i].Article.Author.Id
+		name, ok := names[uid]
+		if !ok {
+			user, err := b.userRepo.FindById(ctx, uid)
+			if err != nil {
+				b.l.Error("获取用户信息失败",
+					logger.Int64("author", uid), logger.Error(err))
+			}
+			name = user.NickName
+			names[uid] = name
+		}
+		arts[i].Article.Author.Name = name
+	}
+}
+
+// hotArticleLess 分数相同时，发表早的排在后面
+func hotArticleLess(a, b domain.HotArticle) bool {
+	if a.Score != b.Score {
+		return a.Score < b.Score
+	}
+	return a.Article.Ctime.Before(b.Article.Ctime)
+}
+
+// hotArticleHeap 小顶堆
+type hotArticleHeap []domain.HotArticle
+
+func (h hotArticleHeap) Len() int {
+	return len(h)
+}
+
+func (h hotArticleHeap) Less(i, j int) bool {
+	return hotArticleLess(h[i], h[j])
+}
+
+func (h hotArticleHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *hotArticleHeap) Push(x any) {
+	*h = append(*h, x.(domain.HotArticle))
+}
+
+func (h *hotArticleHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
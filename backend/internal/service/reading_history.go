@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"time"
+)
+
+var ErrInvalidReadingProgress = errors.New("阅读进度不合法")
+
+// readingHistoryCap 每个用户最多保留的阅读记录数，超出的删掉最早读的
+const readingHistoryCap = 500
+
+type ReadingHistoryService interface {
+	// Record 批量记录阅读事件，未登录用户的忽略
+	Record(ctx context.Context, hs []domain.ReadingHistory) error
+	// ReportProgress 上报阅读进度，progress 是 0 到 100 的百分比，以最后一次上报的为准
+	ReportProgress(ctx context.Context, uid, aid int64, progress int) error
+	// ListRecent 最近读过的文章
+	ListRecent(ctx context.Context, uid int64, offset, limit int) ([]domain.ReadingHistory, error)
+	// ListContinue 读了一部分还没有读完的文章，已经下线的不返回
+	ListContinue(ctx context.Context, uid int64, limit int) ([]domain.ReadingHistory, error)
+	Delete(ctx context.Context, uid int64, aids []int64) error
+	Clear(ctx context.Context, uid int64) error
+}
+
+type readingHistoryService struct {
+	r       repository.ReadingHistoryRepository
+	artRepo repository.ArticleRepository
+	l       logger.Logger
+}
+
+func NewReadingHistoryService(r repository.ReadingHistoryRepository,
+	artRepo repository.ArticleRepository, l logger.Logger) ReadingHistoryService {
+	return &readingHistoryService{
+		r:       r,
+		artRepo: artRepo,
+		l:       l,
+	}
+}
+
+func (s *readingHistoryService) Record(ctx context.Context, hs []domain.ReadingHistory) error {
+	res := make([]domain.ReadingHistory, 0, len(hs))
+	uids := make(map[int64]struct{}, len(hs))
+	for _, h := range hs {
+		if h.Uid <= 0 || h.Aid <= 0 {
+			continue
+		}
+		res = append(res, h)
+		uids[h.Uid] = struct{}{}
+	}
+	err := s.r.Record(ctx, res)
+	if err != nil {
+		return err
+	}
+	for uid := range uids {
+		s.trim(ctx, uid)
+	}
+	return nil
+}
+
+func (s *readingHistoryService) ReportProgress(ctx context.Context, uid, aid int64, progress int) error {
+	if progress < 0 || progress > 100 {
+		return ErrInvalidReadingProgress
+	}
+	art, err := s.artRepo.GetPubById(ctx, aid)
+	if err != nil {
+		return err
+	}
+	if art.Status != domain.ArticleStatusPublished {
+		return ErrArticleNotFound
+	}
+	now := time.Now()
+	err = s.r.UpdateProgress(ctx, domain.ReadingHistory{
+		Uid:      uid,
+		Aid:      aid,
+		Progress: progress,
+		Ctime:    now,
+		Utime:    now,
+	})
+	if err != nil {
+		return err
+	}
+	s.trim(ctx, uid)
+	return nil
+}
+
+func (s *readingHistoryService) ListRecent(ctx context.Context, uid int64, offset, limit int) ([]domain.ReadingHistory, error) {
+	hs, err := s.r.List(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range hs {
+		// 文章已经下线或者查询失败，只保留 id
+		art, ok := s.article(ctx, hs[i].Aid)
+		if ok {
+			hs[i].Article = art
+		}
+	}
+	return hs, nil
+}
+
+func (s *readingHistoryService) ListContinue(ctx context.Context, uid int64, limit int) ([]domain.ReadingHistory, error) {
+	hs, err := s.r.ListUnfinished(ctx, uid, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.ReadingHistory, 0, len(hs))
+	for _, h := range hs {
+		art, ok := s.article(ctx, h.Aid)
+		if !ok {
+			continue
+		}
+		h.Article = art
+		res = append(res, h)
+	}
+	return res, nil
+}
+
+func (s *readingHistoryService) Delete(ctx context.Context, uid int64, aids []int64) error {
+	return s.r.Delete(ctx, uid, aids)
+}
+
+func (s *readingHistoryService) Clear(ctx context.Context, uid int64) error {
+	return s.r.Clear(ctx, uid)
+}
+
+// article 已发表的文章，下线了或者查询失败时返回 false
+func (s *readingHistoryService) article(ctx context.Context, aid int64) (domain.Article, bool) {
+	art, err := s.artRepo.GetPubById(ctx, aid)
+	switch err {
+	case nil:
+		return art, art.Status == domain.ArticleStatusPublished
+	case repository.ErrArticleNotFound:
+	default:
+		s.l.Error("获取读过的文章失败", logger.Int64("aid", aid), logger.Error(err))
+	}
+	return domain.Article{}, false
+}
+
+// trim 超出上限的部分下次写入时还会再删，失败了只记录日志
+func (s *readingHistoryService) trim(ctx context.Context, uid int64) {
+	err := s.r.Trim(ctx, uid, readingHistoryCap)
+	if err != nil {
+		s.l.Error("删除多余的阅读记录失败", logger.Int64("uid", uid), logger.Error(err))
+	}
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository"
+	"strings"
+)
+
+type SearchService interface {
+	SearchArticles(ctx context.Context, q string, offset, limit int) (domain.ArticleSearchResult, error)
+	// RebuildArticleIndex 用线上库重建文章索引，进程内索引在启动时和定期调用
+	RebuildArticleIndex(ctx context.Context) error
+}
+
+type searchService struct {
+	repo repository.ArticleSearchRepository
+}
+
+func NewSearchService(repo repository.ArticleSearchRepository) SearchService {
+	return &searchService{
+		repo: repo,
+	}
+}
+
+func (s *searchService) SearchArticles(ctx context.Context, q string, offset, limit int) (domain.ArticleSearchResult, error) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return domain.ArticleSearchResult{}, nil
+	}
+	return s.repo.Search(ctx, q, offset, limit)
+}
+
+func (s *searchService) RebuildArticleIndex(ctx context.Context) error {
+	return s.repo.RebuildIndex(ctx)
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+)
+
+var (
+	ErrSeriesNotFound         = repository.ErrSeriesNotFound
+	ErrArticleInSeries        = repository.ErrArticleInSeries
+	ErrSeriesArticlesMismatch = repository.ErrSeriesArticlesMismatch
+)
+
+type SeriesService interface {
+	Create(ctx context.Context, s domain.Series) (int64, error)
+	// ListByAuthor 作者的专栏，不包含文章
+	ListByAuthor(ctx context.Context, uid int64) ([]domain.Series, error)
+	// AddArticle 把自己的文章追加到自己的专栏末尾
+	AddArticle(ctx context.Context, sid, aid, uid int64) error
+	RemoveArticle(ctx context.Context, sid, aid, uid int64) error
+	// Reorder aids 必须正好是专栏里的全部文章
+	Reorder(ctx context.Context, sid, uid int64, aids []int64) error
+	// GetPub 读者看到的专栏，只包含已发表的文章
+	GetPub(ctx context.Context, sid int64) (domain.Series, error)
+	// NavOfArticle 已发表文章在专栏中的位置和前后篇，不属于任何专栏时返回 ErrSeriesNotFound
+	NavOfArticle(ctx context.Context, aid int64) (domain.SeriesNav, error)
+}
+
+type seriesService struct {
+	r        repository.SeriesRepository
+	artRepo  repository.ArticleRepository
+	userRepo repository.UserRepository
+	l        logger.Logger
+}
+
+func NewSeriesService(r repository.SeriesRepository, artRepo repository.ArticleRepository,
+	userRepo repository.UserRepository, l logger.Logger) SeriesService {
+	return &seriesService{
+		r:        r,
+		artRepo:  artRepo,
+		userRepo: userRepo,
+		l:        l,
+	}
+}
+
+func (s *seriesService) Create(ctx context.Context, series domain.Series) (int64, error) {
+	return s.r.Create(ctx, series)
+}
+
+func (s *seriesService) ListByAuthor(ctx context.Context, uid int64) ([]domain.Series, error) {
+	return s.r.FindByAuthor(ctx, uid)
+}
+
+func (s *seriesService) AddArticle(ctx context.Context, sid, aid, uid int64) error {
+	err := s.checkOwner(ctx, sid, uid)
+	if err != nil {
+		return err
+	}
+	// 只能加入自己的文章
+	_, err = s.artRepo.GetById(ctx, aid, uid)
+	if err != nil {
+		return err
+	}
+	return s.r.AddArticle(ctx, sid, aid)
+}
+
+func (s *seriesService) RemoveArticle(ctx context.Context, sid, aid, uid int64) error {
+	err := s.checkOwner(ctx, sid, uid)
+	if err != nil {
+		return err
+	}
+	return s.r.RemoveArticle(ctx, sid, aid)
+}
+
+func (s *seriesService) Reorder(ctx context.Context, sid, uid int64, aids []int64) error {
+	err := s.checkOwner(ctx, sid, uid)
+	if err != nil {
+		return err
+	}
+	return s.r.Reorder(ctx, sid, aids)
+}
+
+// checkOwner 不属于该作者的专栏当作不存在
+func (s *seriesService) checkOwner(ctx context.Context, sid, uid int64) error {
+	series, err := s.r.FindById(ctx, sid)
+	if err != nil {
+		return err
+	}
+	if series.Author.Id != uid {
+		return ErrSeriesNotFound
+	}
+	return nil
+}
+
+func (s *seriesService) GetPub(ctx context.Context, sid int64) (domain.Series, error) {
+	series, err := s.r.FindById(ctx, sid)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	user, err := s.userRepo.FindById(ctx, series.Author.Id)
+	if err != nil {
+		// 作者信息缺失不影响展示专栏
+		s.l.Error("获取专栏作者信息失败",
+			logger.Int64("series", sid), logger.Int64("author", series.Author.Id), logger.Error(err))
+	}
+	series.Author.Name = user.NickName
+
+	aids, err := s.r.ArticleIds(ctx, sid)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	series.Articles = make([]domain.Article, 0, len(aids))
+	for _, aid := range aids {
+		art, er := s.artRepo.GetPubById(ctx, aid)
+		if er == repository.ErrArticleNotFound {
+			// 还没有发表过
+			continue
+		}
+		if er != nil {
+			return domain.Series{}, er
+		}
+		if art.Status != domain.ArticleStatusPublished {
+			continue
+		}
+		series.Articles = append(series.Articles, art)
+	}
+	return series, nil
+}
+
+func (s *seriesService) NavOfArticle(ctx context.Context, aid int64) (domain.SeriesNav, error) {
+	sid, err := s.r.FindIdByArticle(ctx, aid)
+	if err != nil {
+		return domain.SeriesNav{}, err
+	}
+	series, err := s.GetPub(ctx, sid)
+	if err != nil {
+		return domain.SeriesNav{}, err
+	}
+	for i, art := range series.Articles {
+		if art.Id != aid {
+			continue
+		}
+		nav := domain.SeriesNav{
+			Series:   series,
+			Position: i + 1,
+			Total:    len(series.Articles),
+		}
+		if i > 0 {
+			nav.Prev = series.Articles[i-1]
+		}
+		if i+1 < len(series.Articles) {
+			nav.Next = series.Articles[i+1]
+		}
+		return nav, nil
+	}
+	// 文章本身没有发表，读者看不到
+	return domain.SeriesNav{}, ErrSeriesNotFound
+}
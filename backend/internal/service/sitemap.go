@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/johnwongx/webook/backend/pkg/sitemap"
+	"regexp"
+	"sync/atomic"
+	"time"
+)
+
+var ErrSitemapNotFound = repository.ErrSitemapNotFound
+
+const (
+	// SitemapIndexName sitemap 索引的文件名，站点地址下的 /sitemap.xml
+	SitemapIndexName = "sitemap.xml"
+	// SitemapPathPrefix 分页的 sitemap 文件的访问路径
+	SitemapPathPrefix = "/sitemaps/"
+	// sitemapFileSize 每个 sitemap 文件包含的文章数量，远小于协议上限，方便搜索引擎抓取
+	sitemapFileSize  = 10000
+	sitemapBatchSize = 1000
+	// sitemapMaxAge 没有文章发表或下线时，也定期重新生成，修正遗漏的变化
+	sitemapMaxAge = time.Hour * 6
+)
+
+var sitemapFileRegexp = regexp.MustCompile(`^sitemap-[1-9][0-9]*\.xml$`)
+
+type SitemapService interface {
+	// Refresh 有文章发表或下线，或者距离上次生成超过 sitemapMaxAge 时重新生成，由后台任务周期调用
+	Refresh(ctx context.Context) error
+	// Generate 遍历所有已发表的文章，重新生成 sitemap 文件和索引
+	Generate(ctx context.Context) error
+	// Get name 为 SitemapIndexName 或者索引中的文件名，不存在时返回 ErrSitemapNotFound
+	Get(ctx context.Context, name string) ([]byte, error)
+	// Robots robots.txt 的内容，指向 sitemap 索引
+	Robots() string
+}
+
+type sitemapService struct {
+	r       repository.SitemapRepository
+	artRepo repository.ArticleRepository
+	site    domain.Site
+	// generated 本实例上次生成的时间
+	generated atomic.Int64
+	l         logger.Logger
+}
+
+func NewSitemapService(r repository.SitemapRepository, artRepo repository.ArticleRepository,
+	site domain.Site, l logger.Logger) SitemapService {
+	return &sitemapService{
+		r:       r,
+		artRepo: artRepo,
+		site:    site,
+		l:       l,
+	}
+}
+
+func (s *sitemapService) Refresh(ctx context.Context) error {
+	changed, err := s.r.TakeChanged(ctx)
+	if err != nil {
+		// 拿不到标记就按过期时间判断
+		s.l.Error("获取 sitemap 更新标记失败", logger.Error(err))
+	}
+	expired := time.Since(time.UnixMilli(s.generated.Load())) >= sitemapMaxAge
+	if !changed && !expired {
+		return nil
+	}
+	err = s.Generate(ctx)
+	if err != nil && changed {
+		// 标记已经被取走了，放回去下次重试
+		er := s.r.MarkChanged(ctx)
+		if er != nil {
+			s.l.Error("恢复 sitemap 更新标记失败", logger.Error(er))
+		}
+	}
+	return err
+}
+
+func (s *sitemapService) Generate(ctx context.Context) error {
+	var (
+		index   []sitemap.URL
+		urls    = make([]sitemap.URL, 0, sitemapFileSize)
+		lastMod time.Time
+		startId int64
+	)
+	flush := func() error {
+		name := fmt.Sprintf("sitemap-%d.xml", len(index)+1)
+		data, err := sitemap.URLSet(urls)
+		if err != nil {
+			return err
+		}
+		err = s.r.Save(ctx, name, data)
+		if err != nil {
+			return err
+		}
+		index = append(index, sitemap.URL{Loc: s.fileURL(name), LastMod: lastMod})
+		urls = urls[:0]
+		lastMod = time.Time{}
+		return nil
+	}
+	for {
+		arts, err := s.artRepo.ScanPub(ctx, startId, sitemapBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, art := range arts {
+			urls = append(urls, sitemap.URL{Loc: s.site.ArticleURL(art.Id), LastMod: art.Utime})
+			if art.Utime.After(lastMod) {
+				lastMod = art.Utime
+			}
+			if len(urls) == sitemapFileSize {
+				if err = flush(); err != nil {
+					return err
+				}
+			}
+		}
+		if len(arts) < sitemapBatchSize {
+			break
+		}
+		startId = arts[len(arts)-1].Id
+	}
+	if len(urls) > 0 {
+		if err := flush(); err != nil {
+			return err
+		}
+	}
+
+	// 分页文件都写好了再写索引，索引中的文件一定存在
+	data, err := sitemap.Index(index)
+	if err != nil {
+		return err
+	}
+	err = s.r.Save(ctx, SitemapIndexName, data)
+	if err != nil {
+		return err
+	}
+	s.generated.Store(time.Now().UnixMilli())
+	s.removeStale(ctx, len(index)+1)
+	return nil
+}
+
+// removeStale 文章变少后，删除索引中不再引用的分页文件
+func (s *sitemapService) removeStale(ctx context.Context, from int) {
+	for i := from; ; i++ {
+		name := fmt.Sprintf("sitemap-%d.xml", i)
+		_, err := s.r.Get(ctx, name)
+		if err == repository.ErrSitemapNotFound {
+			return
+		}
+		if err == nil {
+			err = s.r.Delete(ctx, name)
+		}
+		if err != nil {
+			s.l.Error("删除过期的 sitemap 文件失败",
+				logger.String("name", name), logger.Error(err))
+			return
+		}
+	}
+}
+
+func (s *sitemapService) Get(ctx context.Context, name string) ([]byte, error) {
+	if name != SitemapIndexName && !sitemapFileRegexp.MatchString(name) {
+		return nil, ErrSitemapNotFound
+	}
+	return s.r.Get(ctx, name)
+}
+
+func (s *sitemapService) Robots() string {
+	return "User-agent: *\nAllow: /\n\nSitemap: " + s.site.URL + "/" + SitemapIndexName + "\n"
+}
+
+func (s *sitemapService) fileURL(name string) string {
+	return s.site.URL + SitemapPathPrefix + name
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/johnwongx/webook/backend/pkg/syndication"
+)
+
+// syndicationSize 订阅源中最多包含的文章数量
+const syndicationSize = 20
+
+// SyndicationService 作者和全站的 RSS、Atom 订阅源
+type SyndicationService interface {
+	// AuthorFeed 作者最近发表的文章，作者不存在时返回 ErrUserNotFound
+	AuthorFeed(ctx context.Context, uid int64, format domain.SyndicationFormat) (domain.SyndicationFeed, error)
+	// SiteFeed 全站最近发表的文章
+	SiteFeed(ctx context.Context, format domain.SyndicationFormat) (domain.SyndicationFeed, error)
+}
+
+type syndicationService struct {
+	r        repository.SyndicationRepository
+	artRepo  repository.ArticleRepository
+	userRepo repository.UserRepository
+	site     domain.Site
+	l        logger.Logger
+}
+
+func NewSyndicationService(r repository.SyndicationRepository, artRepo repository.ArticleRepository,
+	userRepo repository.UserRepository, site domain.Site, l logger.Logger) SyndicationService {
+	return &syndicationService{
+		r:        r,
+		artRepo:  artRepo,
+		userRepo: userRepo,
+		site:     site,
+		l:        l,
+	}
+}
+
+func (s *syndicationService) AuthorFeed(ctx context.Context, uid int64, format domain.SyndicationFormat) (domain.SyndicationFeed, error) {
+	return s.cached(ctx, uid, format, func() (syndication.Feed, error) {
+		user, err := s.userRepo.FindById(ctx, uid)
+		if err != nil {
+			return syndication.Feed{}, err
+		}
+		arts, err := s.artRepo.ListPubByAuthor(ctx, uid, domain.ArticleCursor{}, syndicationSize)
+		if err != nil {
+			return syndication.Feed{}, err
+		}
+		names := map[int64]string{uid: user.NickName}
+		return syndication.Feed{
+			Title:       fmt.Sprintf("%s - %s", user.NickName, s.site.Title),
+			Link:        s.site.AuthorURL(uid),
+			Description: user.SelfIntroduction,
+			Items:       s.items(arts, names),
+		}, nil
+	})
+}
+
+func (s *syndicationService) SiteFeed(ctx context.Context, format domain.SyndicationFormat) (domain.SyndicationFeed, error) {
+	return s.cached(ctx, 0, format, func() (syndication.Feed, error) {
+		arts, err := s.artRepo.ListLatestPub(ctx, syndicationSize)
+		if err != nil {
+			return syndication.Feed{}, err
+		}
+		names := make(map[int64]string, len(arts))
+		for _, art := range arts {
+			uid := art.Author.Id
+			if _, ok := names[uid]; ok {
+				continue
+			}
+			user, err := s.userRepo.FindById(ctx, uid)
+			if err != nil {
+				// 缺少作者名不影响订阅
+				s.l.Error("获取作者信息失败",
+					logger.Int64("author", uid), logger.Error(err))
+			}
+			names[uid] = user.NickName
+		}
+		return syndication.Feed{
+			Title:       s.site.Title,
+			Link:        s.site.URL,
+			Description: s.site.Description,
+			Items:       s.items(arts, names),
+		}, nil
+	})
+}
+
+// cached 先查缓存，没有时调用 build 生成并写回缓存
+func (s *syndicationService) cached(ctx context.Context, uid int64, format domain.SyndicationFormat,
+	build func() (syndication.Feed, error)) (domain.SyndicationFeed, error) {
+	res, err := s.r.Get(ctx, uid, format)
+	if err == nil {
+		return res, nil
+	}
+	if err != repository.ErrSyndicationNotCached {
+		s.l.Error("获取订阅源缓存失败",
+			logger.Int64("author", uid), logger.Error(err))
+	}
+
+	f, err := build()
+	if err != nil {
+		return domain.SyndicationFeed{}, err
+	}
+	var body []byte
+	if format == domain.SyndicationFormatAtom {
+		body, err = syndication.Atom(f)
+	} else {
+		body, err = syndication.RSS(f)
+	}
+	if err != nil {
+		return domain.SyndicationFeed{}, err
+	}
+	sum := sha256.Sum256(body)
+	res = domain.SyndicationFeed{
+		Format:       format,
+		Body:         body,
+		ETag:         `"` + hex.EncodeToString(sum[:16]) + `"`,
+		LastModified: f.LastModified(),
+	}
+	err = s.r.Save(ctx, uid, res)
+	if err != nil {
+		s.l.Error("缓存订阅源失败",
+			logger.Int64("author", uid), logger.Error(err))
+	}
+	return res, nil
+}
+
+// items 有渲染结果时带上正文，否则只有摘要
+func (s *syndicationService) items(arts []domain.Article, names map[int64]string) []syndication.Item {
+	res := make([]syndication.Item, 0, len(arts))
+	for _, art := range arts {
+		res = append(res, syndication.Item{
+			Link:      s.site.ArticleURL(art.Id),
+			Title:     art.Title,
+			Author:    names[art.Author.Id],
+			Summary:   art.Abstract(),
+			Content:   art.Rendered.HTML,
+			Published: art.Ctime,
+			Updated:   art.Utime,
+		})
+	}
+	return res
+}
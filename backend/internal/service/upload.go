@@ -0,0 +1,219 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/repository"
+	"github.com/johnwongx/webook/backend/pkg/imagex"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+var (
+	ErrUploadNotFound = repository.ErrUploadNotFound
+	// ErrUploadTooLarge 文件或者像素超过限制
+	ErrUploadTooLarge        = errors.New("图片太大")
+	ErrUploadUnsupportedType = errors.New("不支持的图片格式")
+	ErrUploadInvalidImage    = errors.New("图片无法解析")
+)
+
+const (
+	// MaxUploadSize 单张图片的大小上限
+	MaxUploadSize = 10 << 20
+	// maxUploadPixels 防止很小的文件解码出超大的图片
+	maxUploadPixels = 40_000_000
+	webMaxWidth     = 1280
+	thumbMaxWidth   = 320
+	jpegQuality     = 85
+	// uploadGCGrace 上传后还没保存到文章里的图片，超过该时间才会被回收
+	uploadGCGrace = time.Hour * 24
+)
+
+// ImageURLPrefix 图片的访问路径，正文中按它识别引用了哪些图片
+const ImageURLPrefix = "/images/"
+
+var imageRefPattern = regexp.MustCompile(regexp.QuoteMeta(ImageURLPrefix) + `([0-9a-f]{64})/`)
+var imageHashPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// ImageURL 图片稳定的访问地址，内容不变地址就不变
+func ImageURL(hash string, variant domain.UploadVariant) string {
+	return ImageURLPrefix + hash + "/" + string(variant)
+}
+
+type UploadService interface {
+	// UploadImage 校验、去掉元数据并生成不同尺寸，相同内容返回已有的图片
+	UploadImage(ctx context.Context, uid int64, data []byte) (domain.Upload, error)
+	GetImage(ctx context.Context, hash string, variant domain.UploadVariant) ([]byte, error)
+	// SyncReferences 根据正文记录文章草稿或线上版本引用的图片
+	SyncReferences(ctx context.Context, aid int64, pub bool, content string) error
+	DeleteReferences(ctx context.Context, aids []int64) error
+	// CollectGarbage 回收没有被任何文章引用的图片，由后台任务周期调用
+	CollectGarbage(ctx context.Context) error
+}
+
+type uploadService struct {
+	r repository.UploadRepository
+	l logger.Logger
+}
+
+func NewUploadService(r repository.UploadRepository, l logger.Logger) UploadService {
+	return &uploadService{
+		r: r,
+		l: l,
+	}
+}
+
+func (u *uploadService) UploadImage(ctx context.Context, uid int64, data []byte) (domain.Upload, error) {
+	if len(data) > MaxUploadSize {
+		return domain.Upload{}, ErrUploadTooLarge
+	}
+	contentType := http.DetectContentType(data)
+	if contentType != "image/jpeg" && contentType != "image/png" {
+		return domain.Upload{}, ErrUploadUnsupportedType
+	}
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	res, err := u.r.FindByHash(ctx, hash)
+	switch err {
+	case nil:
+		return res, nil
+	case repository.ErrUploadNotFound:
+	default:
+		return domain.Upload{}, err
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return domain.Upload{}, ErrUploadInvalidImage
+	}
+	if cfg.Width*cfg.Height > maxUploadPixels {
+		return domain.Upload{}, ErrUploadTooLarge
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return domain.Upload{}, ErrUploadInvalidImage
+	}
+
+	// 重新编码会丢掉 EXIF、文本块等元数据
+	res = domain.Upload{
+		Uid:         uid,
+		Hash:        hash,
+		ContentType: contentType,
+		Width:       cfg.Width,
+		Height:      cfg.Height,
+	}
+	variants := map[domain.UploadVariant]image.Image{
+		domain.UploadVariantOriginal: img,
+		domain.UploadVariantWeb:      imagex.Fit(img, webMaxWidth),
+		domain.UploadVariantThumb:    imagex.Fit(img, thumbMaxWidth),
+	}
+	// 先写文件再写记录，记录存在就说明文件已经齐全
+	for variant, vImg := range variants {
+		encoded, err := u.encode(vImg, contentType)
+		if err != nil {
+			return domain.Upload{}, err
+		}
+		if variant == domain.UploadVariantOriginal {
+			res.Size = int64(len(encoded))
+		}
+		err = u.r.PutContent(ctx, hash, variant, encoded, contentType)
+		if err != nil {
+			return domain.Upload{}, err
+		}
+	}
+	res.Id, err = u.r.Create(ctx, res)
+	if err == repository.ErrUploadDuplicate {
+		// 同时上传了相同的图片
+		return u.r.FindByHash(ctx, hash)
+	}
+	res.Ctime = time.Now()
+	return res, err
+}
+
+func (u *uploadService) encode(img image.Image, contentType string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if contentType == "image/png" {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	}
+	return buf.Bytes(), err
+}
+
+func (u *uploadService) GetImage(ctx context.Context, hash string, variant domain.UploadVariant) ([]byte, error) {
+	if !imageHashPattern.MatchString(hash) || !variant.Valid() {
+		return nil, ErrUploadNotFound
+	}
+	data, err := u.r.GetContent(ctx, hash, variant)
+	if err == repository.ErrUploadContentNotFound {
+		return nil, ErrUploadNotFound
+	}
+	return data, err
+}
+
+func (u *uploadService) SyncReferences(ctx context.Context, aid int64, pub bool, content string) error {
+	var hashes []string
+	seen := make(map[string]struct{})
+	for _, m := range imageRefPattern.FindAllStringSubmatch(content, -1) {
+		if _, ok := seen[m[1]]; ok {
+			continue
+		}
+		seen[m[1]] = struct{}{}
+		hashes = append(hashes, m[1])
+	}
+	uploads, err := u.r.FindByHashes(ctx, hashes)
+	if err != nil {
+		return err
+	}
+	ids := slice.Map[domain.Upload, int64](uploads, func(idx int, src domain.Upload) int64 {
+		return src.Id
+	})
+	return u.r.SetReferences(ctx, aid, pub, ids)
+}
+
+func (u *uploadService) DeleteReferences(ctx context.Context, aids []int64) error {
+	return u.r.DeleteReferences(ctx, aids)
+}
+
+func (u *uploadService) CollectGarbage(ctx context.Context) error {
+	const batchSize = 100
+	before := time.Now().Add(-uploadGCGrace)
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		uploads, err := u.r.ListUnreferenced(ctx, before, batchSize)
+		if err != nil {
+			return err
+		}
+		for _, upload := range uploads {
+			// 列出之后可能又被引用了，删除时再确认一次
+			ok, err := u.r.DeleteUnreferenced(ctx, upload.Id)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			err = u.r.DeleteContent(ctx, upload.Hash)
+			if err != nil {
+				u.l.Error("删除图片文件失败",
+					logger.Int64("id", upload.Id), logger.String("hash", upload.Hash), logger.Error(err))
+			}
+		}
+		if len(uploads) < batchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,344 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/service"
+	myjwt "github.com/johnwongx/webook/backend/internal/web/jwt"
+	"github.com/johnwongx/webook/backend/pkg/ginx"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"net/http"
+	"time"
+)
+
+// AdminSet 判断用户是否是管理员
+type AdminSet interface {
+	IsAdmin(uid int64) bool
+}
+
+// AdminHandler 管理后台，所有接口都需要管理员权限
+type AdminHandler struct {
+	artSvc         service.ArticleService
+	migrationSvc   service.ArticleMigrationService
+	consistencySvc service.ArticleConsistencyService
+	readerSvc      service.ArticleReaderService
+	admins         AdminSet
+	l              logger.Logger
+}
+
+func NewAdminHandler(artSvc service.ArticleService, migrationSvc service.ArticleMigrationService,
+	consistencySvc service.ArticleConsistencyService, readerSvc service.ArticleReaderService,
+	admins AdminSet, l logger.Logger) *AdminHandler {
+	return &AdminHandler{
+		artSvc:         artSvc,
+		migrationSvc:   migrationSvc,
+		consistencySvc: consistencySvc,
+		readerSvc:      readerSvc,
+		admins:         admins,
+		l:              l,
+	}
+}
+
+func (a *AdminHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/admin", a.checkAdmin)
+	g.GET("/articles/reviews", ginx.WrapReq[AdminReviewListReq](a.ListReviews, a.l))
+	g.POST("/articles/reviews/approve", ginx.WrapReq[AdminReviewReq](a.ApproveReview, a.l))
+	g.POST("/articles/reviews/reject", ginx.WrapReq[AdminReviewReq](a.RejectReview, a.l))
+	g.GET("/articles/migration", ginx.Wrap(a.MigrationStage, a.l))
+	g.POST("/articles/migration/stage", ginx.WrapReq[AdminMigrationStageReq](a.SetMigrationStage, a.l))
+	g.POST("/articles/migration/backfill", ginx.Wrap(a.StartBackfill, a.l))
+	g.POST("/articles/migration/verify", ginx.Wrap(a.StartVerify, a.l))
+	g.GET("/articles/consistency", ginx.Wrap(a.ConsistencyReport, a.l))
+	g.POST("/articles/consistency/check", ginx.WrapReq[AdminConsistencyCheckReq](a.StartConsistencyCheck, a.l))
+	g.POST("/articles/reader/replay", ginx.Wrap(a.StartReaderReplay, a.l))
+}
+
+func (a *AdminHandler) checkAdmin(ctx *gin.Context) {
+	val, ok := ctx.Get("claims")
+	if !ok {
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	uc, ok := val.(myjwt.UserClaim)
+	if !ok || !a.admins.IsAdmin(uc.UserId) {
+		ctx.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+}
+
+type AdminReviewListReq struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+type AdminReviewReq struct {
+	Id int64 `json:"id"`
+}
+
+// ArticleReviewVO 待审核的文章，Words 为命中的敏感词
+type ArticleReviewVO struct {
+	ArticleVO
+	AuthorId int64    `json:"author_id"`
+	Words    []string `json:"words"`
+}
+
+func (a *AdminHandler) ListReviews(ctx *gin.Context, req AdminReviewListReq) (ginx.Result, error) {
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	res, err := a.artSvc.ListPendingReview(ctx, req.Offset, req.Limit)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map[domain.ArticleReview, ArticleReviewVO](res, func(idx int, src domain.ArticleReview) ArticleReviewVO {
+			return ArticleReviewVO{
+				ArticleVO: ArticleVO{
+					Id:       src.Article.Id,
+					Title:    src.Article.Title,
+					Content:  src.Article.Content,
+					Status:   src.Article.Status.ToUint8(),
+					Version:  src.Article.Version,
+					Tags:     src.Article.Tags,
+					Category: src.Article.Category,
+					Ctime:    src.Article.Ctime.Format(time.DateTime),
+					Utime:    src.Article.Utime.Format(time.DateTime),
+				},
+				AuthorId: src.Article.Author.Id,
+				Words:    src.Words,
+			}
+		}),
+	}, nil
+}
+
+func (a *AdminHandler) ApproveReview(ctx *gin.Context, req AdminReviewReq) (ginx.Result, error) {
+	return a.reviewResult(a.artSvc.ApproveReview(ctx, req.Id))
+}
+
+func (a *AdminHandler) RejectReview(ctx *gin.Context, req AdminReviewReq) (ginx.Result, error) {
+	return a.reviewResult(a.artSvc.RejectReview(ctx, req.Id))
+}
+
+func (a *AdminHandler) reviewResult(err error) (ginx.Result, error) {
+	switch err {
+	case nil:
+		return ginx.Result{Msg: "OK"}, nil
+	case service.ErrArticleNotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "文章不存在或不在等待审核",
+		}, nil
+	case service.ErrArticleVersionConflict:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "作者已修改文章，请刷新后重新审核",
+		}, nil
+	case service.ErrArticleSensitive:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "文章包含违规内容，只能驳回",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
+
+type AdminMigrationStageReq struct {
+	// Stage src_only、src_first、dst_first 或 dst_only
+	Stage string `json:"stage"`
+}
+
+type MigrationStageVO struct {
+	Stage string `json:"stage"`
+}
+
+func (a *AdminHandler) MigrationStage(ctx *gin.Context) (ginx.Result, error) {
+	stage, err := a.migrationSvc.Stage(ctx)
+	if err != nil {
+		return a.migrationResult(err)
+	}
+	return ginx.Result{
+		Data: MigrationStageVO{Stage: stage.String()},
+	}, nil
+}
+
+func (a *AdminHandler) SetMigrationStage(ctx *gin.Context, req AdminMigrationStageReq) (ginx.Result, error) {
+	stage, ok := domain.ParseArticleMigrationStage(req.Stage)
+	if !ok {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "未知的迁移阶段",
+		}, nil
+	}
+	return a.migrationResult(a.migrationSvc.SetStage(ctx, stage))
+}
+
+// StartBackfill 在后台运行，进度和结果见日志
+func (a *AdminHandler) StartBackfill(ctx *gin.Context) (ginx.Result, error) {
+	return a.migrationResult(a.migrationSvc.StartBackfill(ctx))
+}
+
+// StartVerify 在后台运行，进度和结果见日志
+func (a *AdminHandler) StartVerify(ctx *gin.Context) (ginx.Result, error) {
+	return a.migrationResult(a.migrationSvc.StartVerify(ctx))
+}
+
+func (a *AdminHandler) migrationResult(err error) (ginx.Result, error) {
+	switch err {
+	case nil:
+		return ginx.Result{Msg: "OK"}, nil
+	case service.ErrArticleMigrationDisabled:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "没有开启文章存储迁移",
+		}, nil
+	case service.ErrArticleMigrationStageSkip:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "只能切换到相邻的迁移阶段",
+		}, nil
+	case service.ErrArticleMigrationBadStage:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "目标存储已经是主存储，不能全量复制",
+		}, nil
+	case service.ErrArticleMigrationRunning:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "已经有迁移任务在运行",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
+
+type AdminConsistencyCheckReq struct {
+	// Repair 是否以制作库为准修复
+	Repair bool `json:"repair"`
+}
+
+type ConsistencyReportVO struct {
+	Start    string         `json:"start"`
+	End      string         `json:"end"`
+	Repair   bool           `json:"repair"`
+	Scanned  int            `json:"scanned"`
+	Counts   map[string]int `json:"counts"`
+	Drifted  int            `json:"drifted"`
+	Repaired int            `json:"repaired"`
+	Records  []DriftVO      `json:"records"`
+	// Truncated 不一致的文章太多，records 只有前面一部分
+	Truncated bool   `json:"truncated"`
+	Error     string `json:"error,omitempty"`
+}
+
+type DriftVO struct {
+	ArticleId   int64    `json:"article_id"`
+	AuthorId    int64    `json:"author_id"`
+	Drifts      []string `json:"drifts"`
+	DraftStatus uint8    `json:"draft_status"`
+	PubStatus   uint8    `json:"pub_status"`
+	DraftHash   string   `json:"draft_hash"`
+	PubHash     string   `json:"pub_hash"`
+	Repaired    bool     `json:"repaired"`
+	Error       string   `json:"error,omitempty"`
+}
+
+func (a *AdminHandler) ConsistencyReport(ctx *gin.Context) (ginx.Result, error) {
+	r, err := a.consistencySvc.LatestReport(ctx)
+	switch err {
+	case nil:
+	case service.ErrArticleConsistencyNoReport:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "还没有检查过",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	counts := make(map[string]int, len(r.Counts))
+	for k, v := range r.Counts {
+		counts[string(k)] = v
+	}
+	return ginx.Result{
+		Data: ConsistencyReportVO{
+			Start:    r.Start.Format(time.DateTime),
+			End:      r.End.Format(time.DateTime),
+			Repair:   r.Repair,
+			Scanned:  r.Scanned,
+			Counts:   counts,
+			Drifted:  r.Drifted,
+			Repaired: r.Repaired,
+			Records: slice.Map[domain.ArticleDriftRecord, DriftVO](r.Records, func(idx int, src domain.ArticleDriftRecord) DriftVO {
+				return DriftVO{
+					ArticleId: src.ArticleId,
+					AuthorId:  src.AuthorId,
+					Drifts: slice.Map[domain.ArticleDrift, string](src.Drifts, func(idx int, d domain.ArticleDrift) string {
+						return string(d)
+					}),
+					DraftStatus: src.DraftStatus.ToUint8(),
+					PubStatus:   src.PubStatus.ToUint8(),
+					DraftHash:   src.DraftHash,
+					PubHash:     src.PubHash,
+					Repaired:    src.Repaired,
+					Error:       src.Error,
+				}
+			}),
+			Truncated: r.Truncated,
+			Error:     r.Error,
+		},
+	}, nil
+}
+
+// StartConsistencyCheck 在后台运行，结果见报告
+func (a *AdminHandler) StartConsistencyCheck(ctx *gin.Context, req AdminConsistencyCheckReq) (ginx.Result, error) {
+	err := a.consistencySvc.StartCheck(ctx, req.Repair)
+	switch err {
+	case nil:
+		return ginx.Result{Msg: "OK"}, nil
+	case service.ErrArticleConsistencyRunning:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "一致性检查正在运行",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
+
+// StartReaderReplay 在后台用作者侧重建读者侧，进度和结果见日志
+func (a *AdminHandler) StartReaderReplay(ctx *gin.Context) (ginx.Result, error) {
+	err := a.readerSvc.StartReplay(ctx)
+	switch err {
+	case nil:
+		return ginx.Result{Msg: "OK"}, nil
+	case service.ErrArticleReplayRunning:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "读者侧正在重放",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
@@ -0,0 +1,73 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	svcmocks "github.com/johnwongx/webook/backend/internal/service/mocks"
+	myjwt "github.com/johnwongx/webook/backend/internal/web/jwt"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testAdminSet map[int64]bool
+
+func (s testAdminSet) IsAdmin(uid int64) bool {
+	return s[uid]
+}
+
+func TestAdminHandler_checkAdmin(t *testing.T) {
+	testCases := []struct {
+		name   string
+		claims *myjwt.UserClaim
+
+		wantCode int
+	}{
+		{
+			name:     "管理员",
+			claims:   &myjwt.UserClaim{UserId: 1},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "不是管理员",
+			claims:   &myjwt.UserClaim{UserId: 2},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "没有登录信息",
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			as := svcmocks.NewMockArticleService(ctrl)
+			if tc.wantCode == http.StatusOK {
+				as.EXPECT().ListPendingReview(gomock.Any(), 0, 20).Return([]domain.ArticleReview{}, nil)
+			}
+			h := NewAdminHandler(as, nil, nil, nil, testAdminSet{1: true}, &logger.NopLogger{})
+			server := gin.New()
+			server.Use(func(ctx *gin.Context) {
+				if tc.claims != nil {
+					ctx.Set("claims", *tc.claims)
+				}
+			})
+			h.RegisterRoutes(server)
+
+			req, err := http.NewRequest(http.MethodGet, "/admin/articles/reviews", nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, tc.wantCode, resp.Code)
+		})
+	}
+}
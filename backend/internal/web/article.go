@@ -16,21 +16,23 @@ import (
 )
 
 type ArticleHandler struct {
-	svc      service.ArticleService
-	interSvc service.InteractiveService
-	l        logger.Logger
-	biz      string
-	producer article.Producer
+	svc       service.ArticleService
+	interSvc  service.InteractiveService
+	seriesSvc service.SeriesService
+	l         logger.Logger
+	biz       string
+	producer  article.Producer
 }
 
 func NewArticleHandler(svc service.ArticleService, interSvc service.InteractiveService,
-	logger logger.Logger, producer article.Producer) *ArticleHandler {
+	seriesSvc service.SeriesService, logger logger.Logger, producer article.Producer) *ArticleHandler {
 	return &ArticleHandler{
-		svc:      svc,
-		interSvc: interSvc,
-		l:        logger,
-		biz:      "article",
-		producer: producer,
+		svc:       svc,
+		interSvc:  interSvc,
+		seriesSvc: seriesSvc,
+		l:         logger,
+		biz:       "article",
+		producer:  producer,
 	}
 }
 
@@ -39,13 +41,24 @@ func (a *ArticleHandler) RegisterRutes(s *gin.Engine) {
 	g.POST("/edit", a.Edit)
 	g.POST("/publish", a.Publish)
 	g.POST("/withdraw", ginx.WrapReq[WithdrawReq](a.Withdraw, a.l))
+	g.POST("/delete", ginx.WrapReqToken[TrashReq, myjwt.UserClaim](a.Delete, a.l))
+	g.POST("/restore", ginx.WrapReqToken[TrashReq, myjwt.UserClaim](a.Restore, a.l))
+	g.GET("/trash", ginx.WrapReqToken[TrashListReq, myjwt.UserClaim](a.ListTrash, a.l))
+	g.GET("/schedules", ginx.WrapToken[myjwt.UserClaim](a.ListSchedules, a.l))
+	g.POST("/schedules/cancel", ginx.WrapReqToken[CancelScheduleReq, myjwt.UserClaim](a.CancelSchedule, a.l))
 	g.GET("/list", ginx.WrapReqToken[ListReq, myjwt.UserClaim](a.List, a.l))
 	g.GET("/detail/:id", ginx.WrapToken[myjwt.UserClaim](a.Detail, a.l))
+	g.GET("/:id/revisions", ginx.WrapReqToken[RevisionListReq, myjwt.UserClaim](a.ListRevisions, a.l))
+	g.GET("/:id/revisions/diff", ginx.WrapReqToken[RevisionDiffReq, myjwt.UserClaim](a.DiffRevisions, a.l))
+	g.GET("/:id/revisions/:rid", ginx.WrapToken[myjwt.UserClaim](a.GetRevision, a.l))
+	g.POST("/:id/revisions/:rid/restore", ginx.WrapToken[myjwt.UserClaim](a.RestoreRevision, a.l))
 
 	pub := s.Group("/pub")
 	pub.GET("/:id", ginx.WrapToken[myjwt.UserClaim](a.PubDetail, a.l))
 	pub.POST("/like", ginx.WrapReqToken[LikeReq, myjwt.UserClaim](a.Like, a.l))
 	pub.POST("/collect", ginx.WrapReqToken[CollectReq, myjwt.UserClaim](a.Collect, a.l))
+	pub.GET("/tags", ginx.WrapToken[myjwt.UserClaim](a.TagCounts, a.l))
+	pub.GET("/tags/:tag", ginx.WrapReqToken[TagArticleListReq, myjwt.UserClaim](a.ListByTag, a.l))
 }
 
 func (a *ArticleHandler) Withdraw(ctx *gin.Context, req WithdrawReq) (ginx.Result, error) {
@@ -57,7 +70,11 @@ func (a *ArticleHandler) Withdraw(ctx *gin.Context, req WithdrawReq) (ginx.Resul
 		}, errors.New("系统错误")
 	}
 
-	err := a.svc.Withdraw(ctx, req.Id, usr.UserId)
+	var opts []service.WithdrawOption
+	if req.WithdrawAt > 0 {
+		opts = append(opts, service.WithdrawAt(time.UnixMilli(req.WithdrawAt)))
+	}
+	err := a.svc.Withdraw(ctx, req.Id, usr.UserId, opts...)
 	if err != nil {
 		return ginx.Result{
 			Code: 5,
@@ -70,11 +87,84 @@ func (a *ArticleHandler) Withdraw(ctx *gin.Context, req WithdrawReq) (ginx.Resul
 	}, nil
 }
 
+func (a *ArticleHandler) Delete(ctx *gin.Context, req TrashReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	err := a.svc.Delete(ctx, req.Id, uc.UserId)
+	switch err {
+	case nil:
+		return ginx.Result{Data: req.Id}, nil
+	case service.ErrArticleNotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "文章不存在",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
+
+func (a *ArticleHandler) Restore(ctx *gin.Context, req TrashReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	err := a.svc.Restore(ctx, req.Id, uc.UserId)
+	switch err {
+	case nil:
+		return ginx.Result{Data: req.Id}, nil
+	case service.ErrArticleNotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "文章不在回收站中或已超过保留期限",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
+
+func (a *ArticleHandler) ListTrash(ctx *gin.Context, req TrashListReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+	if req.Limit <= 0 || req.Limit > 50 {
+		req.Limit = 10
+	}
+	arts, err := a.svc.ListTrash(ctx, uc.UserId, req.Offset, req.Limit)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map[domain.Article, ArticleVO](arts, func(idx int, src domain.Article) ArticleVO {
+			return ArticleVO{
+				Id:       src.Id,
+				Title:    src.Title,
+				Abstract: src.Abstract(),
+				Status:   src.Status.ToUint8(),
+				Ctime:    src.Ctime.Format(time.DateTime),
+				Utime:    src.Utime.Format(time.DateTime),
+				Dtime:    src.Dtime.Format(time.DateTime),
+				PurgeAt:  src.Dtime.Add(service.TrashRetention).Format(time.DateTime),
+			}
+		}),
+	}, nil
+}
+
 func (a *ArticleHandler) Publish(ctx *gin.Context) {
 	var req ArticleReq
 	if err := ctx.Bind(&req); err != nil {
 		return
 	}
+	if msg, ok := req.checkMeta(); !ok {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  msg,
+		})
+		return
+	}
 
 	usr, ok := ctx.MustGet("claims").(myjwt.UserClaim)
 	if !ok {
@@ -86,7 +176,28 @@ func (a *ArticleHandler) Publish(ctx *gin.Context) {
 		return
 	}
 
-	id, err := a.svc.Publish(ctx, req.toDomain(usr.UserId))
+	var opts []service.PublishOption
+	if req.PublishAt > 0 {
+		opts = append(opts, service.PublishAt(time.UnixMilli(req.PublishAt)))
+	}
+	id, err := a.svc.Publish(ctx, req.toDomain(usr.UserId), opts...)
+	switch err {
+	case service.ErrArticleVersionConflict:
+		a.versionConflict(ctx, req.Id, usr.UserId)
+		return
+	case service.ErrArticleSensitive:
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "文章包含违规内容，无法发表",
+		})
+		return
+	case service.ErrArticlePendingReview:
+		ctx.JSON(http.StatusOK, Result{
+			Msg:  "文章已提交审核，审核通过后自动发表",
+			Data: id,
+		})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, Result{
 			Code: 5,
@@ -106,6 +217,13 @@ func (a *ArticleHandler) Edit(ctx *gin.Context) {
 	if err := ctx.Bind(&req); err != nil {
 		return
 	}
+	if msg, ok := req.checkMeta(); !ok {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  msg,
+		})
+		return
+	}
 
 	usr, ok := ctx.MustGet("claims").(myjwt.UserClaim)
 	if !ok {
@@ -118,6 +236,10 @@ func (a *ArticleHandler) Edit(ctx *gin.Context) {
 	}
 
 	id, err := a.svc.Save(ctx, req.toDomain(usr.UserId))
+	if err == service.ErrArticleVersionConflict {
+		a.versionConflict(ctx, req.Id, usr.UserId)
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, Result{
 			Code: 5,
@@ -132,8 +254,45 @@ func (a *ArticleHandler) Edit(ctx *gin.Context) {
 	})
 }
 
+// versionConflict 返回冲突码和服务端当前的草稿，由前端决定覆盖还是合并
+func (a *ArticleHandler) versionConflict(ctx *gin.Context, id, uid int64) {
+	art, err := a.svc.GetById(ctx, id, uid)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		a.l.Error("获取当前文章失败",
+			logger.Int64("id", id), logger.Int64("author", uid), logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Code: ArticleCodeVersionConflict,
+		Msg:  "文章已在其他地方被修改",
+		Data: ArticleVO{
+			Id:      art.Id,
+			Title:   art.Title,
+			Status:  art.Status.ToUint8(),
+			Content: art.Content,
+			Version: art.Version,
+			Ctime:   art.Ctime.Format(time.DateTime),
+			Utime:   art.Utime.Format(time.DateTime),
+		},
+	})
+}
+
 func (a *ArticleHandler) List(ctx *gin.Context, req ListReq, uc myjwt.UserClaim) (ginx.Result, error) {
-	res, err := a.svc.List(ctx, uc.UserId, req.Offset, req.Limit)
+	cursor, err := domain.ParseArticleCursor(req.Cursor)
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, err
+	}
+	if req.Limit <= 0 || req.Limit > 50 {
+		req.Limit = 10
+	}
+	res, next, err := a.svc.List(ctx, uc.UserId, cursor, req.Limit)
 	if err != nil {
 		return ginx.Result{
 			Code: 5,
@@ -142,20 +301,23 @@ func (a *ArticleHandler) List(ctx *gin.Context, req ListReq, uc myjwt.UserClaim)
 	}
 
 	return ginx.Result{
-		Data: slice.Map[domain.Article, ArticleVO](res, func(idx int, src domain.Article) ArticleVO {
-			return ArticleVO{
-				Id:       src.Id,
-				Title:    src.Title,
-				Abstract: src.Abstract(),
-				Status:   src.Status.ToUint8(),
-				// 列表无需返回内容
-				//Content: src.Content,
-				// 创作者文章列表，无需该字段
-				//Author: src.Author,
-				Ctime: src.Ctime.Format(time.DateTime),
-				Utime: src.Utime.Format(time.DateTime),
-			}
-		}),
+		Data: ArticleListVO{
+			List: slice.Map[domain.Article, ArticleVO](res, func(idx int, src domain.Article) ArticleVO {
+				return ArticleVO{
+					Id:       src.Id,
+					Title:    src.Title,
+					Abstract: src.Abstract(),
+					Status:   src.Status.ToUint8(),
+					// 列表无需返回内容
+					//Content: src.Content,
+					// 创作者文章列表，无需该字段
+					//Author: src.Author,
+					Ctime: src.Ctime.Format(time.DateTime),
+					Utime: src.Utime.Format(time.DateTime),
+				}
+			}),
+			NextCursor: next.Encode(),
+		},
 	}, nil
 }
 
@@ -175,8 +337,11 @@ func (a *ArticleHandler) Detail(ctx *gin.Context, uc myjwt.UserClaim) (ginx.Resu
 			Title: art.Title,
 			// 不需要摘要信息
 			//Abstract: art.Abstract(),
-			Status:  art.Status.ToUint8(),
-			Content: art.Content,
+			Status:   art.Status.ToUint8(),
+			Content:  art.Content,
+			Version:  art.Version,
+			Tags:     art.Tags,
+			Category: art.Category,
 			// 创作者文章列表，无需该字段
 			//Author: art.Author.Name,
 			Ctime: art.Ctime.Format(time.DateTime),
@@ -203,9 +368,10 @@ func (a *ArticleHandler) PubDetail(ctx *gin.Context, uc myjwt.UserClaim) (ginx.R
 
 	go func() {
 		er := a.producer.ProduceReadEvent(ctx, article.ReadEvent{
-			Uid: uc.UserId,
-			Aid: id,
-			Biz: a.biz,
+			Uid:  uc.UserId,
+			Aid:  id,
+			Biz:  a.biz,
+			Time: time.Now().UnixMilli(),
 		})
 		if er != nil {
 			a.l.Error("增加阅读计数失败",
@@ -253,22 +419,43 @@ func (a *ArticleHandler) PubDetail(ctx *gin.Context, uc myjwt.UserClaim) (ginx.R
 		}
 	}()
 
+	var series *SeriesNavVO
+	nav, err := a.seriesSvc.NavOfArticle(ctx, id)
+	switch err {
+	case nil:
+		series = newSeriesNavVO(nav)
+	case service.ErrSeriesNotFound:
+	default:
+		// 专栏导航获取失败不影响阅读
+		a.l.Error("获取专栏导航失败",
+			logger.Int64("id", id), logger.Error(err))
+	}
+
 	return ginx.Result{
 		Data: ArticleVO{
 			Id:    art.Id,
 			Title: art.Title,
 			// 不需要摘要信息
 			//Abstract: art.Abstract(),
-			Status:  art.Status.ToUint8(),
-			Content: art.Content,
+			Status:   art.Status.ToUint8(),
+			Content:  art.Content,
+			Tags:     art.Tags,
+			Category: art.Category,
+
+			HTML:       art.Rendered.HTML,
+			TOC:        newTOCVO(art.Rendered.TOC),
+			FirstImage: art.Rendered.FirstImage,
 
 			ReadCnt:    intr.ReadCnt,
 			LikeCnt:    intr.LikeCnt,
 			CollectCnt: intr.CollectCnt,
+			CommentCnt: intr.CommentCnt,
 
 			Liked:     liked,
 			Collected: collected,
 
+			Series: series,
+
 			// 创作者文章列表，无需该字段
 			Author: art.Author.Name,
 			Ctime:  art.Ctime.Format(time.DateTime),
@@ -303,3 +490,228 @@ func (a *ArticleHandler) Collect(ctx *gin.Context, req CollectReq, uc myjwt.User
 	}
 	return ginx.Result{Msg: "收藏成功"}, nil
 }
+
+func (a *ArticleHandler) ListRevisions(ctx *gin.Context, req RevisionListReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, err
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 10
+	}
+	revs, err := a.svc.ListRevisions(ctx, id, uc.UserId, req.Offset, req.Limit)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map[domain.ArticleRevision, RevisionVO](revs, func(idx int, src domain.ArticleRevision) RevisionVO {
+			// 列表无需返回内容
+			return newRevisionVO(src)
+		}),
+	}, nil
+}
+
+func (a *ArticleHandler) GetRevision(ctx *gin.Context, uc myjwt.UserClaim) (ginx.Result, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, err
+	}
+	rid, err := strconv.ParseInt(ctx.Param("rid"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, err
+	}
+	rev, err := a.svc.GetRevision(ctx, id, rid, uc.UserId)
+	switch err {
+	case nil:
+	case service.ErrArticleNotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "历史版本不存在",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	vo := newRevisionVO(rev)
+	vo.Content = rev.Content
+	return ginx.Result{Data: vo}, nil
+}
+
+func (a *ArticleHandler) DiffRevisions(ctx *gin.Context, req RevisionDiffReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, err
+	}
+	res, err := a.svc.DiffRevisions(ctx, id, uc.UserId, req.From, req.To)
+	switch err {
+	case nil:
+	case service.ErrArticleNotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "历史版本不存在",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{
+		Data: RevisionDiffVO{
+			From: newRevisionVO(res.From),
+			To:   newRevisionVO(res.To),
+			Lines: slice.Map[domain.DiffLine, DiffLineVO](res.Lines, func(idx int, src domain.DiffLine) DiffLineVO {
+				return DiffLineVO{
+					Op:   diffOpSymbols[src.Op],
+					Text: src.Text,
+				}
+			}),
+		},
+	}, nil
+}
+
+func (a *ArticleHandler) RestoreRevision(ctx *gin.Context, uc myjwt.UserClaim) (ginx.Result, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, err
+	}
+	rid, err := strconv.ParseInt(ctx.Param("rid"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, err
+	}
+	id, err = a.svc.RestoreRevision(ctx, id, rid, uc.UserId)
+	switch err {
+	case nil:
+	case service.ErrArticleNotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "历史版本不存在",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{Data: id}, nil
+}
+
+func (a *ArticleHandler) ListSchedules(ctx *gin.Context, uc myjwt.UserClaim) (ginx.Result, error) {
+	res, err := a.svc.ListSchedules(ctx, uc.UserId)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map[domain.ArticleSchedule, ScheduleVO](res, func(idx int, src domain.ArticleSchedule) ScheduleVO {
+			return ScheduleVO{
+				Id:        src.Id,
+				ArticleId: src.ArticleId,
+				Action:    src.Action.ToUint8(),
+				ExecuteAt: src.ExecuteAt.Format(time.DateTime),
+				Ctime:     src.Ctime.Format(time.DateTime),
+			}
+		}),
+	}, nil
+}
+
+func (a *ArticleHandler) CancelSchedule(ctx *gin.Context, req CancelScheduleReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	err := a.svc.CancelSchedule(ctx, req.Id, uc.UserId)
+	switch err {
+	case nil:
+		return ginx.Result{Msg: "取消成功"}, nil
+	case service.ErrScheduleNotPending:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "任务已执行或已取消",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
+
+func (a *ArticleHandler) ListByTag(ctx *gin.Context, req TagArticleListReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	tag := ctx.Param("tag")
+	if tag == "" {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, nil
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 10
+	}
+	res, err := a.svc.ListPubByTag(ctx, tag, req.Offset, req.Limit)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map[domain.Article, ArticleVO](res, func(idx int, src domain.Article) ArticleVO {
+			return ArticleVO{
+				Id:       src.Id,
+				Title:    src.Title,
+				Abstract: src.Abstract(),
+				Status:   src.Status.ToUint8(),
+				Tags:     src.Tags,
+				Category: src.Category,
+				Ctime:    src.Ctime.Format(time.DateTime),
+				Utime:    src.Utime.Format(time.DateTime),
+			}
+		}),
+	}, nil
+}
+
+func (a *ArticleHandler) TagCounts(ctx *gin.Context, uc myjwt.UserClaim) (ginx.Result, error) {
+	res, err := a.svc.TagCounts(ctx)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map[domain.TagCount, TagCountVO](res, func(idx int, src domain.TagCount) TagCountVO {
+			return TagCountVO{
+				Tag:   src.Tag,
+				Count: src.Count,
+			}
+		}),
+	}, nil
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/service"
 	svcmocks "github.com/johnwongx/webook/backend/internal/service/mocks"
 	myjwt "github.com/johnwongx/webook/backend/internal/web/jwt"
 	"github.com/johnwongx/webook/backend/pkg/logger"
@@ -121,7 +122,7 @@ func TestArticleHandler_Publish(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			svc := tc.mock(ctrl)
-			hdl := NewArticleHandler(svc, &logger.NopLogger{})
+			hdl := NewArticleHandler(svc, nil, nil, &logger.NopLogger{}, nil)
 
 			server := gin.Default()
 			server.Use(func(ctx *gin.Context) {
@@ -152,3 +153,116 @@ func TestArticleHandler_Publish(t *testing.T) {
 		})
 	}
 }
+
+func TestArticleHandler_Revisions(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) *svcmocks.MockArticleService
+		method  string
+		url     string
+		wantMsg Result
+	}{
+		{
+			name: "分页参数不合法，使用默认值",
+			mock: func(ctrl *gomock.Controller) *svcmocks.MockArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().ListRevisions(gomock.Any(), int64(1), int64(123), 0, 10).
+					Return([]domain.ArticleRevision{}, nil)
+				return svc
+			},
+			method: http.MethodGet,
+			url:    "/articles/1/revisions?offset=-1&limit=1000000",
+			wantMsg: Result{
+				Data: []any{},
+			},
+		},
+		{
+			name: "历史版本不存在",
+			mock: func(ctrl *gomock.Controller) *svcmocks.MockArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().GetRevision(gomock.Any(), int64(1), int64(9), int64(123)).
+					Return(domain.ArticleRevision{}, service.ErrArticleNotFound)
+				return svc
+			},
+			method: http.MethodGet,
+			url:    "/articles/1/revisions/9",
+			wantMsg: Result{
+				Code: 4,
+				Msg:  "历史版本不存在",
+			},
+		},
+		{
+			name: "查询历史版本出错",
+			mock: func(ctrl *gomock.Controller) *svcmocks.MockArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().GetRevision(gomock.Any(), int64(1), int64(9), int64(123)).
+					Return(domain.ArticleRevision{}, errors.New("db error"))
+				return svc
+			},
+			method: http.MethodGet,
+			url:    "/articles/1/revisions/9",
+			wantMsg: Result{
+				Code: 5,
+				Msg:  "系统错误",
+			},
+		},
+		{
+			name: "对比别人文章的历史版本",
+			mock: func(ctrl *gomock.Controller) *svcmocks.MockArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().DiffRevisions(gomock.Any(), int64(1), int64(123), int64(2), int64(3)).
+					Return(domain.ArticleRevisionDiff{}, service.ErrArticleNotFound)
+				return svc
+			},
+			method: http.MethodGet,
+			url:    "/articles/1/revisions/diff?from=2&to=3",
+			wantMsg: Result{
+				Code: 4,
+				Msg:  "历史版本不存在",
+			},
+		},
+		{
+			name: "恢复不存在的历史版本",
+			mock: func(ctrl *gomock.Controller) *svcmocks.MockArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().RestoreRevision(gomock.Any(), int64(1), int64(9), int64(123)).
+					Return(int64(0), service.ErrArticleNotFound)
+				return svc
+			},
+			method: http.MethodPost,
+			url:    "/articles/1/revisions/9/restore",
+			wantMsg: Result{
+				Code: 4,
+				Msg:  "历史版本不存在",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			hdl := NewArticleHandler(tc.mock(ctrl), nil, nil, &logger.NopLogger{}, nil)
+
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("claims", myjwt.UserClaim{
+					UserId: 123,
+				})
+				ctx.Next()
+			})
+			hdl.RegisterRutes(server)
+
+			req, err := http.NewRequest(tc.method, tc.url, nil)
+			assert.NoError(t, err)
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var res Result
+			err = json.Unmarshal(resp.Body.Bytes(), &res)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantMsg, res)
+		})
+	}
+}
@@ -1,6 +1,14 @@
 package web
 
-import "github.com/johnwongx/webook/backend/internal/domain"
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"time"
+	"unicode/utf8"
+)
+
+// ArticleCodeVersionConflict 草稿已在其他地方被修改，Data 中返回服务端当前的版本
+const ArticleCodeVersionConflict = 409
 
 // VO: view object 对标前端
 type ArticleVO struct {
@@ -10,16 +18,94 @@ type ArticleVO struct {
 	Content  string `json:"content"`
 	Author   string `json:"author"`
 	Status   uint8  `json:"status"`
+	Version  int64  `json:"version"`
+
+	Tags     []string `json:"tags,omitempty"`
+	Category string   `json:"category,omitempty"`
+
+	// HTML 由 Content 渲染并过滤得到，前端可以直接展示
+	HTML       string      `json:"html,omitempty"`
+	TOC        []TOCItemVO `json:"toc,omitempty"`
+	FirstImage string      `json:"first_image,omitempty"`
 
 	ReadCnt    int64 `json:"read_cnt"`
 	LikeCnt    int64 `json:"like_cnt"`
 	CollectCnt int64 `json:"collect_cnt"`
+	CommentCnt int64 `json:"comment_cnt"`
 
 	Liked     bool `json:"liked"`
 	Collected bool `json:"collected"`
 
+	// Series 文章属于某个专栏时返回专栏和前后篇
+	Series *SeriesNavVO `json:"series,omitempty"`
+
 	Ctime string `json:"ctime"`
 	Utime string `json:"utime"`
+	// Dtime 移入回收站的时间，PurgeAt 之后彻底删除，只有回收站列表返回
+	Dtime   string `json:"dtime,omitempty"`
+	PurgeAt string `json:"purge_at,omitempty"`
+}
+
+type TOCItemVO struct {
+	Level  int    `json:"level"`
+	Title  string `json:"title"`
+	Anchor string `json:"anchor"`
+}
+
+func newTOCVO(toc []domain.TOCItem) []TOCItemVO {
+	return slice.Map[domain.TOCItem, TOCItemVO](toc, func(idx int, src domain.TOCItem) TOCItemVO {
+		return TOCItemVO{
+			Level:  src.Level,
+			Title:  src.Title,
+			Anchor: src.Anchor,
+		}
+	})
+}
+
+type RevisionVO struct {
+	Id        int64  `json:"id"`
+	ArticleId int64  `json:"article_id"`
+	Title     string `json:"title"`
+	Content   string `json:"content,omitempty"`
+	Status    uint8  `json:"status"`
+	Ctime     string `json:"ctime"`
+}
+
+func newRevisionVO(rev domain.ArticleRevision) RevisionVO {
+	return RevisionVO{
+		Id:        rev.Id,
+		ArticleId: rev.ArticleId,
+		Title:     rev.Title,
+		Status:    rev.Status.ToUint8(),
+		Ctime:     rev.Ctime.Format(time.DateTime),
+	}
+}
+
+var diffOpSymbols = map[domain.DiffOp]string{
+	domain.DiffOpEqual:  " ",
+	domain.DiffOpInsert: "+",
+	domain.DiffOpDelete: "-",
+}
+
+type DiffLineVO struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+type RevisionDiffVO struct {
+	From  RevisionVO   `json:"from"`
+	To    RevisionVO   `json:"to"`
+	Lines []DiffLineVO `json:"lines"`
+}
+
+type RevisionListReq struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+type RevisionDiffReq struct {
+	From int64 `form:"from"`
+	To   int64 `form:"to"`
 }
 
 type CollectReq struct {
@@ -33,18 +119,87 @@ type LikeReq struct {
 }
 
 type ListReq struct {
-	Offset int `json:"offset"`
-	Limit  int `json:"limit"`
+	// Cursor 上一页返回的 next_cursor，第一页不传
+	Cursor string `json:"cursor" form:"cursor"`
+	Limit  int    `json:"limit" form:"limit"`
+}
+
+// ArticleListVO 游标分页的文章列表，NextCursor 为空表示没有下一页了
+type ArticleListVO struct {
+	List       []ArticleVO `json:"list"`
+	NextCursor string      `json:"next_cursor"`
+}
+
+type TrashReq struct {
+	Id int64 `json:"id"`
+}
+
+type TrashListReq struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
 }
 
 type WithdrawReq struct {
 	Id int64 `json:"id"`
+	// 定时撤回的时间，毫秒时间戳，不传则立即撤回
+	WithdrawAt int64 `json:"withdraw_at"`
 }
 
 type ArticleReq struct {
 	Id      int64  `json:"id"`
 	Title   string `json:"title"`
 	Content string `json:"content"`
+	// 客户端编辑时基于的版本号，不传则不校验
+	Version int64 `json:"version"`
+	// 定时发表的时间，毫秒时间戳，不传则立即发表
+	PublishAt int64 `json:"publish_at"`
+	// 不传表示不修改标签，传空数组表示清空标签
+	Tags []string `json:"tags"`
+	// 不传表示不修改分类
+	Category string `json:"category"`
+}
+
+const (
+	maxTagsPerArticle = 10
+	maxTagLength      = 64
+)
+
+// checkMeta 校验标签和分类，不通过时返回给前端的提示
+func (a *ArticleReq) checkMeta() (string, bool) {
+	if len(a.Tags) > maxTagsPerArticle {
+		return "标签数量不能超过10个", false
+	}
+	for _, t := range a.Tags {
+		if utf8.RuneCountInString(t) > maxTagLength {
+			return "标签长度不能超过64个字符", false
+		}
+	}
+	if utf8.RuneCountInString(a.Category) > maxTagLength {
+		return "分类长度不能超过64个字符", false
+	}
+	return "", true
+}
+
+type TagArticleListReq struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+type TagCountVO struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
+
+type ScheduleVO struct {
+	Id        int64  `json:"id"`
+	ArticleId int64  `json:"article_id"`
+	Action    uint8  `json:"action"`
+	ExecuteAt string `json:"execute_at"`
+	Ctime     string `json:"ctime"`
+}
+
+type CancelScheduleReq struct {
+	Id int64 `json:"id"`
 }
 
 func (a *ArticleReq) toDomain(uid int64) domain.Article {
@@ -55,5 +210,8 @@ func (a *ArticleReq) toDomain(uid int64) domain.Article {
 		Author: domain.Author{
 			Id: uid,
 		},
+		Version:  a.Version,
+		Tags:     a.Tags,
+		Category: a.Category,
 	}
 }
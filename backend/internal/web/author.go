@@ -0,0 +1,138 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/service"
+	"github.com/johnwongx/webook/backend/pkg/ginx"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// AuthorHandler 作者主页，读者可以看到作者的公开资料和已发表的文章
+type AuthorHandler struct {
+	artSvc   service.ArticleService
+	userSvc  service.UserService
+	interSvc service.InteractiveService
+	l        logger.Logger
+	biz      string
+}
+
+func NewAuthorHandler(artSvc service.ArticleService, userSvc service.UserService,
+	interSvc service.InteractiveService, l logger.Logger) *AuthorHandler {
+	return &AuthorHandler{
+		artSvc:   artSvc,
+		userSvc:  userSvc,
+		interSvc: interSvc,
+		l:        l,
+		biz:      "article",
+	}
+}
+
+func (a *AuthorHandler) RegisterRoutes(server *gin.Engine) {
+	server.GET("/pub/authors/:uid", ginx.WrapReq[AuthorHomeReq](a.Home, a.l))
+}
+
+type AuthorHomeReq struct {
+	// Cursor 上一页返回的 next_cursor，第一页不传
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit"`
+}
+
+// AuthorProfileVO 作者的公开资料，不包含邮箱、手机号等隐私信息
+type AuthorProfileVO struct {
+	Id               int64  `json:"id"`
+	NickName         string `json:"nick_name"`
+	SelfIntroduction string `json:"self_introduction"`
+}
+
+type AuthorHomeVO struct {
+	Author   AuthorProfileVO `json:"author"`
+	Articles []ArticleVO     `json:"articles"`
+	// NextCursor 为空表示没有下一页了
+	NextCursor string `json:"next_cursor"`
+}
+
+func (a *AuthorHandler) Home(ctx *gin.Context, req AuthorHomeReq) (ginx.Result, error) {
+	uid, err := strconv.ParseInt(ctx.Param("uid"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, err
+	}
+	cursor, err := domain.ParseArticleCursor(req.Cursor)
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, err
+	}
+	if req.Limit <= 0 || req.Limit > 50 {
+		req.Limit = 10
+	}
+
+	user, err := a.userSvc.Profile(ctx, uid)
+	if err == service.ErrUserNotFound {
+		return ginx.Result{
+			Status: http.StatusNotFound,
+			Code:   4,
+			Msg:    "作者不存在",
+		}, nil
+	}
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+
+	arts, next, err := a.artSvc.ListPubByAuthor(ctx, uid, cursor, req.Limit)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+
+	ids := slice.Map[domain.Article, int64](arts, func(idx int, src domain.Article) int64 {
+		return src.Id
+	})
+	intrs, err := a.interSvc.GetByIds(ctx, a.biz, ids)
+	if err != nil {
+		// 计数获取失败不影响展示文章列表
+		a.l.Error("批量获取阅读，点赞计数失败",
+			logger.Int64("author", uid), logger.Error(err))
+	}
+
+	return ginx.Result{
+		Data: AuthorHomeVO{
+			Author: AuthorProfileVO{
+				Id:               user.Id,
+				NickName:         user.NickName,
+				SelfIntroduction: user.SelfIntroduction,
+			},
+			Articles: slice.Map[domain.Article, ArticleVO](arts, func(idx int, src domain.Article) ArticleVO {
+				intr := intrs[src.Id]
+				return ArticleVO{
+					Id:         src.Id,
+					Title:      src.Title,
+					Abstract:   src.Abstract(),
+					Author:     user.NickName,
+					Status:     src.Status.ToUint8(),
+					Category:   src.Category,
+					ReadCnt:    intr.ReadCnt,
+					LikeCnt:    intr.LikeCnt,
+					CollectCnt: intr.CollectCnt,
+					CommentCnt: intr.CommentCnt,
+					Ctime:      src.Ctime.Format(time.DateTime),
+					Utime:      src.Utime.Format(time.DateTime),
+				}
+			}),
+			NextCursor: next.Encode(),
+		},
+	}, nil
+}
@@ -0,0 +1,226 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/service"
+	svcmocks "github.com/johnwongx/webook/backend/internal/service/mocks"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthorHandler_Home(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	cursor := domain.ArticleCursor{Utime: now, Id: 5}
+	next := domain.ArticleCursor{Utime: now, Id: 2}
+	author := domain.User{
+		Id:               123,
+		Email:            "123@qq.com",
+		Phone:            "13800000000",
+		NickName:         "作者",
+		SelfIntroduction: "简介",
+	}
+	profile := AuthorProfileVO{
+		Id:               123,
+		NickName:         "作者",
+		SelfIntroduction: "简介",
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (service.ArticleService, service.UserService, service.InteractiveService)
+		url  string
+
+		wantCode int
+		wantRes  authorHomeResult
+	}{
+		{
+			name: "作者主页，带上计数",
+			mock: func(ctrl *gomock.Controller) (service.ArticleService, service.UserService, service.InteractiveService) {
+				us := svcmocks.NewMockUserService(ctrl)
+				us.EXPECT().Profile(gomock.Any(), int64(123)).Return(author, nil)
+				as := svcmocks.NewMockArticleService(ctrl)
+				as.EXPECT().ListPubByAuthor(gomock.Any(), int64(123), cursor, 2).Return([]domain.Article{
+					{Id: 2, Title: "标题", Content: "内容", Status: domain.ArticleStatusPublished, Ctime: now, Utime: now},
+				}, next, nil)
+				is := svcmocks.NewMockInteractiveService(ctrl)
+				is.EXPECT().GetByIds(gomock.Any(), "article", []int64{2}).Return(map[int64]domain.Interactive{
+					2: {ReadCnt: 10, LikeCnt: 2, CollectCnt: 1},
+				}, nil)
+				return as, us, is
+			},
+			url:      "/pub/authors/123?limit=2&cursor=" + cursor.Encode(),
+			wantCode: http.StatusOK,
+			wantRes: authorHomeResult{
+				Data: AuthorHomeVO{
+					Author:     profile,
+					NextCursor: next.Encode(),
+					Articles: []ArticleVO{
+						{
+							Id:         2,
+							Title:      "标题",
+							Abstract:   "内容",
+							Author:     "作者",
+							Status:     domain.ArticleStatusPublished.ToUint8(),
+							ReadCnt:    10,
+							LikeCnt:    2,
+							CollectCnt: 1,
+							Ctime:      now.Format(time.DateTime),
+							Utime:      now.Format(time.DateTime),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "计数获取失败，照常返回文章",
+			mock: func(ctrl *gomock.Controller) (service.ArticleService, service.UserService, service.InteractiveService) {
+				us := svcmocks.NewMockUserService(ctrl)
+				us.EXPECT().Profile(gomock.Any(), int64(123)).Return(author, nil)
+				as := svcmocks.NewMockArticleService(ctrl)
+				as.EXPECT().ListPubByAuthor(gomock.Any(), int64(123), domain.ArticleCursor{}, 10).Return([]domain.Article{
+					{Id: 2, Title: "标题", Content: "内容", Status: domain.ArticleStatusPublished, Ctime: now, Utime: now},
+				}, domain.ArticleCursor{}, nil)
+				is := svcmocks.NewMockInteractiveService(ctrl)
+				is.EXPECT().GetByIds(gomock.Any(), "article", []int64{2}).
+					Return(nil, errors.New("redis error"))
+				return as, us, is
+			},
+			url:      "/pub/authors/123",
+			wantCode: http.StatusOK,
+			wantRes: authorHomeResult{
+				Data: AuthorHomeVO{
+					Author: profile,
+					Articles: []ArticleVO{
+						{
+							Id:       2,
+							Title:    "标题",
+							Abstract: "内容",
+							Author:   "作者",
+							Status:   domain.ArticleStatusPublished.ToUint8(),
+							Ctime:    now.Format(time.DateTime),
+							Utime:    now.Format(time.DateTime),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "作者还没有发表文章",
+			mock: func(ctrl *gomock.Controller) (service.ArticleService, service.UserService, service.InteractiveService) {
+				us := svcmocks.NewMockUserService(ctrl)
+				us.EXPECT().Profile(gomock.Any(), int64(123)).Return(author, nil)
+				as := svcmocks.NewMockArticleService(ctrl)
+				as.EXPECT().ListPubByAuthor(gomock.Any(), int64(123), domain.ArticleCursor{}, 10).
+					Return([]domain.Article{}, domain.ArticleCursor{}, nil)
+				is := svcmocks.NewMockInteractiveService(ctrl)
+				is.EXPECT().GetByIds(gomock.Any(), "article", []int64{}).
+					Return(map[int64]domain.Interactive{}, nil)
+				return as, us, is
+			},
+			url:      "/pub/authors/123?limit=100",
+			wantCode: http.StatusOK,
+			wantRes: authorHomeResult{
+				Data: AuthorHomeVO{
+					Author:   profile,
+					Articles: []ArticleVO{},
+				},
+			},
+		},
+		{
+			name: "作者不存在",
+			mock: func(ctrl *gomock.Controller) (service.ArticleService, service.UserService, service.InteractiveService) {
+				us := svcmocks.NewMockUserService(ctrl)
+				us.EXPECT().Profile(gomock.Any(), int64(456)).Return(domain.User{}, service.ErrUserNotFound)
+				return svcmocks.NewMockArticleService(ctrl), us, svcmocks.NewMockInteractiveService(ctrl)
+			},
+			url:      "/pub/authors/456",
+			wantCode: http.StatusNotFound,
+			wantRes: authorHomeResult{
+				Code: 4,
+				Msg:  "作者不存在",
+			},
+		},
+		{
+			name: "作者 id 不合法",
+			mock: func(ctrl *gomock.Controller) (service.ArticleService, service.UserService, service.InteractiveService) {
+				return svcmocks.NewMockArticleService(ctrl), svcmocks.NewMockUserService(ctrl),
+					svcmocks.NewMockInteractiveService(ctrl)
+			},
+			url:      "/pub/authors/abc",
+			wantCode: http.StatusOK,
+			wantRes: authorHomeResult{
+				Code: 4,
+				Msg:  "参数错误",
+			},
+		},
+		{
+			name: "游标不合法",
+			mock: func(ctrl *gomock.Controller) (service.ArticleService, service.UserService, service.InteractiveService) {
+				return svcmocks.NewMockArticleService(ctrl), svcmocks.NewMockUserService(ctrl),
+					svcmocks.NewMockInteractiveService(ctrl)
+			},
+			url:      "/pub/authors/123?cursor=abc",
+			wantCode: http.StatusOK,
+			wantRes: authorHomeResult{
+				Code: 4,
+				Msg:  "参数错误",
+			},
+		},
+		{
+			name: "查询文章出错",
+			mock: func(ctrl *gomock.Controller) (service.ArticleService, service.UserService, service.InteractiveService) {
+				us := svcmocks.NewMockUserService(ctrl)
+				us.EXPECT().Profile(gomock.Any(), int64(123)).Return(author, nil)
+				as := svcmocks.NewMockArticleService(ctrl)
+				as.EXPECT().ListPubByAuthor(gomock.Any(), int64(123), domain.ArticleCursor{}, 10).
+					Return(nil, domain.ArticleCursor{}, errors.New("db error"))
+				return as, us, svcmocks.NewMockInteractiveService(ctrl)
+			},
+			url:      "/pub/authors/123",
+			wantCode: http.StatusOK,
+			wantRes: authorHomeResult{
+				Code: 5,
+				Msg:  "系统错误",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			as, us, is := tc.mock(ctrl)
+			server := gin.Default()
+			h := NewAuthorHandler(as, us, is, logger.NewNopLogger())
+			h.RegisterRoutes(server)
+
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, tc.wantCode, resp.Code)
+			var res authorHomeResult
+			err = json.Unmarshal(resp.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+// authorHomeResult 按作者主页的结构解析 ginx.Result
+type authorHomeResult struct {
+	Code int          `json:"code"`
+	Msg  string       `json:"message"`
+	Data AuthorHomeVO `json:"data"`
+}
@@ -0,0 +1,218 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/service"
+	myjwt "github.com/johnwongx/webook/backend/internal/web/jwt"
+	"github.com/johnwongx/webook/backend/pkg/ginx"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxCollectionNameLength 收藏夹名字的最大长度，以字符计
+const maxCollectionNameLength = 64
+
+type CollectionHandler struct {
+	svc service.CollectionService
+	l   logger.Logger
+	biz string
+}
+
+func NewCollectionHandler(svc service.CollectionService, l logger.Logger) *CollectionHandler {
+	return &CollectionHandler{
+		svc: svc,
+		l:   l,
+		biz: "article",
+	}
+}
+
+func (c *CollectionHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/collections")
+	g.POST("", ginx.WrapReqToken[CollectionReq, myjwt.UserClaim](c.Create, c.l))
+	g.POST("/edit", ginx.WrapReqToken[CollectionReq, myjwt.UserClaim](c.Rename, c.l))
+	g.POST("/delete", ginx.WrapReqToken[CollectionReq, myjwt.UserClaim](c.Delete, c.l))
+	g.GET("", ginx.WrapReqToken[CollectionListReq, myjwt.UserClaim](c.List, c.l))
+	// id 为 0 表示没有放进任何收藏夹的收藏
+	g.GET("/:id/items", ginx.WrapReqToken[CollectionListReq, myjwt.UserClaim](c.ListItems, c.l))
+	g.POST("/items/move", ginx.WrapReqToken[CollectReq, myjwt.UserClaim](c.Move, c.l))
+	g.POST("/items/delete", ginx.WrapReqToken[CollectReq, myjwt.UserClaim](c.Uncollect, c.l))
+}
+
+type CollectionReq struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type CollectionListReq struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+type CollectionVO struct {
+	Id    int64  `json:"id"`
+	Name  string `json:"name"`
+	Ctime string `json:"ctime"`
+	Utime string `json:"utime"`
+}
+
+type CollectionItemVO struct {
+	Cid     int64     `json:"c_id"`
+	Article ArticleVO `json:"article"`
+	Ctime   string    `json:"ctime"`
+}
+
+func (c *CollectionHandler) Create(ctx *gin.Context, req CollectionReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	name, ok := c.checkName(req.Name)
+	if !ok {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "收藏夹名字不能为空，且不能超过 64 个字",
+		}, nil
+	}
+	id, err := c.svc.Create(ctx, domain.Collection{
+		Uid:  uc.UserId,
+		Name: name,
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{Data: id}, nil
+}
+
+func (c *CollectionHandler) Rename(ctx *gin.Context, req CollectionReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	name, ok := c.checkName(req.Name)
+	if !ok {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "收藏夹名字不能为空，且不能超过 64 个字",
+		}, nil
+	}
+	err := c.svc.Rename(ctx, req.Id, uc.UserId, name)
+	return c.result(err)
+}
+
+func (c *CollectionHandler) Delete(ctx *gin.Context, req CollectionReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	err := c.svc.Delete(ctx, req.Id, uc.UserId)
+	return c.result(err)
+}
+
+func (c *CollectionHandler) List(ctx *gin.Context, req CollectionListReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	c.checkPage(&req)
+	cols, err := c.svc.List(ctx, uc.UserId, req.Offset, req.Limit)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map[domain.Collection, CollectionVO](cols,
+			func(idx int, src domain.Collection) CollectionVO {
+				return CollectionVO{
+					Id:    src.Id,
+					Name:  src.Name,
+					Ctime: src.Ctime.Format(time.DateTime),
+					Utime: src.Utime.Format(time.DateTime),
+				}
+			}),
+	}, nil
+}
+
+func (c *CollectionHandler) ListItems(ctx *gin.Context, req CollectionListReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	cid, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, err
+	}
+	c.checkPage(&req)
+	items, err := c.svc.ListItems(ctx, cid, uc.UserId, req.Offset, req.Limit)
+	switch err {
+	case nil:
+	case service.ErrCollectionNotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "收藏夹不存在",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map[domain.CollectionItem, CollectionItemVO](items,
+			func(idx int, src domain.CollectionItem) CollectionItemVO {
+				vo := CollectionItemVO{
+					Cid: src.Cid,
+					Article: ArticleVO{
+						Id:       src.BizId,
+						Title:    src.Article.Title,
+						Abstract: src.Article.Abstract(),
+						Author:   src.Article.Author.Name,
+					},
+					Ctime: src.Ctime.Format(time.DateTime),
+				}
+				if !src.Article.Utime.IsZero() {
+					vo.Article.Utime = src.Article.Utime.Format(time.DateTime)
+				}
+				return vo
+			}),
+	}, nil
+}
+
+func (c *CollectionHandler) Move(ctx *gin.Context, req CollectReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	err := c.svc.Move(ctx, c.biz, req.Id, uc.UserId, req.CId)
+	if err == service.ErrCollectItemNotFound {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "没有收藏过该文章",
+		}, nil
+	}
+	return c.result(err)
+}
+
+func (c *CollectionHandler) Uncollect(ctx *gin.Context, req CollectReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	err := c.svc.Uncollect(ctx, c.biz, req.Id, uc.UserId)
+	return c.result(err)
+}
+
+func (c *CollectionHandler) checkName(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	return name, name != "" && utf8.RuneCountInString(name) <= maxCollectionNameLength
+}
+
+func (c *CollectionHandler) checkPage(req *CollectionListReq) {
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+}
+
+func (c *CollectionHandler) result(err error) (ginx.Result, error) {
+	switch err {
+	case nil:
+		return ginx.Result{Msg: "OK"}, nil
+	case service.ErrCollectionNotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "收藏夹不存在",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
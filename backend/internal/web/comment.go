@@ -0,0 +1,228 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/service"
+	myjwt "github.com/johnwongx/webook/backend/internal/web/jwt"
+	"github.com/johnwongx/webook/backend/pkg/ginx"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxCommentLength 评论内容的最大长度，以字符计
+const maxCommentLength = 1000
+
+type CommentHandler struct {
+	svc      service.CommentService
+	interSvc service.InteractiveService
+	l        logger.Logger
+	// biz 评论自身作为点赞的对象
+	biz string
+}
+
+func NewCommentHandler(svc service.CommentService, interSvc service.InteractiveService,
+	l logger.Logger) *CommentHandler {
+	return &CommentHandler{
+		svc:      svc,
+		interSvc: interSvc,
+		l:        l,
+		biz:      "comment",
+	}
+}
+
+func (c *CommentHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/comments")
+	g.POST("", ginx.WrapReqToken[CommentReq, myjwt.UserClaim](c.Create, c.l))
+	g.POST("/delete", ginx.WrapReqToken[DeleteCommentReq, myjwt.UserClaim](c.Delete, c.l))
+	g.POST("/like", ginx.WrapReqToken[LikeReq, myjwt.UserClaim](c.Like, c.l))
+	g.GET("", ginx.WrapReq[CommentListReq](c.List, c.l))
+	g.GET("/:id/replies", ginx.WrapReq[ReplyListReq](c.Replies, c.l))
+}
+
+type CommentReq struct {
+	// Biz 评论的对象，默认是文章
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+	// ParentId 回复的评论，直接评论时不传
+	ParentId int64  `json:"parent_id"`
+	Content  string `json:"content"`
+}
+
+type DeleteCommentReq struct {
+	Id int64 `json:"id"`
+}
+
+type CommentListReq struct {
+	Biz   string `form:"biz"`
+	BizId int64  `form:"biz_id"`
+	// BeforeId 上一页最后一条根评论的 id，第一页不传
+	BeforeId int64 `form:"before_id"`
+	Limit    int   `form:"limit"`
+}
+
+type ReplyListReq struct {
+	// AfterId 上一页最后一条回复的 id，从头开始时不传
+	AfterId int64 `form:"after_id"`
+	Limit   int   `form:"limit"`
+}
+
+type CommentVO struct {
+	Id          int64       `json:"id"`
+	Uid         int64       `json:"uid"`
+	Commentator string      `json:"commentator"`
+	Content     string      `json:"content"`
+	RootId      int64       `json:"root_id"`
+	ParentId    int64       `json:"parent_id"`
+	LikeCnt     int64       `json:"like_cnt"`
+	Replies     []CommentVO `json:"replies,omitempty"`
+	Ctime       string      `json:"ctime"`
+}
+
+func (c *CommentHandler) Create(ctx *gin.Context, req CommentReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	req.Content = strings.TrimSpace(req.Content)
+	if req.Content == "" || utf8.RuneCountInString(req.Content) > maxCommentLength {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "评论内容不能为空，且不能超过 1000 字",
+		}, nil
+	}
+	if req.Biz == "" {
+		req.Biz = "article"
+	}
+	id, err := c.svc.Create(ctx, domain.Comment{
+		Biz:   req.Biz,
+		BizId: req.BizId,
+		Commentator: domain.Author{
+			Id: uc.UserId,
+		},
+		ParentId: req.ParentId,
+		Content:  req.Content,
+	})
+	switch err {
+	case nil:
+		return ginx.Result{Data: id}, nil
+	case service.ErrCommentNotFound, service.ErrCommentTargetNotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "评论对象不存在",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
+
+func (c *CommentHandler) Delete(ctx *gin.Context, req DeleteCommentReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	err := c.svc.Delete(ctx, req.Id, uc.UserId)
+	switch err {
+	case nil:
+		return ginx.Result{Msg: "OK"}, nil
+	case service.ErrCommentNotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "评论不存在",
+		}, nil
+	case service.ErrCommentPermissionDenied:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "无权删除该评论",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
+
+func (c *CommentHandler) Like(ctx *gin.Context, req LikeReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	var err error
+	if req.IsLike {
+		err = c.interSvc.Like(ctx, req.Id, c.biz, uc.UserId)
+	} else {
+		err = c.interSvc.CancelLike(ctx, req.Id, c.biz, uc.UserId)
+	}
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (c *CommentHandler) List(ctx *gin.Context, req CommentListReq) (ginx.Result, error) {
+	if req.Biz == "" {
+		req.Biz = "article"
+	}
+	if req.Limit <= 0 || req.Limit > 50 {
+		req.Limit = 20
+	}
+	cs, err := c.svc.ListRoots(ctx, req.Biz, req.BizId, req.BeforeId, req.Limit)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{Data: c.toVOs(ctx, cs)}, nil
+}
+
+func (c *CommentHandler) Replies(ctx *gin.Context, req ReplyListReq) (ginx.Result, error) {
+	rootId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, err
+	}
+	if req.Limit <= 0 || req.Limit > 50 {
+		req.Limit = 20
+	}
+	cs, err := c.svc.ListReplies(ctx, rootId, req.AfterId, req.Limit)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{Data: c.toVOs(ctx, cs)}, nil
+}
+
+// toVOs 转换的同时批量查出评论的点赞数，包括内联的回复
+func (c *CommentHandler) toVOs(ctx *gin.Context, cs []domain.Comment) []CommentVO {
+	var ids []int64
+	for _, cm := range cs {
+		ids = append(ids, cm.Id)
+		for _, child := range cm.Children {
+			ids = append(ids, child.Id)
+		}
+	}
+	intrs, err := c.interSvc.GetByIds(ctx, c.biz, ids)
+	if err != nil {
+		// 点赞数获取失败不影响展示评论
+		c.l.Error("批量获取评论点赞数失败", logger.Error(err))
+	}
+	var toVO func(idx int, src domain.Comment) CommentVO
+	toVO = func(idx int, src domain.Comment) CommentVO {
+		return CommentVO{
+			Id:          src.Id,
+			Uid:         src.Commentator.Id,
+			Commentator: src.Commentator.Name,
+			Content:     src.Content,
+			RootId:      src.RootId,
+			ParentId:    src.ParentId,
+			LikeCnt:     intrs[src.Id].LikeCnt,
+			Replies:     slice.Map[domain.Comment, CommentVO](src.Children, toVO),
+			Ctime:       src.Ctime.Format(time.DateTime),
+		}
+	}
+	return slice.Map[domain.Comment, CommentVO](cs, toVO)
+}
@@ -0,0 +1,129 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/service"
+	myjwt "github.com/johnwongx/webook/backend/internal/web/jwt"
+	"github.com/johnwongx/webook/backend/pkg/ginx"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"strconv"
+	"time"
+)
+
+// ExportHandler 作者导出自己的全部文章
+type ExportHandler struct {
+	svc service.ExportService
+	l   logger.Logger
+}
+
+func NewExportHandler(svc service.ExportService, l logger.Logger) *ExportHandler {
+	return &ExportHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (e *ExportHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/articles/exports")
+	g.POST("", ginx.WrapToken[myjwt.UserClaim](e.Create, e.l))
+	g.GET("/:id", ginx.WrapToken[myjwt.UserClaim](e.Detail, e.l))
+	g.GET("/:id/download", ginx.WrapToken[myjwt.UserClaim](e.Download, e.l))
+}
+
+// ExportVO Status 为 domain.ArticleExportStatus，Done、Total 为进度
+type ExportVO struct {
+	Id     int64  `json:"id"`
+	Status uint8  `json:"status"`
+	Total  int    `json:"total"`
+	Done   int    `json:"done"`
+	Ctime  string `json:"ctime"`
+	Utime  string `json:"utime"`
+}
+
+type ExportDownloadVO struct {
+	URL      string `json:"url"`
+	ExpireAt string `json:"expire_at"`
+}
+
+func newExportVO(e domain.ArticleExport) ExportVO {
+	return ExportVO{
+		Id:     e.Id,
+		Status: e.Status.ToUint8(),
+		Total:  e.Total,
+		Done:   e.Done,
+		Ctime:  e.Ctime.Format(time.DateTime),
+		Utime:  e.Utime.Format(time.DateTime),
+	}
+}
+
+func (e *ExportHandler) Create(ctx *gin.Context, uc myjwt.UserClaim) (ginx.Result, error) {
+	res, err := e.svc.Create(ctx, uc.UserId)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{Data: newExportVO(res)}, nil
+}
+
+func (e *ExportHandler) Detail(ctx *gin.Context, uc myjwt.UserClaim) (ginx.Result, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, err
+	}
+	res, err := e.svc.Get(ctx, id, uc.UserId)
+	switch err {
+	case nil:
+		return ginx.Result{Data: newExportVO(res)}, nil
+	case service.ErrExportNotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "导出任务不存在",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
+
+func (e *ExportHandler) Download(ctx *gin.Context, uc myjwt.UserClaim) (ginx.Result, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, err
+	}
+	url, err := e.svc.DownloadURL(ctx, id, uc.UserId)
+	switch err {
+	case nil:
+		return ginx.Result{
+			Data: ExportDownloadVO{
+				URL:      url,
+				ExpireAt: time.Now().Add(service.ExportLinkExpiration).Format(time.DateTime),
+			},
+		}, nil
+	case service.ErrExportNotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "导出任务不存在",
+		}, nil
+	case service.ErrExportNotReady:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "导出尚未完成",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
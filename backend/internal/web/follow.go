@@ -0,0 +1,206 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/service"
+	myjwt "github.com/johnwongx/webook/backend/internal/web/jwt"
+	"github.com/johnwongx/webook/backend/pkg/ginx"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"time"
+)
+
+type FollowHandler struct {
+	svc     service.FollowService
+	feedSvc service.FeedService
+	l       logger.Logger
+}
+
+func NewFollowHandler(svc service.FollowService, feedSvc service.FeedService, l logger.Logger) *FollowHandler {
+	return &FollowHandler{
+		svc:     svc,
+		feedSvc: feedSvc,
+		l:       l,
+	}
+}
+
+func (f *FollowHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/follow")
+	g.POST("", ginx.WrapReqToken[FollowReq, myjwt.UserClaim](f.Follow, f.l))
+	g.POST("/cancel", ginx.WrapReqToken[FollowReq, myjwt.UserClaim](f.Unfollow, f.l))
+	g.GET("/followees", ginx.WrapReqToken[FollowListReq, myjwt.UserClaim](f.Followees, f.l))
+	g.GET("/followers", ginx.WrapReqToken[FollowListReq, myjwt.UserClaim](f.Followers, f.l))
+	g.GET("/statics", ginx.WrapReqToken[FollowStaticsReq, myjwt.UserClaim](f.Statics, f.l))
+
+	server.GET("/feed", ginx.WrapReqToken[FeedReq, myjwt.UserClaim](f.Feed, f.l))
+}
+
+type FollowReq struct {
+	Followee int64 `json:"followee"`
+}
+
+type FollowListReq struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+type FollowStaticsReq struct {
+	// Uid 为 0 时查自己的
+	Uid int64 `form:"uid"`
+}
+
+type FeedReq struct {
+	// Cursor 上一页返回的 next_cursor，第一页不传
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit"`
+}
+
+type FollowRelationVO struct {
+	Follower int64  `json:"follower"`
+	Followee int64  `json:"followee"`
+	Ctime    string `json:"ctime"`
+}
+
+type FollowStaticsVO struct {
+	Uid       int64 `json:"uid"`
+	Followers int64 `json:"followers"`
+	Followees int64 `json:"followees"`
+}
+
+func (f *FollowHandler) Follow(ctx *gin.Context, req FollowReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	err := f.svc.Follow(ctx, uc.UserId, req.Followee)
+	switch err {
+	case nil:
+		return ginx.Result{Msg: "OK"}, nil
+	case service.ErrFollowSelf:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "不能关注自己",
+		}, nil
+	case service.ErrUserNotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "用户不存在",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
+
+func (f *FollowHandler) Unfollow(ctx *gin.Context, req FollowReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	err := f.svc.Unfollow(ctx, uc.UserId, req.Followee)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (f *FollowHandler) Followees(ctx *gin.Context, req FollowListReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	req.Offset, req.Limit = followPage(req.Offset, req.Limit)
+	rels, err := f.svc.Followees(ctx, uc.UserId, req.Offset, req.Limit)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{Data: newFollowRelationVOs(rels)}, nil
+}
+
+func (f *FollowHandler) Followers(ctx *gin.Context, req FollowListReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	req.Offset, req.Limit = followPage(req.Offset, req.Limit)
+	rels, err := f.svc.Followers(ctx, uc.UserId, req.Offset, req.Limit)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{Data: newFollowRelationVOs(rels)}, nil
+}
+
+func (f *FollowHandler) Statics(ctx *gin.Context, req FollowStaticsReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	uid := req.Uid
+	if uid == 0 {
+		uid = uc.UserId
+	}
+	statics, err := f.svc.Statics(ctx, uid)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{
+		Data: FollowStaticsVO{
+			Uid:       statics.Uid,
+			Followers: statics.Followers,
+			Followees: statics.Followees,
+		},
+	}, nil
+}
+
+func (f *FollowHandler) Feed(ctx *gin.Context, req FeedReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	cursor, err := domain.ParseArticleCursor(req.Cursor)
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, err
+	}
+	if req.Limit <= 0 || req.Limit > 50 {
+		req.Limit = 10
+	}
+	items, next, err := f.feedSvc.Feed(ctx, uc.UserId, cursor, req.Limit)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{
+		Data: ArticleListVO{
+			List: slice.Map[domain.FeedItem, ArticleVO](items, func(idx int, src domain.FeedItem) ArticleVO {
+				return ArticleVO{
+					Id:       src.Article.Id,
+					Title:    src.Article.Title,
+					Abstract: src.Article.Abstract(),
+					Author:   src.Article.Author.Name,
+					Status:   src.Article.Status.ToUint8(),
+					Tags:     src.Article.Tags,
+					Category: src.Article.Category,
+					Ctime:    src.Article.Ctime.Format(time.DateTime),
+					Utime:    src.Article.Utime.Format(time.DateTime),
+				}
+			}),
+			NextCursor: next.Encode(),
+		},
+	}, nil
+}
+
+func followPage(offset, limit int) (int, int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	return offset, limit
+}
+
+func newFollowRelationVOs(rels []domain.FollowRelation) []FollowRelationVO {
+	return slice.Map[domain.FollowRelation, FollowRelationVO](rels, func(idx int, src domain.FollowRelation) FollowRelationVO {
+		return FollowRelationVO{
+			Follower: src.Follower,
+			Followee: src.Followee,
+			Ctime:    src.Ctime.Format(time.DateTime),
+		}
+	})
+}
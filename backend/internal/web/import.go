@@ -0,0 +1,105 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/service"
+	myjwt "github.com/johnwongx/webook/backend/internal/web/jwt"
+	"github.com/johnwongx/webook/backend/pkg/ginx"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"io"
+	"strconv"
+)
+
+// ImportHandler 从 Markdown 文件批量导入文章
+type ImportHandler struct {
+	svc service.ImportService
+	l   logger.Logger
+}
+
+func NewImportHandler(svc service.ImportService, l logger.Logger) *ImportHandler {
+	return &ImportHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (i *ImportHandler) RegisterRoutes(server *gin.Engine) {
+	server.POST("/articles/imports", ginx.WrapToken[myjwt.UserClaim](i.Import, i.l))
+}
+
+// ImportResultVO Status 为 domain.ArticleImportStatus，失败时 Reason 为原因
+type ImportResultVO struct {
+	Name      string `json:"name"`
+	ArticleId int64  `json:"article_id,omitempty"`
+	Status    uint8  `json:"status"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// Import 表单字段 files 为一个或多个文件，publish 为 true 时按 front matter 中的状态发表
+func (i *ImportHandler) Import(ctx *gin.Context, uc myjwt.UserClaim) (ginx.Result, error) {
+	form, err := ctx.MultipartForm()
+	if err != nil || len(form.File["files"]) == 0 {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "请选择要导入的文件",
+		}, nil
+	}
+	var total int64
+	for _, fh := range form.File["files"] {
+		total += fh.Size
+	}
+	if total > service.MaxImportSize {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "文件总大小不能超过 20MB",
+		}, nil
+	}
+	files := make([]domain.ImportFile, 0, len(form.File["files"]))
+	for _, fh := range form.File["files"] {
+		f, err := fh.Open()
+		if err != nil {
+			return ginx.Result{
+				Code: 5,
+				Msg:  "系统错误",
+			}, err
+		}
+		data, err := io.ReadAll(io.LimitReader(f, service.MaxImportSize+1))
+		_ = f.Close()
+		if err != nil {
+			return ginx.Result{
+				Code: 5,
+				Msg:  "系统错误",
+			}, err
+		}
+		files = append(files, domain.ImportFile{Name: fh.Filename, Data: data})
+	}
+	publish, _ := strconv.ParseBool(ctx.PostForm("publish"))
+
+	res, err := i.svc.Import(ctx, uc.UserId, files, publish)
+	switch err {
+	case nil:
+		return ginx.Result{
+			Data: slice.Map[domain.ArticleImportResult, ImportResultVO](res,
+				func(idx int, src domain.ArticleImportResult) ImportResultVO {
+					return ImportResultVO{
+						Name:      src.Name,
+						ArticleId: src.ArticleId,
+						Status:    src.Status.ToUint8(),
+						Reason:    src.Reason,
+					}
+				}),
+		}, nil
+	case service.ErrImportTooManyFiles:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "一次最多导入 " + strconv.Itoa(service.MaxImportFiles) + " 篇文章",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
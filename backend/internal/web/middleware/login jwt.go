@@ -3,6 +3,7 @@ package middleware
 import (
 	"encoding/gob"
 	"net/http"
+	"strings"
 	"time"
 
 	myjwt "github.com/johnwongx/webook/backend/internal/web/jwt"
@@ -12,7 +13,9 @@ import (
 )
 
 type LoginJWTMiddlewareBuilder struct {
-	paths []string
+	paths    []string
+	prefixes []string
+	suffixes []string
 	myjwt.JwtHandler
 }
 
@@ -27,6 +30,18 @@ func (l *LoginJWTMiddlewareBuilder) IgnorePath(path string) *LoginJWTMiddlewareB
 	return l
 }
 
+// IgnorePrefix 以 prefix 开头的路径都不需要登录，例如自带签名的下载链接
+func (l *LoginJWTMiddlewareBuilder) IgnorePrefix(prefix string) *LoginJWTMiddlewareBuilder {
+	l.prefixes = append(l.prefixes, prefix)
+	return l
+}
+
+// IgnoreSuffix 以 suffix 结尾的路径都不需要登录，例如带路径参数的订阅源
+func (l *LoginJWTMiddlewareBuilder) IgnoreSuffix(suffix string) *LoginJWTMiddlewareBuilder {
+	l.suffixes = append(l.suffixes, suffix)
+	return l
+}
+
 func (l *LoginJWTMiddlewareBuilder) Builder() gin.HandlerFunc {
 	gob.Register(time.Now())
 	return func(ctx *gin.Context) {
@@ -35,6 +50,16 @@ func (l *LoginJWTMiddlewareBuilder) Builder() gin.HandlerFunc {
 				return
 			}
 		}
+		for _, prefix := range l.prefixes {
+			if strings.HasPrefix(ctx.Request.URL.Path, prefix) {
+				return
+			}
+		}
+		for _, suffix := range l.suffixes {
+			if strings.HasSuffix(ctx.Request.URL.Path, suffix) {
+				return
+			}
+		}
 
 		tokenStr, err := l.ExtraToken(ctx)
 		if err != nil {
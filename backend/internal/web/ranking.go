@@ -0,0 +1,62 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/service"
+	"github.com/johnwongx/webook/backend/pkg/ginx"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"time"
+)
+
+type RankingHandler struct {
+	svc service.RankingService
+	l   logger.Logger
+}
+
+func NewRankingHandler(svc service.RankingService, l logger.Logger) *RankingHandler {
+	return &RankingHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (r *RankingHandler) RegisterRoutes(server *gin.Engine) {
+	server.GET("/pub/ranking", ginx.Wrap(r.Ranking, r.l))
+}
+
+type HotArticleVO struct {
+	Id         int64   `json:"id"`
+	Title      string  `json:"title"`
+	Author     string  `json:"author"`
+	ReadCnt    int64   `json:"read_cnt"`
+	LikeCnt    int64   `json:"like_cnt"`
+	CollectCnt int64   `json:"collect_cnt"`
+	Score      float64 `json:"score"`
+	Utime      string  `json:"utime"`
+}
+
+func (r *RankingHandler) Ranking(ctx *gin.Context) (ginx.Result, error) {
+	arts, err := r.svc.GetTopN(ctx)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map[domain.HotArticle, HotArticleVO](arts, func(idx int, src domain.HotArticle) HotArticleVO {
+			return HotArticleVO{
+				Id:         src.Article.Id,
+				Title:      src.Article.Title,
+				Author:     src.Article.Author.Name,
+				ReadCnt:    src.Intr.ReadCnt,
+				LikeCnt:    src.Intr.LikeCnt,
+				CollectCnt: src.Intr.CollectCnt,
+				Score:      src.Score,
+				Utime:      src.Article.Utime.Format(time.DateTime),
+			}
+		}),
+	}, nil
+}
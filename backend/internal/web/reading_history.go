@@ -0,0 +1,162 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/service"
+	myjwt "github.com/johnwongx/webook/backend/internal/web/jwt"
+	"github.com/johnwongx/webook/backend/pkg/ginx"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"time"
+)
+
+type ReadingHistoryHandler struct {
+	svc service.ReadingHistoryService
+	l   logger.Logger
+}
+
+func NewReadingHistoryHandler(svc service.ReadingHistoryService, l logger.Logger) *ReadingHistoryHandler {
+	return &ReadingHistoryHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *ReadingHistoryHandler) RegisterRoutes(server *gin.Engine) {
+	server.POST("/pub/progress", ginx.WrapReqToken[ReadingProgressReq, myjwt.UserClaim](h.ReportProgress, h.l))
+
+	g := server.Group("/history")
+	g.GET("", ginx.WrapReqToken[ReadingHistoryListReq, myjwt.UserClaim](h.ListRecent, h.l))
+	g.GET("/continue", ginx.WrapReqToken[ReadingHistoryListReq, myjwt.UserClaim](h.ListContinue, h.l))
+	g.POST("/delete", ginx.WrapReqToken[ReadingHistoryDeleteReq, myjwt.UserClaim](h.Delete, h.l))
+	g.POST("/clear", ginx.WrapToken[myjwt.UserClaim](h.Clear, h.l))
+}
+
+type ReadingProgressReq struct {
+	Id int64 `json:"id"`
+	// Progress 读到了百分之多少，0 到 100
+	Progress int `json:"progress"`
+}
+
+type ReadingHistoryListReq struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+type ReadingHistoryDeleteReq struct {
+	Ids []int64 `json:"ids"`
+}
+
+type ReadingHistoryVO struct {
+	Article  ArticleVO `json:"article"`
+	Progress int       `json:"progress"`
+	Finished bool      `json:"finished"`
+	// Utime 最近一次阅读的时间
+	Utime string `json:"utime"`
+}
+
+func (h *ReadingHistoryHandler) ReportProgress(ctx *gin.Context, req ReadingProgressReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	err := h.svc.ReportProgress(ctx, uc.UserId, req.Id, req.Progress)
+	switch err {
+	case nil:
+		return ginx.Result{Msg: "OK"}, nil
+	case service.ErrInvalidReadingProgress:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "阅读进度必须在 0 到 100 之间",
+		}, nil
+	case service.ErrArticleNotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "文章不存在",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
+
+func (h *ReadingHistoryHandler) ListRecent(ctx *gin.Context, req ReadingHistoryListReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	h.checkPage(&req)
+	hs, err := h.svc.ListRecent(ctx, uc.UserId, req.Offset, req.Limit)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{Data: h.toVOs(hs)}, nil
+}
+
+func (h *ReadingHistoryHandler) ListContinue(ctx *gin.Context, req ReadingHistoryListReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	h.checkPage(&req)
+	hs, err := h.svc.ListContinue(ctx, uc.UserId, req.Limit)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{Data: h.toVOs(hs)}, nil
+}
+
+func (h *ReadingHistoryHandler) Delete(ctx *gin.Context, req ReadingHistoryDeleteReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	if len(req.Ids) == 0 || len(req.Ids) > 100 {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "一次最多删除 100 条",
+		}, nil
+	}
+	err := h.svc.Delete(ctx, uc.UserId, req.Ids)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *ReadingHistoryHandler) Clear(ctx *gin.Context, uc myjwt.UserClaim) (ginx.Result, error) {
+	err := h.svc.Clear(ctx, uc.UserId)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *ReadingHistoryHandler) checkPage(req *ReadingHistoryListReq) {
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+}
+
+func (h *ReadingHistoryHandler) toVOs(hs []domain.ReadingHistory) []ReadingHistoryVO {
+	return slice.Map[domain.ReadingHistory, ReadingHistoryVO](hs,
+		func(idx int, src domain.ReadingHistory) ReadingHistoryVO {
+			vo := ReadingHistoryVO{
+				Article: ArticleVO{
+					Id:       src.Aid,
+					Title:    src.Article.Title,
+					Abstract: src.Article.Abstract(),
+					Author:   src.Article.Author.Name,
+				},
+				Progress: src.Progress,
+				Finished: src.Finished(),
+				Utime:    src.Utime.Format(time.DateTime),
+			}
+			if !src.Article.Utime.IsZero() {
+				vo.Article.Utime = src.Article.Utime.Format(time.DateTime)
+			}
+			return vo
+		})
+}
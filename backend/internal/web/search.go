@@ -0,0 +1,91 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/service"
+	"github.com/johnwongx/webook/backend/pkg/ginx"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"time"
+	"unicode/utf8"
+)
+
+// maxSearchQueryLength 查询词的最大长度，以字符计
+const maxSearchQueryLength = 64
+
+type SearchHandler struct {
+	svc service.SearchService
+	l   logger.Logger
+}
+
+func NewSearchHandler(svc service.SearchService, l logger.Logger) *SearchHandler {
+	return &SearchHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (s *SearchHandler) RegisterRoutes(server *gin.Engine) {
+	server.GET("/pub/search", ginx.WrapReq[SearchReq](s.Search, s.l))
+}
+
+type SearchReq struct {
+	Q      string `form:"q"`
+	Offset int    `form:"offset"`
+	Limit  int    `form:"limit"`
+}
+
+type SearchHitVO struct {
+	Id     int64   `json:"id"`
+	Title  string  `json:"title"`
+	Author string  `json:"author"`
+	Score  float64 `json:"score"`
+	// 高亮的内容已做 HTML 转义，命中的词用 <em></em> 包裹
+	TitleHighlight   string `json:"title_highlight"`
+	ContentHighlight string `json:"content_highlight"`
+	Utime            string `json:"utime"`
+}
+
+type SearchResultVO struct {
+	Total int           `json:"total"`
+	Hits  []SearchHitVO `json:"hits"`
+}
+
+func (s *SearchHandler) Search(ctx *gin.Context, req SearchReq) (ginx.Result, error) {
+	if utf8.RuneCountInString(req.Q) > maxSearchQueryLength {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "搜索内容过长",
+		}, nil
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+	if req.Limit <= 0 || req.Limit > 50 {
+		req.Limit = 10
+	}
+	res, err := s.svc.SearchArticles(ctx, req.Q, req.Offset, req.Limit)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{
+		Data: SearchResultVO{
+			Total: res.Total,
+			Hits: slice.Map[domain.ArticleSearchHit, SearchHitVO](res.Hits, func(idx int, src domain.ArticleSearchHit) SearchHitVO {
+				return SearchHitVO{
+					Id:               src.Article.Id,
+					Title:            src.Article.Title,
+					Author:           src.Article.Author.Name,
+					Score:            src.Score,
+					TitleHighlight:   src.TitleHighlight,
+					ContentHighlight: src.ContentHighlight,
+					Utime:            src.Article.Utime.Format(time.DateTime),
+				}
+			}),
+		},
+	}, nil
+}
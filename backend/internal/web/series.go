@@ -0,0 +1,234 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/service"
+	myjwt "github.com/johnwongx/webook/backend/internal/web/jwt"
+	"github.com/johnwongx/webook/backend/pkg/ginx"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type SeriesHandler struct {
+	svc service.SeriesService
+	l   logger.Logger
+}
+
+func NewSeriesHandler(svc service.SeriesService, l logger.Logger) *SeriesHandler {
+	return &SeriesHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (s *SeriesHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/series")
+	g.POST("", ginx.WrapReqToken[SeriesReq, myjwt.UserClaim](s.Create, s.l))
+	g.GET("", ginx.WrapToken[myjwt.UserClaim](s.List, s.l))
+	g.POST("/articles/add", ginx.WrapReqToken[SeriesArticleReq, myjwt.UserClaim](s.AddArticle, s.l))
+	g.POST("/articles/remove", ginx.WrapReqToken[SeriesArticleReq, myjwt.UserClaim](s.RemoveArticle, s.l))
+	g.POST("/articles/reorder", ginx.WrapReqToken[SeriesReorderReq, myjwt.UserClaim](s.Reorder, s.l))
+
+	server.GET("/pub/series/:id", ginx.Wrap(s.PubDetail, s.l))
+}
+
+type SeriesReq struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type SeriesArticleReq struct {
+	SeriesId  int64 `json:"series_id"`
+	ArticleId int64 `json:"article_id"`
+}
+
+type SeriesReorderReq struct {
+	SeriesId int64 `json:"series_id"`
+	// ArticleIds 专栏里全部文章的 id，按新的顺序排列
+	ArticleIds []int64 `json:"article_ids"`
+}
+
+type SeriesVO struct {
+	Id          int64       `json:"id"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	Author      string      `json:"author,omitempty"`
+	Articles    []ArticleVO `json:"articles,omitempty"`
+	Ctime       string      `json:"ctime"`
+	Utime       string      `json:"utime"`
+}
+
+// SeriesNavVO 文章详情页上的专栏导航，Prev、Next 为空表示没有
+type SeriesNavVO struct {
+	Id       int64      `json:"id"`
+	Title    string     `json:"title"`
+	Position int        `json:"position"`
+	Total    int        `json:"total"`
+	Prev     *ArticleVO `json:"prev,omitempty"`
+	Next     *ArticleVO `json:"next,omitempty"`
+}
+
+func newSeriesVO(s domain.Series) SeriesVO {
+	return SeriesVO{
+		Id:          s.Id,
+		Title:       s.Title,
+		Description: s.Description,
+		Author:      s.Author.Name,
+		Articles: slice.Map[domain.Article, ArticleVO](s.Articles, func(idx int, src domain.Article) ArticleVO {
+			return newSeriesArticleVO(src)
+		}),
+		Ctime: s.Ctime.Format(time.DateTime),
+		Utime: s.Utime.Format(time.DateTime),
+	}
+}
+
+func newSeriesNavVO(nav domain.SeriesNav) *SeriesNavVO {
+	vo := &SeriesNavVO{
+		Id:       nav.Series.Id,
+		Title:    nav.Series.Title,
+		Position: nav.Position,
+		Total:    nav.Total,
+	}
+	if nav.Prev.Id > 0 {
+		prev := newSeriesArticleVO(nav.Prev)
+		vo.Prev = &prev
+	}
+	if nav.Next.Id > 0 {
+		next := newSeriesArticleVO(nav.Next)
+		vo.Next = &next
+	}
+	return vo
+}
+
+// newSeriesArticleVO 专栏里的文章只需要标题和摘要
+func newSeriesArticleVO(art domain.Article) ArticleVO {
+	return ArticleVO{
+		Id:       art.Id,
+		Title:    art.Title,
+		Abstract: art.Abstract(),
+		Ctime:    art.Ctime.Format(time.DateTime),
+		Utime:    art.Utime.Format(time.DateTime),
+	}
+}
+
+func (s *SeriesHandler) Create(ctx *gin.Context, req SeriesReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	req.Title = strings.TrimSpace(req.Title)
+	if req.Title == "" || utf8.RuneCountInString(req.Title) > 128 {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "专栏标题不能为空，且不能超过 128 个字",
+		}, nil
+	}
+	if utf8.RuneCountInString(req.Description) > 1024 {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "专栏简介不能超过 1024 个字",
+		}, nil
+	}
+	id, err := s.svc.Create(ctx, domain.Series{
+		Author: domain.Author{
+			Id: uc.UserId,
+		},
+		Title:       req.Title,
+		Description: strings.TrimSpace(req.Description),
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{Data: id}, nil
+}
+
+func (s *SeriesHandler) List(ctx *gin.Context, uc myjwt.UserClaim) (ginx.Result, error) {
+	res, err := s.svc.ListByAuthor(ctx, uc.UserId)
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map[domain.Series, SeriesVO](res, func(idx int, src domain.Series) SeriesVO {
+			return newSeriesVO(src)
+		}),
+	}, nil
+}
+
+func (s *SeriesHandler) AddArticle(ctx *gin.Context, req SeriesArticleReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	err := s.svc.AddArticle(ctx, req.SeriesId, req.ArticleId, uc.UserId)
+	return s.result(err)
+}
+
+func (s *SeriesHandler) RemoveArticle(ctx *gin.Context, req SeriesArticleReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	err := s.svc.RemoveArticle(ctx, req.SeriesId, req.ArticleId, uc.UserId)
+	return s.result(err)
+}
+
+func (s *SeriesHandler) Reorder(ctx *gin.Context, req SeriesReorderReq, uc myjwt.UserClaim) (ginx.Result, error) {
+	err := s.svc.Reorder(ctx, req.SeriesId, uc.UserId, req.ArticleIds)
+	return s.result(err)
+}
+
+func (s *SeriesHandler) PubDetail(ctx *gin.Context) (ginx.Result, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "参数错误",
+		}, err
+	}
+	series, err := s.svc.GetPub(ctx, id)
+	switch err {
+	case nil:
+		return ginx.Result{Data: newSeriesVO(series)}, nil
+	case service.ErrSeriesNotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "专栏不存在",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
+
+func (s *SeriesHandler) result(err error) (ginx.Result, error) {
+	switch err {
+	case nil:
+		return ginx.Result{Msg: "OK"}, nil
+	case service.ErrSeriesNotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "专栏不存在",
+		}, nil
+	case service.ErrArticleNotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "文章不存在",
+		}, nil
+	case service.ErrArticleInSeries:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "文章已经属于某个专栏",
+		}, nil
+	case service.ErrSeriesArticlesMismatch:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "文章列表和专栏不一致，请刷新后重试",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
@@ -0,0 +1,54 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/johnwongx/webook/backend/internal/service"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"net/http"
+)
+
+// SitemapHandler 给搜索引擎的 sitemap 和 robots.txt，不需要登录
+type SitemapHandler struct {
+	svc service.SitemapService
+	l   logger.Logger
+}
+
+func NewSitemapHandler(svc service.SitemapService, l logger.Logger) *SitemapHandler {
+	return &SitemapHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (s *SitemapHandler) RegisterRoutes(server *gin.Engine) {
+	server.GET("/"+service.SitemapIndexName, s.Index)
+	server.GET(service.SitemapPathPrefix+":name", s.File)
+	server.GET("/robots.txt", s.Robots)
+}
+
+func (s *SitemapHandler) Index(ctx *gin.Context) {
+	s.serve(ctx, service.SitemapIndexName)
+}
+
+func (s *SitemapHandler) File(ctx *gin.Context) {
+	s.serve(ctx, ctx.Param("name"))
+}
+
+func (s *SitemapHandler) Robots(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=86400")
+	ctx.String(http.StatusOK, s.svc.Robots())
+}
+
+func (s *SitemapHandler) serve(ctx *gin.Context, name string) {
+	data, err := s.svc.Get(ctx, name)
+	switch err {
+	case nil:
+		ctx.Header("Cache-Control", "public, max-age=3600")
+		ctx.Data(http.StatusOK, "application/xml; charset=utf-8", data)
+	case service.ErrSitemapNotFound:
+		ctx.Status(http.StatusNotFound)
+	default:
+		s.l.Error("读取 sitemap 失败", logger.String("name", name), logger.Error(err))
+		ctx.Status(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,75 @@
+package web
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/service"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"net/http"
+	"strconv"
+)
+
+// SyndicationFileName 订阅源的文件名，订阅器无法登录，这些地址不需要登录
+const SyndicationFileName = "feed.xml"
+
+// SyndicationHandler 作者和全站的订阅源，默认 RSS 2.0，format=atom 时返回 Atom
+type SyndicationHandler struct {
+	svc service.SyndicationService
+	l   logger.Logger
+}
+
+func NewSyndicationHandler(svc service.SyndicationService, l logger.Logger) *SyndicationHandler {
+	return &SyndicationHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (s *SyndicationHandler) RegisterRoutes(server *gin.Engine) {
+	server.GET("/pub/"+SyndicationFileName, s.Site)
+	server.GET("/pub/authors/:uid/"+SyndicationFileName, s.Author)
+}
+
+func (s *SyndicationHandler) Site(ctx *gin.Context) {
+	feed, err := s.svc.SiteFeed(ctx, s.format(ctx))
+	if err != nil {
+		s.l.Error("生成全站订阅源失败", logger.Error(err))
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	s.serve(ctx, feed)
+}
+
+func (s *SyndicationHandler) Author(ctx *gin.Context) {
+	uid, err := strconv.ParseInt(ctx.Param("uid"), 10, 64)
+	if err != nil {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	feed, err := s.svc.AuthorFeed(ctx, uid, s.format(ctx))
+	switch err {
+	case nil:
+		s.serve(ctx, feed)
+	case service.ErrUserNotFound:
+		ctx.Status(http.StatusNotFound)
+	default:
+		s.l.Error("生成作者订阅源失败", logger.Int64("author", uid), logger.Error(err))
+		ctx.Status(http.StatusInternalServerError)
+	}
+}
+
+func (s *SyndicationHandler) format(ctx *gin.Context) domain.SyndicationFormat {
+	if ctx.Query("format") == "atom" {
+		return domain.SyndicationFormatAtom
+	}
+	return domain.SyndicationFormatRSS
+}
+
+// serve 由 http.ServeContent 处理 If-None-Match 和 If-Modified-Since
+func (s *SyndicationHandler) serve(ctx *gin.Context, feed domain.SyndicationFeed) {
+	ctx.Header("Content-Type", feed.Format.ContentType())
+	ctx.Header("ETag", feed.ETag)
+	ctx.Header("Cache-Control", "public, max-age=300")
+	http.ServeContent(ctx.Writer, ctx.Request, "", feed.LastModified, bytes.NewReader(feed.Body))
+}
@@ -0,0 +1,130 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/service"
+	myjwt "github.com/johnwongx/webook/backend/internal/web/jwt"
+	"github.com/johnwongx/webook/backend/pkg/ginx"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"io"
+	"net/http"
+)
+
+type UploadHandler struct {
+	svc service.UploadService
+	l   logger.Logger
+}
+
+func NewUploadHandler(svc service.UploadService, l logger.Logger) *UploadHandler {
+	return &UploadHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (u *UploadHandler) RegisterRoutes(server *gin.Engine) {
+	server.POST("/uploads/images", ginx.WrapToken[myjwt.UserClaim](u.UploadImage, u.l))
+	// 图片地址写在正文里，不需要登录
+	server.GET(service.ImageURLPrefix+":hash/:variant", u.Image)
+}
+
+type UploadVO struct {
+	Id       int64  `json:"id"`
+	URL      string `json:"url"`
+	WebURL   string `json:"web_url"`
+	ThumbURL string `json:"thumb_url"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+	Size     int64  `json:"size"`
+}
+
+func (u *UploadHandler) UploadImage(ctx *gin.Context, uc myjwt.UserClaim) (ginx.Result, error) {
+	fh, err := ctx.FormFile("file")
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "请选择图片",
+		}, nil
+	}
+	if fh.Size > service.MaxUploadSize {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "图片不能超过 10MB",
+		}, nil
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, service.MaxUploadSize+1))
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+
+	res, err := u.svc.UploadImage(ctx, uc.UserId, data)
+	switch err {
+	case nil:
+		return ginx.Result{
+			Data: UploadVO{
+				Id:       res.Id,
+				URL:      service.ImageURL(res.Hash, domain.UploadVariantOriginal),
+				WebURL:   service.ImageURL(res.Hash, domain.UploadVariantWeb),
+				ThumbURL: service.ImageURL(res.Hash, domain.UploadVariantThumb),
+				Width:    res.Width,
+				Height:   res.Height,
+				Size:     res.Size,
+			},
+		}, nil
+	case service.ErrUploadTooLarge:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "图片太大",
+		}, nil
+	case service.ErrUploadUnsupportedType:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "只支持 JPEG 和 PNG 格式的图片",
+		}, nil
+	case service.ErrUploadInvalidImage:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "图片已损坏",
+		}, nil
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "系统错误",
+		}, err
+	}
+}
+
+// Image 地址由内容决定，内容不会变化，可以长期缓存
+func (u *UploadHandler) Image(ctx *gin.Context) {
+	hash := ctx.Param("hash")
+	variant := domain.UploadVariant(ctx.Param("variant"))
+	etag := `"` + hash + "-" + string(variant) + `"`
+	if ctx.GetHeader("If-None-Match") == etag {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+	data, err := u.svc.GetImage(ctx, hash, variant)
+	switch err {
+	case nil:
+		ctx.Header("Cache-Control", "public, max-age=31536000, immutable")
+		ctx.Header("ETag", etag)
+		ctx.Data(http.StatusOK, http.DetectContentType(data), data)
+	case service.ErrUploadNotFound:
+		ctx.Status(http.StatusNotFound)
+	default:
+		u.l.Error("读取图片失败", logger.String("hash", hash), logger.Error(err))
+		ctx.Status(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,42 @@
+package ioc
+
+import (
+	"github.com/fsnotify/fsnotify"
+	"github.com/johnwongx/webook/backend/internal/web"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/spf13/viper"
+	"sync/atomic"
+)
+
+type adminSet struct {
+	uids atomic.Pointer[map[int64]struct{}]
+}
+
+func (a *adminSet) IsAdmin(uid int64) bool {
+	_, ok := (*a.uids.Load())[uid]
+	return ok
+}
+
+// InitAdminSet 管理员的用户 id 配置在 admin.uids 下，修改配置文件后立即生效
+func InitAdminSet(l logger.Logger) web.AdminSet {
+	res := &adminSet{}
+	res.uids.Store(&map[int64]struct{}{})
+	load := func() {
+		var uids []int64
+		err := viper.UnmarshalKey("admin.uids", &uids)
+		if err != nil {
+			l.Error("加载管理员配置失败", logger.Error(err))
+			return
+		}
+		m := make(map[int64]struct{}, len(uids))
+		for _, uid := range uids {
+			m[uid] = struct{}{}
+		}
+		res.uids.Store(&m)
+	}
+	load()
+	OnConfigChange(func(in fsnotify.Event) {
+		load()
+	})
+	return res
+}
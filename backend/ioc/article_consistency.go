@@ -0,0 +1,23 @@
+package ioc
+
+import (
+	"fmt"
+	"github.com/johnwongx/webook/backend/internal/job"
+	"github.com/johnwongx/webook/backend/internal/service"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/johnwongx/webook/backend/pkg/redislock"
+	"github.com/spf13/viper"
+)
+
+func InitArticleConsistencyJob(svc service.ArticleConsistencyService, client *redislock.Client,
+	l logger.Logger) *job.ArticleConsistencyJob {
+	type Config struct {
+		Repair bool `yaml:"repair"`
+	}
+	var c Config
+	err := viper.UnmarshalKey("article.consistency", &c)
+	if err != nil {
+		panic(fmt.Errorf("初始化配置失败 %v, 原因 %w", c, err))
+	}
+	return job.NewArticleConsistencyJob(svc, c.Repair, client, l)
+}
@@ -0,0 +1,60 @@
+package ioc
+
+import (
+	"fmt"
+	"github.com/bwmarrin/snowflake"
+	"github.com/johnwongx/webook/backend/internal/repository/dao/article"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// InitArticleMigrationDAO 没有开启文章存储迁移时返回 nil。
+// 源存储和目标存储是 db 或者 mongo，内容放在对象存储时不支持迁移
+func InitArticleMigrationDAO(db *gorm.DB, l logger.Logger) *article.DoubleWriteDAO {
+	type Config struct {
+		Enabled bool   `yaml:"enabled"`
+		Src     string `yaml:"src"`
+		Dst     string `yaml:"dst"`
+		// Node MongoDB 生成雪花 id 的节点号，多实例部署时各不相同
+		Node int64 `yaml:"node"`
+	}
+	c := Config{
+		Src:  "db",
+		Dst:  "mongo",
+		Node: 1,
+	}
+	err := viper.UnmarshalKey("article.migration", &c)
+	if err != nil {
+		panic(fmt.Errorf("初始化配置失败 %v, 原因 %w", c, err))
+	}
+	if !c.Enabled {
+		return nil
+	}
+	if viper.GetString("article.storage") == "blob" {
+		panic(fmt.Errorf("文章内容放在对象存储时不支持存储迁移"))
+	}
+	if c.Src == c.Dst {
+		panic(fmt.Errorf("文章存储迁移的源存储和目标存储相同 %s", c.Src))
+	}
+	create := func(typ string) article.MigratableDAO {
+		switch typ {
+		case "db":
+			return article.NewGORMArticleDAO(db, l).(*article.GORMArticleDAO)
+		case "mongo":
+			mdb := InitMongoDB()
+			err := article.InitCollections(mdb)
+			if err != nil {
+				panic(fmt.Errorf("初始化 MongoDB 集合失败 %w", err))
+			}
+			node, err := snowflake.NewNode(c.Node)
+			if err != nil {
+				panic(fmt.Errorf("初始化雪花 id 节点失败 %w", err))
+			}
+			return article.NewMongoArticleDAO(mdb, node)
+		default:
+			panic(fmt.Errorf("未知的文章存储方式 %s", typ))
+		}
+	}
+	return article.NewDoubleWriteDAO(create(c.Src), create(c.Dst), l)
+}
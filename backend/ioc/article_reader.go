@@ -0,0 +1,33 @@
+package ioc
+
+import (
+	"fmt"
+	"github.com/johnwongx/webook/backend/internal/repository/dao/article"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/spf13/viper"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// InitReaderArticleDAO 读者侧可以放在单独的库里，没有配置 dsn 时和作者侧共用 db
+func InitReaderArticleDAO(db *gorm.DB, l logger.Logger) article.ReaderArticleDAO {
+	type Config struct {
+		DSN string `yaml:"dsn"`
+	}
+	var c Config
+	err := viper.UnmarshalKey("article.reader", &c)
+	if err != nil {
+		panic(fmt.Errorf("初始化配置失败 %v, 原因 %w", c, err))
+	}
+	if c.DSN != "" {
+		db, err = gorm.Open(mysql.Open(c.DSN), gormConfig(l))
+		if err != nil {
+			panic(err)
+		}
+	}
+	err = db.AutoMigrate(&article.ReaderArticle{})
+	if err != nil {
+		panic(err)
+	}
+	return article.NewGORMReaderArticleDAO(db)
+}
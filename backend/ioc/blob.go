@@ -0,0 +1,90 @@
+package ioc
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/johnwongx/webook/backend/internal/repository/dao/article"
+	"github.com/johnwongx/webook/backend/pkg/blobstore"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+	"os"
+)
+
+// BlobPathPrefix 本地存储时签名链接的访问路径
+const BlobPathPrefix = "/blob/"
+
+// BlobSecretEnv 本地存储签名链接的密钥，不要写在配置文件里
+const BlobSecretEnv = "WEBOOK_BLOB_SECRET"
+
+func InitBlobStore() blobstore.BlobStore {
+	type Config struct {
+		// Type local 或 s3
+		Type string `yaml:"type"`
+		// 本地存储
+		Root    string `yaml:"root"`
+		BaseURL string `yaml:"base_url" mapstructure:"base_url"`
+		// Secret 签名链接的密钥，没有配置时读取环境变量 BlobSecretEnv
+		Secret string `yaml:"secret"`
+		// S3 兼容的对象存储
+		Endpoint  string `yaml:"endpoint"`
+		Region    string `yaml:"region"`
+		Bucket    string `yaml:"bucket"`
+		AccessKey string `yaml:"access_key" mapstructure:"access_key"`
+		SecretKey string `yaml:"secret_key" mapstructure:"secret_key"`
+	}
+	c := Config{
+		Type:    "local",
+		Root:    "./data/blob",
+		BaseURL: "http://localhost:8080" + BlobPathPrefix,
+	}
+	err := viper.UnmarshalKey("blob", &c)
+	if err != nil {
+		panic(fmt.Errorf("初始化配置失败 %v, 原因 %w", c, err))
+	}
+	switch c.Type {
+	case "local":
+		if c.Secret == "" {
+			c.Secret = os.Getenv(BlobSecretEnv)
+		}
+		// 密钥泄露或者为空，任何人都能伪造下载链接
+		if c.Secret == "" {
+			panic(fmt.Errorf("没有配置签名链接的密钥 blob.secret 或环境变量 %s", BlobSecretEnv))
+		}
+		return blobstore.NewLocalStore(c.Root, c.BaseURL, []byte(c.Secret))
+	case "s3":
+		sess, err := session.NewSession(&aws.Config{
+			Credentials:      credentials.NewStaticCredentials(c.AccessKey, c.SecretKey, ""),
+			Endpoint:         aws.String(c.Endpoint),
+			Region:           aws.String(c.Region),
+			S3ForcePathStyle: aws.Bool(true),
+		})
+		if err != nil {
+			panic(fmt.Errorf("初始化对象存储失败 %w", err))
+		}
+		return blobstore.NewS3Store(s3.New(sess), c.Bucket)
+	default:
+		panic(fmt.Errorf("未知的对象存储类型 %s", c.Type))
+	}
+}
+
+// InitArticleDAO 根据配置决定线上库的文章内容是放在数据库还是对象存储，
+// 开启了存储迁移时使用双写的 DAO
+func InitArticleDAO(db *gorm.DB, store blobstore.BlobStore, migration *article.DoubleWriteDAO,
+	l logger.Logger) article.ArticleDAO {
+	if migration != nil {
+		return migration
+	}
+	storage := viper.GetString("article.storage")
+	switch storage {
+	case "", "db":
+		return article.NewGORMArticleDAO(db, l)
+	case "blob":
+		return article.NewS3DAO(store, db, l)
+	default:
+		panic(fmt.Errorf("未知的文章存储方式 %s", storage))
+	}
+}
@@ -0,0 +1,27 @@
+package ioc
+
+import (
+	"github.com/fsnotify/fsnotify"
+	"sync"
+)
+
+var (
+	configWatchersMu sync.RWMutex
+	configWatchers   []func(in fsnotify.Event)
+)
+
+// OnConfigChange viper 只会保留最后一个回调，需要监听配置变化的组件都通过这里注册
+func OnConfigChange(fn func(in fsnotify.Event)) {
+	configWatchersMu.Lock()
+	defer configWatchersMu.Unlock()
+	configWatchers = append(configWatchers, fn)
+}
+
+// NotifyConfigChange 由 main.initVipper 注册到 viper 上，依次通知所有组件
+func NotifyConfigChange(in fsnotify.Event) {
+	configWatchersMu.RLock()
+	defer configWatchersMu.RUnlock()
+	for _, fn := range configWatchers {
+		fn(in)
+	}
+}
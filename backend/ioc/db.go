@@ -23,13 +23,7 @@ func InitDB(l logger.Logger) *gorm.DB {
 	if err != nil {
 		panic(fmt.Errorf("初始化配置失败 %v, 原因 %w", c, err))
 	}
-	db, err := gorm.Open(mysql.Open(c.DSN), &gorm.Config{
-		Logger: glogger.New(gormLoggerFunc(l.Debug),
-			glogger.Config{
-				SlowThreshold: time.Millisecond * 50,
-				LogLevel:      glogger.Info,
-			}),
-	})
+	db, err := gorm.Open(mysql.Open(c.DSN), gormConfig(l))
 	if err != nil {
 		panic(err)
 	}
@@ -54,6 +48,16 @@ func InitDB(l logger.Logger) *gorm.DB {
 	return db
 }
 
+func gormConfig(l logger.Logger) *gorm.Config {
+	return &gorm.Config{
+		Logger: glogger.New(gormLoggerFunc(l.Debug),
+			glogger.Config{
+				SlowThreshold: time.Millisecond * 50,
+				LogLevel:      glogger.Info,
+			}),
+	}
+}
+
 type gormLoggerFunc func(msg string, fields ...logger.Field)
 
 func (g gormLoggerFunc) Printf(msg string, args ...interface{}) {
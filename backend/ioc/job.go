@@ -0,0 +1,27 @@
+package ioc
+
+import (
+	"github.com/johnwongx/webook/backend/internal/job"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"time"
+)
+
+func NewJobs(l logger.Logger, articleSchedule *job.ArticleScheduleJob,
+	searchIndex *job.SearchIndexJob, ranking *job.RankingJob,
+	articlePurge *job.ArticlePurgeJob, uploadGC *job.UploadGCJob,
+	articleExport *job.ArticleExportJob, sitemap *job.SitemapJob,
+	migrationStage *job.ArticleMigrationStageJob, migrationVerify *job.ArticleMigrationVerifyJob,
+	consistency *job.ArticleConsistencyJob) []*job.TickerScheduler {
+	return []*job.TickerScheduler{
+		job.NewTickerScheduler(articleSchedule, time.Second*10, l).Timeout(time.Minute),
+		job.NewTickerScheduler(searchIndex, time.Minute*10, l).RunOnStart(),
+		job.NewTickerScheduler(ranking, time.Minute*3, l).Timeout(time.Minute).RunOnStart(),
+		job.NewTickerScheduler(articlePurge, time.Hour, l).Timeout(time.Minute * 10),
+		job.NewTickerScheduler(uploadGC, time.Hour, l).Timeout(time.Minute * 10),
+		job.NewTickerScheduler(articleExport, time.Second*10, l).Timeout(time.Minute * 30),
+		job.NewTickerScheduler(sitemap, time.Minute, l).Timeout(time.Minute * 10).RunOnStart(),
+		job.NewTickerScheduler(migrationStage, time.Second*5, l).RunOnStart(),
+		job.NewTickerScheduler(migrationVerify, time.Minute, l).Timeout(time.Minute * 5),
+		job.NewTickerScheduler(consistency, time.Hour, l).Timeout(time.Minute * 30),
+	}
+}
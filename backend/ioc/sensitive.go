@@ -0,0 +1,41 @@
+package ioc
+
+import (
+	"github.com/fsnotify/fsnotify"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/johnwongx/webook/backend/pkg/sensitive"
+	"github.com/spf13/viper"
+)
+
+// InitSensitiveMatcher 词库放在配置文件的 sensitive 下，修改配置文件后自动重新加载
+func InitSensitiveMatcher(l logger.Logger) *sensitive.Matcher {
+	m := sensitive.NewMatcher()
+	load := func() {
+		type Config struct {
+			// Block 命中后直接拒绝发表
+			Block []string `yaml:"block"`
+			// Review 命中后进入人工审核
+			Review []string `yaml:"review"`
+		}
+		var c Config
+		err := viper.UnmarshalKey("sensitive", &c)
+		if err != nil {
+			// 保留原来的词库
+			l.Error("加载敏感词失败", logger.Error(err))
+			return
+		}
+		words := make(map[string]sensitive.Level, len(c.Block)+len(c.Review))
+		for _, w := range c.Review {
+			words[w] = sensitive.LevelReview
+		}
+		for _, w := range c.Block {
+			words[w] = sensitive.LevelBlock
+		}
+		m.Load(words)
+	}
+	load()
+	OnConfigChange(func(in fsnotify.Event) {
+		load()
+	})
+	return m
+}
@@ -0,0 +1,29 @@
+package ioc
+
+import (
+	"fmt"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/spf13/viper"
+	"strings"
+)
+
+func InitSite() domain.Site {
+	type Config struct {
+		URL         string `yaml:"url"`
+		Title       string `yaml:"title"`
+		Description string `yaml:"description"`
+	}
+	c := Config{
+		URL:   "http://localhost:3000",
+		Title: "webook",
+	}
+	err := viper.UnmarshalKey("site", &c)
+	if err != nil {
+		panic(fmt.Errorf("初始化配置失败 %v, 原因 %w", c, err))
+	}
+	return domain.Site{
+		URL:         strings.TrimSuffix(c.URL, "/"),
+		Title:       c.Title,
+		Description: c.Description,
+	}
+}
@@ -5,25 +5,54 @@ import (
 	"github.com/fsnotify/fsnotify"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/johnwongx/webook/backend/internal/service"
 	"github.com/johnwongx/webook/backend/internal/web"
 	"github.com/johnwongx/webook/backend/internal/web/jwt"
+	"github.com/johnwongx/webook/backend/internal/web/middleware"
+	"github.com/johnwongx/webook/backend/pkg/blobstore"
 	ginlogger "github.com/johnwongx/webook/backend/pkg/ginx/middlewares/logger"
 	"github.com/johnwongx/webook/backend/pkg/ginx/middlewares/metrics"
+	ginlimit "github.com/johnwongx/webook/backend/pkg/ginx/middlewares/ratelimit"
 	"github.com/johnwongx/webook/backend/pkg/logger"
 	"github.com/johnwongx/webook/backend/pkg/ratelimit"
 	"github.com/redis/go-redis/v9"
 	"github.com/spf13/viper"
+	"net/http"
 	"strings"
 	"time"
 )
 
 func InitWebServer(mdls []gin.HandlerFunc, userHdl *web.UserHandler, wechatHdl *web.OAuth2WechatHandler,
-	articleHdl *web.ArticleHandler) *gin.Engine {
+	articleHdl *web.ArticleHandler, searchHdl *web.SearchHandler, authorHdl *web.AuthorHandler,
+	rankingHdl *web.RankingHandler, followHdl *web.FollowHandler, commentHdl *web.CommentHandler,
+	collectionHdl *web.CollectionHandler, seriesHdl *web.SeriesHandler, uploadHdl *web.UploadHandler,
+	adminHdl *web.AdminHandler, exportHdl *web.ExportHandler,
+	importHdl *web.ImportHandler, syndicationHdl *web.SyndicationHandler,
+	sitemapHdl *web.SitemapHandler, historyHdl *web.ReadingHistoryHandler,
+	store blobstore.BlobStore) *gin.Engine {
 	server := gin.Default()
 	server.Use(mdls...)
+	// 本地存储没有对象存储的访问地址，签名链接由自己提供
+	if local, ok := store.(*blobstore.LocalStore); ok {
+		server.GET(BlobPathPrefix+"*key", gin.WrapH(http.StripPrefix(BlobPathPrefix, local.Handler())))
+	}
 	userHdl.RegisterRoutes(server)
 	wechatHdl.RegisterRoutes(server)
 	articleHdl.RegisterRutes(server)
+	searchHdl.RegisterRoutes(server)
+	authorHdl.RegisterRoutes(server)
+	rankingHdl.RegisterRoutes(server)
+	followHdl.RegisterRoutes(server)
+	commentHdl.RegisterRoutes(server)
+	collectionHdl.RegisterRoutes(server)
+	seriesHdl.RegisterRoutes(server)
+	uploadHdl.RegisterRoutes(server)
+	adminHdl.RegisterRoutes(server)
+	exportHdl.RegisterRoutes(server)
+	importHdl.RegisterRoutes(server)
+	syndicationHdl.RegisterRoutes(server)
+	sitemapHdl.RegisterRoutes(server)
+	historyHdl.RegisterRoutes(server)
 	return server
 }
 
@@ -35,7 +64,7 @@ func InitMiddlewares(limiter ratelimit.Limiter, j jwt.JwtHandler, l logger.Logge
 	gl := ginlogger.NewBuilder(func(ctx context.Context, al *ginlogger.AccessLog) {
 		l.Debug("HTTP请求", logger.Field{Key: "al", Value: al})
 	}).AllowReqBody(true).AllowRespBody(true)
-	viper.OnConfigChange(func(in fsnotify.Event) {
+	OnConfigChange(func(in fsnotify.Event) {
 		ok := viper.GetBool("web.logreq")
 		gl.AllowReqBody(ok)
 		ok = viper.GetBool("web.logresp")
@@ -45,25 +74,38 @@ func InitMiddlewares(limiter ratelimit.Limiter, j jwt.JwtHandler, l logger.Logge
 		corsHdl(),
 		gl.Build(),
 		(&metrics.MiddlewareBuilder{
-			Namespace: "john_server",
-			Subsystem: "webook",
-			Name: "gin_http",
-			Help: "统计 GIN 的 HTTP 接口",
+			Namespace:  "john_server",
+			Subsystem:  "webook",
+			Name:       "gin_http",
+			Help:       "统计 GIN 的 HTTP 接口",
 			InstanceId: "my-instance-1",
-		}).Build()),
-		middleware.NewLoginJWTMiddlewareBuilder(j).
-			IgnorePath("/users/signup").
-			IgnorePath("/users/login").
-			IgnorePath("/users/login_sms/code/send").
-			IgnorePath("/users/login_sms").
-			IgnorePath("/users/refresh_token").
-			IgnorePath("/oauth2/wechat/authurl").
-			IgnorePath("/oauth2/wechat/callback").
-			Builder(),
+		}).Build(),
+		loginHdl(j),
 		ginlimit.NewBuilder(limiter).Build(),
 	}
 }
 
+func loginHdl(j jwt.JwtHandler) gin.HandlerFunc {
+	return middleware.NewLoginJWTMiddlewareBuilder(j).
+		IgnorePath("/users/signup").
+		IgnorePath("/users/login").
+		IgnorePath("/users/login_sms/code/send").
+		IgnorePath("/users/login_sms").
+		IgnorePath("/users/refresh_token").
+		IgnorePath("/oauth2/wechat/authurl").
+		IgnorePath("/oauth2/wechat/callback").
+		IgnorePrefix(BlobPathPrefix).
+		IgnorePrefix(service.ImageURLPrefix).
+		// 订阅器无法登录
+		IgnorePath("/pub/" + web.SyndicationFileName).
+		IgnoreSuffix("/" + web.SyndicationFileName).
+		// 搜索引擎的爬虫
+		IgnorePath("/" + service.SitemapIndexName).
+		IgnorePath("/robots.txt").
+		IgnorePrefix(service.SitemapPathPrefix).
+		Builder()
+}
+
 func corsHdl() gin.HandlerFunc {
 	return cors.New(cors.Config{
 		AllowHeaders:     []string{"Content-Type", "Authorization"},
@@ -0,0 +1,150 @@
+package ioc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/johnwongx/webook/backend/internal/domain"
+	"github.com/johnwongx/webook/backend/internal/service"
+	svcmocks "github.com/johnwongx/webook/backend/internal/service/mocks"
+	"github.com/johnwongx/webook/backend/internal/web"
+	"github.com/johnwongx/webook/backend/internal/web/jwt"
+	"github.com/johnwongx/webook/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// 不带 token 访问不需要登录的地址
+func TestLoginHdl_Public(t *testing.T) {
+	feed := domain.SyndicationFeed{
+		Format:       domain.SyndicationFormatRSS,
+		Body:         []byte("<rss></rss>"),
+		ETag:         `"abc"`,
+		LastModified: time.UnixMilli(100),
+	}
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) service.SyndicationService
+		path     string
+		wantCode int
+	}{
+		{
+			name: "全站订阅源",
+			mock: func(ctrl *gomock.Controller) service.SyndicationService {
+				svc := svcmocks.NewMockSyndicationService(ctrl)
+				svc.EXPECT().SiteFeed(gomock.Any(), domain.SyndicationFormatRSS).Return(feed, nil)
+				return svc
+			},
+			path:     "/pub/feed.xml",
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "作者订阅源",
+			mock: func(ctrl *gomock.Controller) service.SyndicationService {
+				svc := svcmocks.NewMockSyndicationService(ctrl)
+				svc.EXPECT().AuthorFeed(gomock.Any(), int64(123), domain.SyndicationFormatAtom).Return(feed, nil)
+				return svc
+			},
+			path:     "/pub/authors/123/feed.xml?format=atom",
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "作者不存在",
+			mock: func(ctrl *gomock.Controller) service.SyndicationService {
+				svc := svcmocks.NewMockSyndicationService(ctrl)
+				svc.EXPECT().AuthorFeed(gomock.Any(), int64(123), domain.SyndicationFormatRSS).
+					Return(domain.SyndicationFeed{}, service.ErrUserNotFound)
+				return svc
+			},
+			path:     "/pub/authors/123/feed.xml",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "其他地址仍然需要登录",
+			mock: func(ctrl *gomock.Controller) service.SyndicationService {
+				return svcmocks.NewMockSyndicationService(ctrl)
+			},
+			path:     "/pub/authors/123",
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.New()
+			// 不带 token 的请求走不到 redis
+			server.Use(loginHdl(jwt.NewRedisJwtHandler(nil)))
+			web.NewSyndicationHandler(tc.mock(ctrl), logger.NewNopLogger()).RegisterRoutes(server)
+			server.GET("/pub/authors/:uid", func(ctx *gin.Context) {
+				ctx.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+			assert.Equal(t, tc.wantCode, resp.Code)
+		})
+	}
+}
+
+func TestLoginHdl_Sitemap(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) service.SitemapService
+		path     string
+		wantCode int
+	}{
+		{
+			name: "sitemap 索引",
+			mock: func(ctrl *gomock.Controller) service.SitemapService {
+				svc := svcmocks.NewMockSitemapService(ctrl)
+				svc.EXPECT().Get(gomock.Any(), service.SitemapIndexName).Return([]byte("<sitemapindex/>"), nil)
+				return svc
+			},
+			path:     "/sitemap.xml",
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "分页的 sitemap",
+			mock: func(ctrl *gomock.Controller) service.SitemapService {
+				svc := svcmocks.NewMockSitemapService(ctrl)
+				svc.EXPECT().Get(gomock.Any(), "sitemap-1.xml").Return(nil, service.ErrSitemapNotFound)
+				return svc
+			},
+			path:     "/sitemaps/sitemap-1.xml",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "robots.txt",
+			mock: func(ctrl *gomock.Controller) service.SitemapService {
+				svc := svcmocks.NewMockSitemapService(ctrl)
+				svc.EXPECT().Robots().Return("User-agent: *\n")
+				return svc
+			},
+			path:     "/robots.txt",
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.New()
+			server.Use(loginHdl(jwt.NewRedisJwtHandler(nil)))
+			web.NewSitemapHandler(tc.mock(ctrl), logger.NewNopLogger()).RegisterRoutes(server)
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+			assert.Equal(t, tc.wantCode, resp.Code)
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"github.com/fsnotify/fsnotify"
+	"github.com/johnwongx/webook/backend/ioc"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -10,12 +12,23 @@ import (
 	"net/http"
 )
 
+// replayReader 用作者侧重建读者侧后退出，不启动服务
+var replayReader = pflag.Bool("replay-reader", false, "用作者侧重建读者侧的文章后退出")
+
 func main() {
 	initVipper()
 	initLogger()
-	initPrometheus()
 
 	app := InitWebServer()
+	if *replayReader {
+		n, err := app.readerSvc.Replay(context.Background())
+		if err != nil {
+			panic(fmt.Errorf("重放读者侧失败，已写入 %d 篇，原因 %w", n, err))
+		}
+		fmt.Printf("重放读者侧完成，共 %d 篇\n", n)
+		return
+	}
+	initPrometheus()
 
 	for _, consumer := range app.consumers {
 		err := consumer.Start()
@@ -24,6 +37,13 @@ func main() {
 		}
 	}
 
+	for _, j := range app.jobs {
+		err := j.Start()
+		if err != nil {
+			panic(err)
+		}
+	}
+
 	app.server.Run(":8080")
 }
 
@@ -51,6 +71,7 @@ func initVipper() {
 	viper.OnConfigChange(func(in fsnotify.Event) {
 		// 重新加载配置内容
 		fmt.Sprintln("Config file changed")
+		ioc.NotifyConfigChange(in)
 	})
 	err := viper.ReadInConfig()
 	if err != nil {
@@ -0,0 +1,104 @@
+package blobstore
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore_PutGetDelete(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalStore(t.TempDir(), "http://localhost/blob", []byte("secret"))
+
+	_, err := store.Get(ctx, "articles/1")
+	assert.Equal(t, ErrNotFound, err)
+
+	err = store.Put(ctx, "articles/1", []byte("hello"), "text/plain")
+	require.NoError(t, err)
+	err = store.Put(ctx, "articles/1", []byte("world"), "text/plain")
+	require.NoError(t, err)
+	data, err := store.Get(ctx, "articles/1")
+	require.NoError(t, err)
+	assert.Equal(t, "world", string(data))
+
+	require.NoError(t, store.Delete(ctx, "articles/1"))
+	_, err = store.Get(ctx, "articles/1")
+	assert.Equal(t, ErrNotFound, err)
+	// 删除不存在的对象不报错
+	assert.NoError(t, store.Delete(ctx, "articles/1"))
+}
+
+func TestLocalStore_InvalidKey(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalStore(t.TempDir(), "http://localhost/blob", []byte("secret"))
+	for _, key := range []string{"", "/abs", "../escape", "a/../../b", "a//b", `a\b`} {
+		err := store.Put(ctx, key, []byte("x"), "")
+		assert.Equal(t, ErrInvalidKey, err, key)
+		_, err = store.Get(ctx, key)
+		assert.Equal(t, ErrInvalidKey, err, key)
+	}
+}
+
+func TestLocalStore_SignedURL(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalStore(t.TempDir(), "http://localhost/blob/", []byte("secret"))
+	require.NoError(t, store.Put(ctx, "exports/1.zip", []byte("zip"), "application/zip"))
+
+	testCases := []struct {
+		name     string
+		url      func(t *testing.T) string
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "签名有效",
+			url: func(t *testing.T) string {
+				u, err := store.SignedURL(ctx, "exports/1.zip", time.Minute)
+				require.NoError(t, err)
+				return u
+			},
+			wantCode: http.StatusOK,
+			wantBody: "zip",
+		},
+		{
+			name: "签名被篡改",
+			url: func(t *testing.T) string {
+				u, err := store.SignedURL(ctx, "exports/1.zip", time.Minute)
+				require.NoError(t, err)
+				return strings.Replace(u, "1.zip", "2.zip", 1)
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "已过期",
+			url: func(t *testing.T) string {
+				u, err := store.SignedURL(ctx, "exports/1.zip", -time.Minute)
+				require.NoError(t, err)
+				return u
+			},
+			wantCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			u, err := url.Parse(tc.url(t))
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(u.Path, "/blob/"))
+			req := httptest.NewRequest(http.MethodGet, u.RequestURI(), nil)
+			recorder := httptest.NewRecorder()
+			http.StripPrefix("/blob/", store.Handler()).ServeHTTP(recorder, req)
+			assert.Equal(t, tc.wantCode, recorder.Code)
+			if tc.wantBody != "" {
+				assert.Equal(t, tc.wantBody, recorder.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotFound 对象不存在
+	ErrNotFound = errors.New("对象不存在")
+	// ErrInvalidKey key 为空、是绝对路径或者包含 ..
+	ErrInvalidKey = errors.New("非法的对象 key")
+)
+
+// BlobStore 对象存储，key 用 / 分隔层级
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get 对象不存在时返回 ErrNotFound
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete 对象不存在时什么也不做
+	Delete(ctx context.Context, key string) error
+	// SignedURL 生成 expiration 内有效的下载链接
+	SignedURL(ctx context.Context, key string, expiration time.Duration) (string, error)
+}
+
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+	for _, seg := range strings.Split(key, "/") {
+		if seg == "" || seg == "." || seg == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
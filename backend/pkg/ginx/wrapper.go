@@ -23,12 +23,12 @@ func WrapToken[C jwt.Claims](fn func(ctx *gin.Context, uc C) (Result, error), l
 
 		res, err := fn(ctx, c)
 		if err != nil {
-			ctx.JSON(http.StatusOK, res)
+			ctx.JSON(res.status(), res)
 			l.Error("Req error", logger.Error(err))
 			return
 		}
 
-		ctx.JSON(http.StatusOK, res)
+		ctx.JSON(res.status(), res)
 	}
 }
 
@@ -58,12 +58,26 @@ func WrapReqToken[T any, C jwt.Claims](fn func(ctx *gin.Context, req T, uc C) (R
 
 		res, err := fn(ctx, req, c)
 		if err != nil {
-			ctx.JSON(http.StatusOK, res)
+			ctx.JSON(res.status(), res)
 			l.Error("Req error", logger.Error(err))
 			return
 		}
 
-		ctx.JSON(http.StatusOK, res)
+		ctx.JSON(res.status(), res)
+	}
+}
+
+// Wrap 不需要请求参数和登录信息的接口
+func Wrap(fn func(ctx *gin.Context) (Result, error), l logger.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		res, err := fn(ctx)
+		if err != nil {
+			ctx.JSON(res.status(), res)
+			l.Error("Req error", logger.Error(err))
+			return
+		}
+
+		ctx.JSON(res.status(), res)
 	}
 }
 
@@ -82,12 +96,12 @@ func WrapReq[T any](fn func(ctx *gin.Context, req T) (Result, error), l logger.L
 
 		res, err := fn(ctx, req)
 		if err != nil {
-			ctx.JSON(http.StatusOK, res)
+			ctx.JSON(res.status(), res)
 			l.Error("Req error", logger.Error(err))
 			return
 		}
 
-		ctx.JSON(http.StatusOK, res)
+		ctx.JSON(res.status(), res)
 	}
 }
 
@@ -95,4 +109,13 @@ type Result struct {
 	Code int    `json:"code"`
 	Msg  string `json:"message"`
 	Data any    `json:"data"`
+	// Status 响应的 HTTP 状态码，为 0 时是 200
+	Status int `json:"-"`
+}
+
+func (r Result) status() int {
+	if r.Status == 0 {
+		return http.StatusOK
+	}
+	return r.Status
 }
@@ -0,0 +1,59 @@
+// Package imagex 图片处理的辅助方法，只依赖标准库
+package imagex
+
+import (
+	"image"
+	"image/color"
+)
+
+// Fit 按比例缩小到宽度不超过 maxWidth，本身不超过时原样返回
+func Fit(src image.Image, maxWidth int) image.Image {
+	b := src.Bounds()
+	if maxWidth <= 0 || b.Dx() <= maxWidth {
+		return src
+	}
+	h := b.Dy() * maxWidth / b.Dx()
+	if h < 1 {
+		h = 1
+	}
+	return Resize(src, maxWidth, h)
+}
+
+// Resize 按区域平均缩放到 w*h，适合缩小，放大时退化为最近邻
+func Resize(src image.Image, w, h int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	for y := 0; y < h; y++ {
+		y0 := b.Min.Y + y*sh/h
+		y1 := b.Min.Y + (y+1)*sh/h
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < w; x++ {
+			x0 := b.Min.X + x*sw/w
+			x1 := b.Min.X + (x+1)*sw/w
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					bl += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+			dst.SetRGBA64(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(bl / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
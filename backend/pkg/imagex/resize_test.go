@@ -0,0 +1,56 @@
+package imagex
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFit(t *testing.T) {
+	testCases := []struct {
+		name     string
+		src      image.Rectangle
+		maxWidth int
+		want     image.Rectangle
+	}{
+		{
+			name:     "按比例缩小",
+			src:      image.Rect(0, 0, 400, 300),
+			maxWidth: 200,
+			want:     image.Rect(0, 0, 200, 150),
+		},
+		{
+			name:     "不放大",
+			src:      image.Rect(0, 0, 100, 80),
+			maxWidth: 200,
+			want:     image.Rect(0, 0, 100, 80),
+		},
+		{
+			name:     "细长图片高度至少为 1",
+			src:      image.Rect(0, 0, 1000, 1),
+			maxWidth: 10,
+			want:     image.Rect(0, 0, 10, 1),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := Fit(image.NewRGBA(tc.src), tc.maxWidth)
+			assert.Equal(t, tc.want, res.Bounds())
+		})
+	}
+}
+
+func TestResize(t *testing.T) {
+	// 左半边黑，右半边白，缩到 2*1 后颜色不变
+	src := image.NewGray(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 2; x < 4; x++ {
+			src.SetGray(x, y, color.Gray{Y: 255})
+		}
+	}
+	dst := Resize(src, 2, 1)
+	assert.Equal(t, color.RGBA{A: 255}, dst.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{R: 255, G: 255, B: 255, A: 255}, dst.RGBAAt(1, 0))
+}
@@ -0,0 +1,381 @@
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	fenceRe     = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})[ \t]*([^`\\s]*)")
+	headingRe   = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	quoteRe     = regexp.MustCompile(`^ {0,3}> ?`)
+	listItemRe  = regexp.MustCompile(`^( {0,3})([-*+]|\d{1,9}[.)])( +|$)(.*)$`)
+	htmlBlockRe = regexp.MustCompile(`^ {0,3}<(/?[A-Za-z][A-Za-z0-9-]*|!--)`)
+	tableSepRe  = regexp.MustCompile(`^ *\|? *:?-+:? *(\| *:?-+:? *)*\|? *$`)
+)
+
+// blockRenderer 逐行解析块级元素，支持标题、段落、引用、列表、代码块、分割线、表格和 HTML 块
+type blockRenderer struct {
+	sb *strings.Builder
+}
+
+func (b *blockRenderer) render(lines []string, tight bool) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case isBlank(line):
+			i++
+		case fenceRe.MatchString(line):
+			i = b.fencedCode(lines, i)
+		case headingRe.MatchString(line):
+			m := headingRe.FindStringSubmatch(line)
+			level := strconv.Itoa(len(m[1]))
+			b.sb.WriteString("<h" + level + ">")
+			b.sb.WriteString(renderInline(m[2]))
+			b.sb.WriteString("</h" + level + ">\n")
+			i++
+		case isThematicBreak(line):
+			b.sb.WriteString("<hr>\n")
+			i++
+		case quoteRe.MatchString(line):
+			i = b.blockquote(lines, i)
+		case listItemRe.MatchString(line):
+			i = b.list(lines, i)
+		case htmlBlockRe.MatchString(line):
+			i = b.htmlBlock(lines, i)
+		case indentWidth(line) >= 4:
+			i = b.indentedCode(lines, i)
+		case i+1 < len(lines) && isTableStart(line, lines[i+1]):
+			i = b.table(lines, i)
+		default:
+			i = b.paragraph(lines, i, tight)
+		}
+	}
+}
+
+func (b *blockRenderer) fencedCode(lines []string, start int) int {
+	m := fenceRe.FindStringSubmatch(lines[start])
+	fence, lang := m[1], m[2]
+	indent := indentWidth(lines[start])
+	b.sb.WriteString("<pre><code")
+	if lang != "" {
+		b.sb.WriteString(` class="language-` + html.EscapeString(lang) + `"`)
+	}
+	b.sb.WriteString(">")
+	i := start + 1
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			i++
+			break
+		}
+		b.sb.WriteString(html.EscapeString(dedent(lines[i], indent)))
+		b.sb.WriteString("\n")
+	}
+	b.sb.WriteString("</code></pre>\n")
+	return i
+}
+
+func (b *blockRenderer) indentedCode(lines []string, start int) int {
+	end := start
+	for i := start; i < len(lines); i++ {
+		if isBlank(lines[i]) {
+			continue
+		}
+		if indentWidth(lines[i]) < 4 {
+			break
+		}
+		end = i + 1
+	}
+	b.sb.WriteString("<pre><code>")
+	for _, line := range lines[start:end] {
+		b.sb.WriteString(html.EscapeString(dedent(line, 4)))
+		b.sb.WriteString("\n")
+	}
+	b.sb.WriteString("</code></pre>\n")
+	return end
+}
+
+func (b *blockRenderer) blockquote(lines []string, start int) int {
+	var inner []string
+	i := start
+	for ; i < len(lines) && quoteRe.MatchString(lines[i]); i++ {
+		inner = append(inner, quoteRe.ReplaceAllString(lines[i], ""))
+	}
+	b.sb.WriteString("<blockquote>\n")
+	b.render(inner, false)
+	b.sb.WriteString("</blockquote>\n")
+	return i
+}
+
+func (b *blockRenderer) list(lines []string, start int) int {
+	first := listItemRe.FindStringSubmatch(lines[start])
+	ordered := isOrderedMarker(first[2])
+	if ordered {
+		n, _ := strconv.Atoi(first[2][:len(first[2])-1])
+		if n != 1 {
+			b.sb.WriteString(`<ol start="` + strconv.Itoa(n) + `">` + "\n")
+		} else {
+			b.sb.WriteString("<ol>\n")
+		}
+	} else {
+		b.sb.WriteString("<ul>\n")
+	}
+
+	var (
+		items [][]string
+		loose bool
+		i     = start
+	)
+	for i < len(lines) {
+		m := listItemRe.FindStringSubmatch(lines[i])
+		if m == nil || isOrderedMarker(m[2]) != ordered {
+			break
+		}
+		contentIndent := len(m[1]) + len(m[2]) + 1
+		item := []string{m[4]}
+		i++
+		for i < len(lines) {
+			line := lines[i]
+			if isBlank(line) {
+				// 空行后面还是本项的内容或者下一项，说明是松散列表
+				j := i
+				for j < len(lines) && isBlank(lines[j]) {
+					j++
+				}
+				if j < len(lines) && (indentWidth(lines[j]) >= contentIndent || isSameListItem(lines[j], ordered)) {
+					loose = true
+					item = append(item, lines[i:j]...)
+					i = j
+					continue
+				}
+				break
+			}
+			if indentWidth(line) >= 2 {
+				item = append(item, dedent(line, contentIndent))
+				i++
+				continue
+			}
+			if isSameListItem(line, ordered) || startsBlock(line) {
+				break
+			}
+			// 懒惰续行，属于上一段
+			item = append(item, line)
+			i++
+		}
+		items = append(items, trimBlankTail(item))
+	}
+
+	for _, item := range items {
+		b.sb.WriteString("<li>")
+		b.render(item, !loose)
+		b.sb.WriteString("</li>\n")
+	}
+	if ordered {
+		b.sb.WriteString("</ol>\n")
+	} else {
+		b.sb.WriteString("</ul>\n")
+	}
+	return i
+}
+
+func (b *blockRenderer) htmlBlock(lines []string, start int) int {
+	i := start
+	for ; i < len(lines) && !isBlank(lines[i]); i++ {
+		b.sb.WriteString(lines[i])
+		b.sb.WriteString("\n")
+	}
+	return i
+}
+
+func (b *blockRenderer) table(lines []string, start int) int {
+	header := splitRow(lines[start])
+	aligns := make([]string, 0, len(header))
+	for _, cell := range splitRow(lines[start+1]) {
+		left, right := strings.HasPrefix(cell, ":"), strings.HasSuffix(cell, ":")
+		switch {
+		case left && right:
+			aligns = append(aligns, "center")
+		case right:
+			aligns = append(aligns, "right")
+		case left:
+			aligns = append(aligns, "left")
+		default:
+			aligns = append(aligns, "")
+		}
+	}
+	b.sb.WriteString("<table>\n<thead>\n")
+	b.tableRow(header, aligns, "th")
+	b.sb.WriteString("</thead>\n")
+	i := start + 2
+	if i < len(lines) && !isBlank(lines[i]) && strings.Contains(lines[i], "|") {
+		b.sb.WriteString("<tbody>\n")
+		for ; i < len(lines) && !isBlank(lines[i]) && strings.Contains(lines[i], "|"); i++ {
+			b.tableRow(splitRow(lines[i]), aligns, "td")
+		}
+		b.sb.WriteString("</tbody>\n")
+	}
+	b.sb.WriteString("</table>\n")
+	return i
+}
+
+func (b *blockRenderer) tableRow(cells []string, aligns []string, tag string) {
+	b.sb.WriteString("<tr>")
+	// 列数以表头为准，多的丢弃，少的补空
+	for idx, align := range aligns {
+		b.sb.WriteString("<" + tag)
+		if align != "" {
+			b.sb.WriteString(` align="` + align + `"`)
+		}
+		b.sb.WriteString(">")
+		if idx < len(cells) {
+			b.sb.WriteString(renderInline(cells[idx]))
+		}
+		b.sb.WriteString("</" + tag + ">")
+	}
+	b.sb.WriteString("</tr>\n")
+}
+
+func (b *blockRenderer) paragraph(lines []string, start int, tight bool) int {
+	i := start
+	var sb strings.Builder
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if isBlank(line) || (i > start && startsBlock(line)) {
+			break
+		}
+		if i > start {
+			prev := lines[i-1]
+			if strings.HasSuffix(prev, "  ") || strings.HasSuffix(prev, "\\") {
+				sb.WriteString("\u0000")
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString(strings.TrimSpace(line))
+	}
+	text := strings.TrimSuffix(sb.String(), "\\")
+	// 行尾两个空格或反斜杠表示硬换行
+	text = strings.ReplaceAll(renderInline(strings.ReplaceAll(text, "\\\u0000", "\u0000")), "\u0000", "<br>")
+	if tight {
+		b.sb.WriteString(text)
+		b.sb.WriteString("\n")
+		return i
+	}
+	b.sb.WriteString("<p>")
+	b.sb.WriteString(text)
+	b.sb.WriteString("</p>\n")
+	return i
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+// indentWidth 行首缩进的宽度，制表符按 4 个空格算
+func indentWidth(line string) int {
+	w := 0
+	for _, c := range line {
+		switch c {
+		case ' ':
+			w++
+		case '\t':
+			w += 4 - w%4
+		default:
+			return w
+		}
+	}
+	return w
+}
+
+// dedent 去掉最多 n 个宽度的缩进
+func dedent(line string, n int) string {
+	w := 0
+	for i, c := range line {
+		if w >= n {
+			return line[i:]
+		}
+		switch c {
+		case ' ':
+			w++
+		case '\t':
+			w += 4 - w%4
+		default:
+			return line[i:]
+		}
+	}
+	return ""
+}
+
+func isThematicBreak(line string) bool {
+	if indentWidth(line) > 3 {
+		return false
+	}
+	s := strings.ReplaceAll(strings.TrimSpace(line), " ", "")
+	if len(s) < 3 {
+		return false
+	}
+	c := s[0]
+	if c != '-' && c != '*' && c != '_' {
+		return false
+	}
+	return strings.Count(s, string(c)) == len(s)
+}
+
+func isOrderedMarker(marker string) bool {
+	return marker[0] >= '0' && marker[0] <= '9'
+}
+
+func isSameListItem(line string, ordered bool) bool {
+	m := listItemRe.FindStringSubmatch(line)
+	return m != nil && isOrderedMarker(m[2]) == ordered
+}
+
+// startsBlock 该行会打断段落，开始一个新的块
+func startsBlock(line string) bool {
+	return fenceRe.MatchString(line) || headingRe.MatchString(line) ||
+		isThematicBreak(line) || quoteRe.MatchString(line) ||
+		htmlBlockRe.MatchString(line) || listItemRe.MatchString(line)
+}
+
+func isTableStart(line, next string) bool {
+	if !strings.Contains(line, "|") || !tableSepRe.MatchString(next) || !strings.Contains(next, "-") {
+		return false
+	}
+	return len(splitRow(line)) == len(splitRow(next))
+}
+
+// splitRow 按未转义的 | 切分表格的一行
+func splitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, "\\|") {
+		line = line[:len(line)-1]
+	}
+	var (
+		cells []string
+		cur   strings.Builder
+	)
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' && i+1 < len(line) && line[i+1] == '|' {
+			cur.WriteByte('|')
+			i++
+			continue
+		}
+		if line[i] == '|' {
+			cells = append(cells, strings.TrimSpace(cur.String()))
+			cur.Reset()
+			continue
+		}
+		cur.WriteByte(line[i])
+	}
+	return append(cells, strings.TrimSpace(cur.String()))
+}
+
+func trimBlankTail(lines []string) []string {
+	for len(lines) > 0 && isBlank(lines[len(lines)-1]) {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
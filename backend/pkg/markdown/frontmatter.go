@@ -0,0 +1,49 @@
+package markdown
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const frontMatterDelimiter = "---"
+
+// WithFrontMatter 把 meta 编码成 YAML，放在正文前面的 --- 之间
+func WithFrontMatter(meta any, body string) (string, error) {
+	bs, err := yaml.Marshal(meta)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	sb.Grow(len(bs) + len(body) + 2*len(frontMatterDelimiter) + 3)
+	sb.WriteString(frontMatterDelimiter + "\n")
+	sb.Write(bs)
+	sb.WriteString(frontMatterDelimiter + "\n\n")
+	sb.WriteString(body)
+	return sb.String(), nil
+}
+
+// ParseFrontMatter 把开头 --- 之间的 YAML 解析到 meta 中，返回剩下的正文；
+// 没有 front matter 时 meta 不变，原样返回 src
+func ParseFrontMatter(src string, meta any) (string, error) {
+	src = strings.TrimPrefix(src, "\ufeff")
+	first, rest, ok := strings.Cut(src, "\n")
+	if !ok || strings.TrimRight(first, " \t\r") != frontMatterDelimiter {
+		return src, nil
+	}
+	var yml strings.Builder
+	for {
+		line, next, ok := strings.Cut(rest, "\n")
+		if strings.TrimRight(line, " \t\r") == frontMatterDelimiter {
+			err := yaml.Unmarshal([]byte(yml.String()), meta)
+			return strings.TrimLeft(next, "\r\n"), err
+		}
+		if !ok {
+			// 没有结束的分隔符，不当作 front matter
+			return src, nil
+		}
+		yml.WriteString(line)
+		yml.WriteByte('\n')
+		rest = next
+	}
+}
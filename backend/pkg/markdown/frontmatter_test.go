@@ -0,0 +1,114 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithFrontMatter(t *testing.T) {
+	type meta struct {
+		Title string   `yaml:"title"`
+		Tags  []string `yaml:"tags,omitempty"`
+	}
+	testCases := []struct {
+		name string
+		meta meta
+		body string
+		want string
+	}{
+		{
+			name: "普通字段",
+			meta: meta{Title: "Hello", Tags: []string{"go"}},
+			body: "# Hello\n",
+			want: "---\ntitle: Hello\ntags:\n    - go\n---\n\n# Hello\n",
+		},
+		{
+			name: "标题里的特殊字符会被转义",
+			meta: meta{Title: "a: b # c"},
+			body: "body",
+			want: "---\ntitle: 'a: b # c'\n---\n\nbody",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := WithFrontMatter(tc.meta, tc.body)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func TestParseFrontMatter(t *testing.T) {
+	type meta struct {
+		Title string   `yaml:"title"`
+		Tags  []string `yaml:"tags"`
+	}
+	testCases := []struct {
+		name     string
+		src      string
+		wantMeta meta
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "有 front matter",
+			src:      "---\ntitle: Hello\ntags: [go, gin]\n---\n\n# Hello\n",
+			wantMeta: meta{Title: "Hello", Tags: []string{"go", "gin"}},
+			wantBody: "# Hello\n",
+		},
+		{
+			name:     "Windows 换行和 BOM",
+			src:      "\ufeff---\r\ntitle: Hello\r\n---\r\nbody",
+			wantMeta: meta{Title: "Hello"},
+			wantBody: "body",
+		},
+		{
+			name:     "没有 front matter",
+			src:      "# Hello\n---\n",
+			wantBody: "# Hello\n---\n",
+		},
+		{
+			name:     "没有结束的分隔符",
+			src:      "---\ntitle: Hello\n",
+			wantBody: "---\ntitle: Hello\n",
+		},
+		{
+			name:     "结束分隔符在最后一行",
+			src:      "---\ntitle: Hello\n---",
+			wantMeta: meta{Title: "Hello"},
+		},
+		{
+			name:    "YAML 格式错误",
+			src:     "---\ntitle: [\n---\nbody",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var m meta
+			body, err := ParseFrontMatter(tc.src, &m)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantMeta, m)
+			assert.Equal(t, tc.wantBody, body)
+		})
+	}
+}
+
+func TestFrontMatter_RoundTrip(t *testing.T) {
+	type meta struct {
+		Title string `yaml:"title"`
+	}
+	src, err := WithFrontMatter(meta{Title: "a: b"}, "body\n")
+	require.NoError(t, err)
+	var m meta
+	body, err := ParseFrontMatter(src, &m)
+	require.NoError(t, err)
+	assert.Equal(t, meta{Title: "a: b"}, m)
+	assert.Equal(t, "body\n", body)
+}
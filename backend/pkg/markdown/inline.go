@@ -0,0 +1,336 @@
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	autolinkRe  = regexp.MustCompile(`^<((?:https?://|mailto:)[^\s<>]*)>`)
+	inlineTagRe = regexp.MustCompile(`^</?[A-Za-z][A-Za-z0-9-]*(?:\s+[^<>]*)?/?>`)
+	entityRe    = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[A-Za-z][A-Za-z0-9]{1,31});`)
+)
+
+// renderInline 渲染行内元素：转义、代码、链接、图片、强调、删除线以及行内 HTML。
+// 不追求完全符合 CommonMark，非法或不配对的标记按原文输出
+func renderInline(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch c {
+		case '\\':
+			if i+1 < len(s) && isASCIIPunct(s[i+1]) {
+				sb.WriteString(html.EscapeString(s[i+1 : i+2]))
+				i += 2
+				continue
+			}
+		case '`':
+			if end, ok := codeSpan(s, i, &sb); ok {
+				i = end
+				continue
+			}
+			n := runLength(s, i, '`')
+			sb.WriteString(s[i : i+n])
+			i += n
+			continue
+		case '!':
+			if i+1 < len(s) && s[i+1] == '[' {
+				if l, ok := parseLink(s, i+1); ok {
+					sb.WriteString(`<img src="` + html.EscapeString(l.dest) + `" alt="` +
+						html.EscapeString(plainInline(l.text)) + `"`)
+					if l.title != "" {
+						sb.WriteString(` title="` + html.EscapeString(l.title) + `"`)
+					}
+					sb.WriteString(">")
+					i = l.end
+					continue
+				}
+			}
+		case '[':
+			if l, ok := parseLink(s, i); ok {
+				sb.WriteString(`<a href="` + html.EscapeString(l.dest) + `"`)
+				if l.title != "" {
+					sb.WriteString(` title="` + html.EscapeString(l.title) + `"`)
+				}
+				sb.WriteString(">" + renderInline(l.text) + "</a>")
+				i = l.end
+				continue
+			}
+		case '<':
+			if m := autolinkRe.FindStringSubmatch(s[i:]); m != nil {
+				sb.WriteString(`<a href="` + html.EscapeString(m[1]) + `">` + html.EscapeString(m[1]) + "</a>")
+				i += len(m[0])
+				continue
+			}
+			// 行内 HTML 原样输出，由 Sanitize 过滤
+			if m := inlineTagRe.FindString(s[i:]); m != "" {
+				sb.WriteString(m)
+				i += len(m)
+				continue
+			}
+		case '&':
+			if m := entityRe.FindString(s[i:]); m != "" {
+				sb.WriteString(m)
+				i += len(m)
+				continue
+			}
+		case '*', '_':
+			if end, ok := emphasis(s, i, &sb); ok {
+				i = end
+				continue
+			}
+			n := runLength(s, i, c)
+			sb.WriteString(s[i : i+n])
+			i += n
+			continue
+		case '~':
+			if strings.HasPrefix(s[i:], "~~") {
+				if j := strings.Index(s[i+2:], "~~"); j > 0 {
+					sb.WriteString("<del>" + renderInline(s[i+2:i+2+j]) + "</del>")
+					i += j + 4
+					continue
+				}
+			}
+		}
+		sb.WriteString(html.EscapeString(s[i : i+1]))
+		i++
+	}
+	return sb.String()
+}
+
+// codeSpan 反引号包裹的行内代码，开始和结束的反引号数量必须相同
+func codeSpan(s string, start int, sb *strings.Builder) (int, bool) {
+	n := runLength(s, start, '`')
+	for j := start + n; j < len(s); {
+		k := strings.IndexByte(s[j:], '`')
+		if k < 0 {
+			return 0, false
+		}
+		j += k
+		m := runLength(s, j, '`')
+		if m == n {
+			code := strings.ReplaceAll(s[start+n:j], "\n", " ")
+			if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.Trim(code, " ") != "" {
+				code = code[1 : len(code)-1]
+			}
+			sb.WriteString("<code>" + html.EscapeString(code) + "</code>")
+			return j + m, true
+		}
+		j += m
+	}
+	return 0, false
+}
+
+// emphasis 处理 *em*、_em_、**strong**、__strong__
+func emphasis(s string, start int, sb *strings.Builder) (int, bool) {
+	d := s[start]
+	n := runLength(s, start, d)
+	// 下划线在单词内部不算强调，比如 snake_case
+	if d == '_' && isWordBefore(s, start) {
+		return 0, false
+	}
+	for _, size := range []int{2, 1} {
+		if n < size {
+			continue
+		}
+		open := start + size
+		if open >= len(s) || isSpaceAt(s, open) {
+			continue
+		}
+		if end, ok := findCloser(s, open, d, size); ok {
+			tag := "em"
+			if size == 2 {
+				tag = "strong"
+			}
+			sb.WriteString("<" + tag + ">" + renderInline(s[open:end]) + "</" + tag + ">")
+			return end + size, true
+		}
+	}
+	return 0, false
+}
+
+// findCloser 找到和开始标记长度相同的结束标记，结束标记前不能是空白
+func findCloser(s string, from int, d byte, size int) (int, bool) {
+	for j := from; j < len(s); {
+		switch s[j] {
+		case '`':
+			// 跳过行内代码，代码里的标记不参与配对
+			var tmp strings.Builder
+			if end, ok := codeSpan(s, j, &tmp); ok {
+				j = end
+				continue
+			}
+		case '\\':
+			j += 2
+			continue
+		case d:
+			m := runLength(s, j, d)
+			closeAt := -1
+			if m == size {
+				closeAt = j
+			} else if m == 3 {
+				closeAt = j + m - size
+			}
+			if closeAt > from && !isSpaceAt(s, j-1) {
+				after := closeAt + size
+				if d != '_' || !isWordAt(s, after) {
+					return closeAt, true
+				}
+			}
+			j += m
+			continue
+		}
+		j++
+	}
+	return 0, false
+}
+
+type link struct {
+	text  string
+	dest  string
+	title string
+	end   int
+}
+
+// parseLink 解析 [text](dest "title")，不支持引用式链接
+func parseLink(s string, start int) (link, bool) {
+	depth := 0
+	i := start
+	for ; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+			continue
+		case '[':
+			depth++
+		case ']':
+			depth--
+		}
+		if depth == 0 {
+			break
+		}
+	}
+	if i >= len(s) || i+1 >= len(s) || s[i+1] != '(' {
+		return link{}, false
+	}
+	l := link{text: s[start+1 : i]}
+	j := skipSpaces(s, i+2)
+	if j < len(s) && s[j] == '<' {
+		k := strings.IndexAny(s[j+1:], ">\n")
+		if k < 0 || s[j+1+k] != '>' {
+			return link{}, false
+		}
+		l.dest = s[j+1 : j+1+k]
+		j += k + 2
+	} else {
+		parens := 0
+		k := j
+		for ; k < len(s); k++ {
+			c := s[k]
+			if c == '\\' && k+1 < len(s) {
+				k++
+				continue
+			}
+			if c == '(' {
+				parens++
+			} else if c == ')' {
+				if parens == 0 {
+					break
+				}
+				parens--
+			} else if c == ' ' || c == '\n' {
+				break
+			}
+		}
+		l.dest = unescapePunct(s[j:k])
+		j = k
+	}
+	j = skipSpaces(s, j)
+	if j < len(s) && (s[j] == '"' || s[j] == '\'') {
+		q := s[j]
+		k := strings.IndexByte(s[j+1:], q)
+		if k < 0 {
+			return link{}, false
+		}
+		l.title = unescapePunct(s[j+1 : j+1+k])
+		j = skipSpaces(s, j+k+2)
+	}
+	if j >= len(s) || s[j] != ')' {
+		return link{}, false
+	}
+	l.end = j + 1
+	return l, true
+}
+
+// plainInline 去掉行内标记，用于图片的 alt
+func plainInline(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '*', '_', '`', '~', '[', ']', '\\':
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func unescapePunct(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]) {
+			i++
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
+
+func runLength(s string, start int, c byte) int {
+	n := 0
+	for start+n < len(s) && s[start+n] == c {
+		n++
+	}
+	return n
+}
+
+func skipSpaces(s string, i int) int {
+	for i < len(s) && (s[i] == ' ' || s[i] == '\n') {
+		i++
+	}
+	return i
+}
+
+func isASCIIPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func isSpaceAt(s string, i int) bool {
+	if i < 0 || i >= len(s) {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(s[i:])
+	return unicode.IsSpace(r)
+}
+
+// isWordBefore s[:i] 以字母或数字结尾
+func isWordBefore(s string, i int) bool {
+	if i <= 0 {
+		return false
+	}
+	r, _ := utf8.DecodeLastRuneInString(s[:i])
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isWordAt s[i:] 以字母或数字开头
+func isWordAt(s string, i int) bool {
+	if i >= len(s) {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(s[i:])
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
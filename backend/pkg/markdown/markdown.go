@@ -0,0 +1,43 @@
+// Package markdown 把 Markdown 渲染成经过白名单过滤的 HTML。
+// 只实现了写文章常用的语法：标题、段落、强调、删除线、行内代码、代码块、
+// 引用、列表、链接、图片、分割线和表格，允许内嵌 HTML，输出前统一过滤
+package markdown
+
+import (
+	"strings"
+)
+
+// Heading 目录中的一项
+type Heading struct {
+	Level int
+	Text  string
+	// Anchor 对应标题元素的 id
+	Anchor string
+}
+
+// Document 渲染结果
+type Document struct {
+	// HTML 过滤后的 HTML，可以直接输出给浏览器
+	HTML string
+	TOC  []Heading
+	// FirstImage 第一张图片的地址，没有图片时为空
+	FirstImage string
+	// Text 去掉所有标记后的纯文本，连续空白合并为一个空格
+	Text string
+}
+
+// Render 渲染并过滤 Markdown
+func Render(src string) Document {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\r", "\n")
+	// 渲染时用 \u0000 标记硬换行
+	src = strings.ReplaceAll(src, "\u0000", "�")
+	var sb strings.Builder
+	(&blockRenderer{sb: &sb}).render(strings.Split(src, "\n"), false)
+	return Sanitize(sb.String())
+}
+
+// PlainText Markdown 渲染后的纯文本
+func PlainText(src string) string {
+	return Render(src).Text
+}
@@ -0,0 +1,84 @@
+package markdown
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  string
+		html string
+	}{
+		{
+			name: "标题和强调",
+			src:  "# Hello *World*\n\nSome **bold** and `a<b`",
+			html: "<h1 id=\"hello-world\">Hello <em>World</em></h1>\n" +
+				"<p>Some <strong>bold</strong> and <code>a&lt;b</code></p>\n",
+		},
+		{
+			name: "紧凑列表",
+			src:  "- a\n- b\n  - c",
+			html: "<ul>\n<li>a\n</li>\n<li>b\n<ul>\n<li>c\n</li>\n</ul>\n</li>\n</ul>\n",
+		},
+		{
+			name: "有序列表起始编号",
+			src:  "3. x\n4. y",
+			html: "<ol start=\"3\">\n<li>x\n</li>\n<li>y\n</li>\n</ol>\n",
+		},
+		{
+			name: "代码块内容转义",
+			src:  "```go\nfmt.Println(\"<hi>\")\n```",
+			html: "<pre><code class=\"language-go\">fmt.Println(&#34;&lt;hi&gt;&#34;)\n</code></pre>\n",
+		},
+		{
+			name: "表格",
+			src:  "| a | b |\n|:-|-:|\n| 1 | 2 |",
+			html: "<table>\n<thead>\n<tr><th align=\"left\">a</th><th align=\"right\">b</th></tr>\n</thead>\n" +
+				"<tbody>\n<tr><td align=\"left\">1</td><td align=\"right\">2</td></tr>\n</tbody>\n</table>\n",
+		},
+		{
+			name: "下划线在单词内部",
+			src:  "snake_case_var",
+			html: "<p>snake_case_var</p>\n",
+		},
+		{
+			name: "硬换行",
+			src:  "a  \nb\\\nc",
+			html: "<p>a<br>\nb<br>\nc</p>\n",
+		},
+		{
+			name: "过滤危险链接",
+			src:  "[x](javascript:alert(1)) [y](https://a.com)",
+			html: "<p><a>x</a> <a href=\"https://a.com\" rel=\"nofollow noopener noreferrer\">y</a></p>\n",
+		},
+		{
+			name: "过滤内嵌 HTML",
+			src:  "<script>alert(1)</script><div onclick=\"x\">hi<img src=x onerror=alert(1)></div>",
+			html: "<div>hi<img src=\"x\"></div>\n",
+		},
+		{
+			name: "没有合法地址的图片整个丢弃",
+			src:  "<img src=\"javascript:alert(1)\">ok",
+			html: "ok\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.html, Render(tc.src).HTML)
+		})
+	}
+}
+
+func TestRender_Meta(t *testing.T) {
+	doc := Render("# 简介\n\n![封面](/img/a.png)\n\n## 简介\n\n正文 **内容**\n\n<h3>Raw</h3>")
+	assert.Equal(t, []Heading{
+		{Level: 1, Text: "简介", Anchor: "简介"},
+		{Level: 2, Text: "简介", Anchor: "简介-1"},
+		{Level: 3, Text: "Raw", Anchor: "raw"},
+	}, doc.TOC)
+	assert.Equal(t, "/img/a.png", doc.FirstImage)
+	assert.Equal(t, "简介 简介 正文 内容 Raw", doc.Text)
+}
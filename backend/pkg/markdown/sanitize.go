@@ -0,0 +1,271 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+
+	xhtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// allowedAttrs 白名单中的标签以及每个标签允许的属性，标题的 id 由 Sanitize 生成
+var allowedAttrs = map[string]map[string]bool{
+	"p": {}, "br": {}, "hr": {}, "div": {}, "span": {},
+	"h1": {}, "h2": {}, "h3": {}, "h4": {}, "h5": {}, "h6": {},
+	"blockquote": {}, "pre": {}, "code": {"class": true},
+	"em": {}, "strong": {}, "del": {}, "s": {}, "b": {}, "i": {}, "u": {},
+	"sup": {}, "sub": {}, "kbd": {}, "mark": {},
+	"ul": {}, "ol": {"start": true}, "li": {},
+	"dl": {}, "dt": {}, "dd": {},
+	"table": {}, "thead": {}, "tbody": {}, "tr": {},
+	"th": {"align": true}, "td": {"align": true},
+	"a":   {"href": true, "title": true},
+	"img": {"src": true, "alt": true, "title": true, "width": true, "height": true},
+}
+
+// droppedTags 连同内容一起丢弃的标签，其余不在白名单中的标签只去掉标签本身，保留内容
+var droppedTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "frame": true, "frameset": true,
+	"object": true, "embed": true, "applet": true, "noscript": true, "template": true,
+	"textarea": true, "select": true, "button": true, "input": true, "form": true,
+	"svg": true, "math": true, "head": true, "title": true, "link": true, "meta": true, "base": true,
+}
+
+var voidTags = map[string]bool{"br": true, "hr": true, "img": true}
+
+// blockTags 提取纯文本时，这些标签前后需要断开
+var blockTags = map[string]bool{
+	"p": true, "br": true, "hr": true, "div": true, "blockquote": true, "pre": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"ul": true, "ol": true, "li": true, "dl": true, "dt": true, "dd": true,
+	"table": true, "tr": true, "th": true, "td": true,
+}
+
+var (
+	codeClassRe = regexp.MustCompile(`^language-[A-Za-z0-9_+#-]{1,32}$`)
+	numberRe    = regexp.MustCompile(`^[0-9]{1,5}$`)
+)
+
+// Sanitize 按白名单过滤 HTML，同时生成目录、找到第一张图片并提取纯文本
+func Sanitize(raw string) Document {
+	nodes, err := xhtml.ParseFragment(strings.NewReader(raw), &xhtml.Node{
+		Type:     xhtml.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		// 解析失败时退化为纯文本
+		return Document{HTML: html.EscapeString(raw), Text: collapseSpace(raw)}
+	}
+	s := &sanitizer{anchors: make(map[string]int)}
+	for _, n := range nodes {
+		s.walk(n)
+	}
+	return Document{
+		HTML:       s.out.String(),
+		TOC:        s.toc,
+		FirstImage: s.firstImage,
+		Text:       collapseSpace(s.text.String()),
+	}
+}
+
+type sanitizer struct {
+	out        strings.Builder
+	text       strings.Builder
+	toc        []Heading
+	firstImage string
+	anchors    map[string]int
+}
+
+func (s *sanitizer) walk(n *xhtml.Node) {
+	switch n.Type {
+	case xhtml.TextNode:
+		s.out.WriteString(html.EscapeString(n.Data))
+		s.text.WriteString(n.Data)
+		return
+	case xhtml.ElementNode:
+	default:
+		// 注释、doctype 等直接丢弃
+		return
+	}
+
+	tag := n.Data
+	if droppedTags[tag] {
+		return
+	}
+	allowed, ok := allowedAttrs[tag]
+	if !ok {
+		s.walkChildren(n)
+		return
+	}
+
+	attrs, ok := s.filterAttrs(n, allowed)
+	if !ok {
+		return
+	}
+	if blockTags[tag] {
+		s.text.WriteString("\n")
+	}
+	if level := headingLevel(tag); level > 0 {
+		title := collapseSpace(textContent(n))
+		anchor := s.anchor(title)
+		attrs = append(attrs, xhtml.Attribute{Key: "id", Val: anchor})
+		s.toc = append(s.toc, Heading{Level: level, Text: title, Anchor: anchor})
+	}
+
+	s.out.WriteString("<" + tag)
+	for _, a := range attrs {
+		s.out.WriteString(" " + a.Key + `="` + html.EscapeString(a.Val) + `"`)
+	}
+	s.out.WriteString(">")
+	if voidTags[tag] {
+		return
+	}
+	s.walkChildren(n)
+	s.out.WriteString("</" + tag + ">")
+	if blockTags[tag] {
+		s.text.WriteString("\n")
+	}
+}
+
+func (s *sanitizer) walkChildren(n *xhtml.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		s.walk(c)
+	}
+}
+
+// filterAttrs 返回 false 表示整个元素都要丢弃，比如没有合法 src 的图片
+func (s *sanitizer) filterAttrs(n *xhtml.Node, allowed map[string]bool) ([]xhtml.Attribute, bool) {
+	var (
+		res      []xhtml.Attribute
+		external bool
+	)
+	for _, a := range n.Attr {
+		key := strings.ToLower(a.Key)
+		if a.Namespace != "" || !allowed[key] {
+			continue
+		}
+		val := strings.TrimSpace(a.Val)
+		switch key {
+		case "href", "src":
+			u, ok := safeURL(val, key == "href")
+			if !ok {
+				continue
+			}
+			external = u.IsAbs()
+		case "class":
+			if !codeClassRe.MatchString(val) {
+				continue
+			}
+		case "align":
+			if val != "left" && val != "center" && val != "right" {
+				continue
+			}
+		case "start", "width", "height":
+			if !numberRe.MatchString(val) {
+				continue
+			}
+		}
+		res = append(res, xhtml.Attribute{Key: key, Val: val})
+	}
+
+	switch n.Data {
+	case "img":
+		src, ok := attrVal(res, "src")
+		if !ok {
+			return nil, false
+		}
+		if s.firstImage == "" {
+			s.firstImage = src
+		}
+	case "a":
+		if external {
+			res = append(res, xhtml.Attribute{Key: "rel", Val: "nofollow noopener noreferrer"})
+		}
+	}
+	return res, true
+}
+
+// anchor 根据标题生成唯一的锚点
+func (s *sanitizer) anchor(title string) string {
+	slug := strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return unicode.ToLower(r)
+		case r == ' ' || r == '-' || r == '_':
+			return '-'
+		}
+		return -1
+	}, title)
+	slug = strings.Trim(slug, "-")
+	if slug == "" {
+		slug = "section"
+	}
+	cnt := s.anchors[slug]
+	s.anchors[slug] = cnt + 1
+	if cnt > 0 {
+		return fmt.Sprintf("%s-%d", slug, cnt)
+	}
+	return slug
+}
+
+// safeURL 只允许 http、https，链接额外允许 mailto，以及不带协议的相对地址
+func safeURL(raw string, isLink bool) (*url.URL, bool) {
+	// 浏览器会忽略协议中的控制字符和空白，比如 java\tscript:
+	for _, r := range raw {
+		if unicode.IsControl(r) || unicode.IsSpace(r) {
+			return nil, false
+		}
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "":
+		return u, raw != ""
+	case "http", "https":
+		return u, true
+	case "mailto":
+		return u, isLink
+	}
+	return nil, false
+}
+
+func attrVal(attrs []xhtml.Attribute, key string) (string, bool) {
+	for _, a := range attrs {
+		if a.Key == key {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+func headingLevel(tag string) int {
+	if len(tag) == 2 && tag[0] == 'h' && tag[1] >= '1' && tag[1] <= '6' {
+		return int(tag[1] - '0')
+	}
+	return 0
+}
+
+func textContent(n *xhtml.Node) string {
+	if n.Type == xhtml.TextNode {
+		return n.Data
+	}
+	if n.Type == xhtml.ElementNode && droppedTags[n.Data] {
+		return ""
+	}
+	var sb strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sb.WriteString(textContent(c))
+	}
+	return sb.String()
+}
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
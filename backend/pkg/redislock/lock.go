@@ -0,0 +1,119 @@
+package redislock
+
+import (
+	"context"
+	"crypto/rand"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// ErrLockHeld 锁已经被其他人持有
+	ErrLockHeld = errors.New("锁已被他人持有")
+	// ErrLockNotHeld 锁已经过期或者被其他人抢走
+	ErrLockNotHeld = errors.New("未持有锁")
+)
+
+var (
+	//go:embed unlock.lua
+	luaUnlock string
+	//go:embed refresh.lua
+	luaRefresh string
+)
+
+// Client 基于 Redis SET NX 的分布式锁
+type Client struct {
+	cmd redis.Cmdable
+}
+
+func NewClient(cmd redis.Cmdable) *Client {
+	return &Client{
+		cmd: cmd,
+	}
+}
+
+// TryLock 尝试加锁，不等待，锁被他人持有时返回 ErrLockHeld
+func (c *Client) TryLock(ctx context.Context, key string, expiration time.Duration) (*Lock, error) {
+	val, err := token()
+	if err != nil {
+		return nil, err
+	}
+	ok, err := c.cmd.SetNX(ctx, key, val, expiration).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrLockHeld
+	}
+	return &Lock{
+		cmd:        c.cmd,
+		key:        key,
+		value:      val,
+		expiration: expiration,
+	}, nil
+}
+
+type Lock struct {
+	cmd redis.Cmdable
+	key string
+	// value 用来确认锁还是自己的，避免释放别人的锁
+	value      string
+	expiration time.Duration
+}
+
+func (l *Lock) Unlock(ctx context.Context) error {
+	res, err := l.cmd.Eval(ctx, luaUnlock, []string{l.key}, l.value).Int64()
+	if err != nil {
+		return err
+	}
+	if res != 1 {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// Refresh 续约，把过期时间重置为加锁时的 expiration
+func (l *Lock) Refresh(ctx context.Context) error {
+	res, err := l.cmd.Eval(ctx, luaRefresh, []string{l.key}, l.value, l.expiration.Milliseconds()).Int64()
+	if err != nil {
+		return err
+	}
+	if res != 1 {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// AutoRefresh 每隔 interval 续约一次，直到 ctx 结束时返回 nil。
+// 续约失败时返回错误，调用方应该停止锁保护的工作
+func (l *Lock) AutoRefresh(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			rctx, cancel := context.WithTimeout(ctx, interval)
+			err := l.Refresh(rctx)
+			cancel()
+			// ctx 在续约期间结束不算失败
+			if err != nil && ctx.Err() == nil {
+				return err
+			}
+		}
+	}
+}
+
+func token() (string, error) {
+	bs := make([]byte, 16)
+	_, err := rand.Read(bs)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bs), nil
+}
@@ -0,0 +1,114 @@
+// Package sensitive 敏感词匹配，词库可以在运行时整体替换
+package sensitive
+
+import (
+	"strings"
+	"sync/atomic"
+	"unicode"
+)
+
+// Level 命中敏感词后的处理方式，数值越大越严重
+type Level uint8
+
+const (
+	LevelNone Level = iota
+	// LevelReview 需要人工审核
+	LevelReview
+	// LevelBlock 直接拒绝
+	LevelBlock
+)
+
+// Result 匹配结果，Level 是命中的词里最严重的级别
+type Result struct {
+	Level Level
+	// Words 命中的词，去重后按首次出现的顺序排列
+	Words []string
+}
+
+type node struct {
+	children map[rune]*node
+	// level 不为 LevelNone 表示从根到这里是一个完整的词
+	level Level
+	word  string
+}
+
+// Matcher 基于前缀树的敏感词匹配，忽略大小写，并发安全
+type Matcher struct {
+	root atomic.Pointer[node]
+}
+
+func NewMatcher() *Matcher {
+	m := &Matcher{}
+	m.root.Store(&node{})
+	return m
+}
+
+// Load 用 words 替换整个词库，同一个词出现多次时取最严重的级别
+func (m *Matcher) Load(words map[string]Level) {
+	root := &node{}
+	for w, level := range words {
+		w = normalize(w)
+		if w == "" || level == LevelNone {
+			continue
+		}
+		cur := root
+		for _, r := range w {
+			if cur.children == nil {
+				cur.children = make(map[rune]*node)
+			}
+			next, ok := cur.children[r]
+			if !ok {
+				next = &node{}
+				cur.children[r] = next
+			}
+			cur = next
+		}
+		if level > cur.level {
+			cur.level = level
+		}
+		cur.word = w
+	}
+	m.root.Store(root)
+}
+
+// Match 找出 text 中所有的敏感词，词和词之间可以重叠
+func (m *Matcher) Match(text string) Result {
+	root := m.root.Load()
+	var res Result
+	if len(root.children) == 0 {
+		return res
+	}
+	runes := []rune(normalize(text))
+	seen := make(map[string]struct{})
+	for i := range runes {
+		cur := root
+		for j := i; j < len(runes); j++ {
+			next, ok := cur.children[runes[j]]
+			if !ok {
+				break
+			}
+			cur = next
+			if cur.level == LevelNone {
+				continue
+			}
+			if cur.level > res.Level {
+				res.Level = cur.level
+			}
+			if _, ok := seen[cur.word]; !ok {
+				seen[cur.word] = struct{}{}
+				res.Words = append(res.Words, cur.word)
+			}
+		}
+	}
+	return res
+}
+
+func normalize(s string) string {
+	return strings.Map(func(r rune) rune {
+		// 去掉空白，避免用空格把敏感词隔开
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, s)
+}
@@ -0,0 +1,59 @@
+package sensitive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatcher_Match(t *testing.T) {
+	m := NewMatcher()
+	m.Load(map[string]Level{
+		"赌博":   LevelBlock,
+		"代购":   LevelReview,
+		"Spam": LevelReview,
+		"":     LevelBlock,
+	})
+
+	testCases := []struct {
+		name string
+		text string
+		want Result
+	}{
+		{
+			name: "没有命中",
+			text: "今天天气不错",
+		},
+		{
+			name: "命中需要审核的词",
+			text: "海外代购，欢迎咨询",
+			want: Result{Level: LevelReview, Words: []string{"代购"}},
+		},
+		{
+			name: "取最严重的级别，忽略大小写和空白",
+			text: "SPAM 代购 赌 博",
+			want: Result{Level: LevelBlock, Words: []string{"spam", "代购", "赌博"}},
+		},
+		{
+			name: "重复出现只记录一次",
+			text: "代购代购",
+			want: Result{Level: LevelReview, Words: []string{"代购"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, m.Match(tc.text))
+		})
+	}
+}
+
+func TestMatcher_Load(t *testing.T) {
+	m := NewMatcher()
+	m.Load(map[string]Level{"代购": LevelReview})
+	assert.Equal(t, LevelReview, m.Match("代购").Level)
+
+	// 重新加载后旧词库失效
+	m.Load(map[string]Level{"赌博": LevelBlock})
+	assert.Equal(t, LevelNone, m.Match("代购").Level)
+	assert.Equal(t, LevelBlock, m.Match("赌博").Level)
+}
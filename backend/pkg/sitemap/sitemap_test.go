@@ -0,0 +1,41 @@
+package sitemap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLSet(t *testing.T) {
+	bs, err := URLSet([]URL{
+		{Loc: "https://example.com/articles/1?a=1&b=2", LastMod: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{Loc: "https://example.com/articles/2"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url>
+    <loc>https://example.com/articles/1?a=1&amp;b=2</loc>
+    <lastmod>2024-01-02T03:04:05Z</lastmod>
+  </url>
+  <url>
+    <loc>https://example.com/articles/2</loc>
+  </url>
+</urlset>`, string(bs))
+}
+
+func TestIndex(t *testing.T) {
+	bs, err := Index([]URL{
+		{Loc: "https://example.com/sitemaps/sitemap-1.xml", LastMod: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap>
+    <loc>https://example.com/sitemaps/sitemap-1.xml</loc>
+    <lastmod>2024-01-02T03:04:05Z</lastmod>
+  </sitemap>
+</sitemapindex>`, string(bs))
+}
@@ -0,0 +1,172 @@
+// Package syndication 生成 RSS 2.0 和 Atom 1.0 格式的订阅源
+package syndication
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+type Feed struct {
+	Title       string
+	Link        string
+	Description string
+	// Updated 为零值时使用条目中最晚的更新时间
+	Updated time.Time
+	Items   []Item
+}
+
+type Item struct {
+	// Link 文章地址，同时用作条目的唯一标识
+	Link    string
+	Title   string
+	Author  string
+	Summary string
+	// Content HTML 格式的正文，为空时只输出摘要
+	Content   string
+	Published time.Time
+	Updated   time.Time
+}
+
+// LastModified 订阅源的最后修改时间
+func (f Feed) LastModified() time.Time {
+	res := f.Updated
+	for _, it := range f.Items {
+		if it.Updated.After(res) {
+			res = it.Updated
+		}
+	}
+	return res
+}
+
+type rss struct {
+	XMLName xml.Name `xml:"rss"`
+	Version string   `xml:"version,attr"`
+	// DC 作者用 dc:creator 表示，RSS 自带的 author 要求是邮箱
+	DC      string     `xml:"xmlns:dc,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	Author      string  `xml:"dc:creator,omitempty"`
+	Description string  `xml:"description"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// RSS 生成 RSS 2.0，正文不为空时 description 使用正文
+func RSS(f Feed) ([]byte, error) {
+	ch := rssChannel{
+		Title:       f.Title,
+		Link:        f.Link,
+		Description: f.Description,
+		Items:       make([]rssItem, 0, len(f.Items)),
+	}
+	if updated := f.LastModified(); !updated.IsZero() {
+		ch.LastBuildDate = updated.UTC().Format(time.RFC1123Z)
+	}
+	for _, it := range f.Items {
+		desc := it.Content
+		if desc == "" {
+			desc = it.Summary
+		}
+		ch.Items = append(ch.Items, rssItem{
+			Title:       it.Title,
+			Link:        it.Link,
+			GUID:        rssGUID{IsPermaLink: true, Value: it.Link},
+			Author:      it.Author,
+			Description: desc,
+			PubDate:     it.Published.UTC().Format(time.RFC1123Z),
+		})
+	}
+	return marshal(rss{
+		Version: "2.0",
+		DC:      "http://purl.org/dc/elements/1.1/",
+		Channel: ch,
+	})
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Link    atomLink    `xml:"link"`
+	Updated string      `xml:"updated"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title     string      `xml:"title"`
+	ID        string      `xml:"id"`
+	Link      atomLink    `xml:"link"`
+	Author    *atomAuthor `xml:"author,omitempty"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+	Summary   string      `xml:"summary,omitempty"`
+	Content   *atomText   `xml:"content,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Atom 生成 Atom 1.0，摘要和正文分别输出
+func Atom(f Feed) ([]byte, error) {
+	res := atomFeed{
+		Title:   f.Title,
+		ID:      f.Link,
+		Link:    atomLink{Href: f.Link, Rel: "alternate"},
+		Updated: f.LastModified().UTC().Format(time.RFC3339),
+		Entries: make([]atomEntry, 0, len(f.Items)),
+	}
+	for _, it := range f.Items {
+		entry := atomEntry{
+			Title:     it.Title,
+			ID:        it.Link,
+			Link:      atomLink{Href: it.Link, Rel: "alternate"},
+			Published: it.Published.UTC().Format(time.RFC3339),
+			Updated:   it.Updated.UTC().Format(time.RFC3339),
+			Summary:   it.Summary,
+		}
+		if it.Author != "" {
+			entry.Author = &atomAuthor{Name: it.Author}
+		}
+		if it.Content != "" {
+			entry.Content = &atomText{Type: "html", Value: it.Content}
+		}
+		res.Entries = append(res.Entries, entry)
+	}
+	return marshal(res)
+}
+
+func marshal(v any) ([]byte, error) {
+	bs, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), bs...), nil
+}
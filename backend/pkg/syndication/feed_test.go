@@ -0,0 +1,100 @@
+package syndication
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFeed() Feed {
+	return Feed{
+		Title:       "小明的文章",
+		Link:        "https://example.com/authors/1",
+		Description: "小明最近发表的文章",
+		Items: []Item{
+			{
+				Link:      "https://example.com/articles/2",
+				Title:     "第二篇 <b>",
+				Author:    "小明",
+				Summary:   "摘要 & 更多",
+				Content:   "<p>正文</p>",
+				Published: time.UnixMilli(2000),
+				Updated:   time.UnixMilli(3000),
+			},
+			{
+				Link:      "https://example.com/articles/1",
+				Title:     "第一篇",
+				Summary:   "只有摘要",
+				Published: time.UnixMilli(1000),
+				Updated:   time.UnixMilli(1000),
+			},
+		},
+	}
+}
+
+func TestFeed_LastModified(t *testing.T) {
+	assert.Equal(t, time.UnixMilli(3000), testFeed().LastModified())
+	assert.True(t, Feed{}.LastModified().IsZero())
+}
+
+func TestRSS(t *testing.T) {
+	bs, err := RSS(testFeed())
+	require.NoError(t, err)
+	assert.Contains(t, string(bs), `xmlns:dc="http://purl.org/dc/elements/1.1/"`)
+	assert.Contains(t, string(bs), "<dc:creator>小明</dc:creator>")
+
+	var res struct {
+		Version string `xml:"version,attr"`
+		Channel struct {
+			Title string `xml:"title"`
+			Items []struct {
+				Title       string `xml:"title"`
+				Link        string `xml:"link"`
+				Description string `xml:"description"`
+				PubDate     string `xml:"pubDate"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	require.NoError(t, xml.Unmarshal(bs, &res))
+	assert.Equal(t, "2.0", res.Version)
+	assert.Equal(t, "小明的文章", res.Channel.Title)
+	require.Len(t, res.Channel.Items, 2)
+	assert.Equal(t, "第二篇 <b>", res.Channel.Items[0].Title)
+	assert.Equal(t, "<p>正文</p>", res.Channel.Items[0].Description)
+	assert.Equal(t, "只有摘要", res.Channel.Items[1].Description)
+	assert.Equal(t, time.UnixMilli(1000).UTC().Format(time.RFC1123Z), res.Channel.Items[1].PubDate)
+}
+
+func TestAtom(t *testing.T) {
+	bs, err := Atom(testFeed())
+	require.NoError(t, err)
+
+	var res struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		Updated string   `xml:"updated"`
+		Entries []struct {
+			ID      string `xml:"id"`
+			Summary string `xml:"summary"`
+			Author  *struct {
+				Name string `xml:"name"`
+			} `xml:"author"`
+			Content *struct {
+				Type  string `xml:"type,attr"`
+				Value string `xml:",chardata"`
+			} `xml:"content"`
+		} `xml:"entry"`
+	}
+	require.NoError(t, xml.Unmarshal(bs, &res))
+	assert.Equal(t, time.UnixMilli(3000).UTC().Format(time.RFC3339), res.Updated)
+	require.Len(t, res.Entries, 2)
+	assert.Equal(t, "https://example.com/articles/2", res.Entries[0].ID)
+	assert.Equal(t, "摘要 & 更多", res.Entries[0].Summary)
+	assert.Equal(t, "小明", res.Entries[0].Author.Name)
+	assert.Equal(t, "html", res.Entries[0].Content.Type)
+	assert.Equal(t, "<p>正文</p>", res.Entries[0].Content.Value)
+	assert.Nil(t, res.Entries[1].Author)
+	assert.Nil(t, res.Entries[1].Content)
+}
@@ -5,14 +5,17 @@ package main
 import (
 	"github.com/google/wire"
 	article2 "github.com/johnwongx/webook/backend/internal/events/article"
+	"github.com/johnwongx/webook/backend/internal/job"
 	"github.com/johnwongx/webook/backend/internal/repository"
 	"github.com/johnwongx/webook/backend/internal/repository/cache"
 	"github.com/johnwongx/webook/backend/internal/repository/dao"
 	"github.com/johnwongx/webook/backend/internal/repository/dao/article"
+	"github.com/johnwongx/webook/backend/internal/repository/search"
 	"github.com/johnwongx/webook/backend/internal/service"
 	"github.com/johnwongx/webook/backend/internal/web"
 	"github.com/johnwongx/webook/backend/internal/web/jwt"
 	"github.com/johnwongx/webook/backend/ioc"
+	"github.com/johnwongx/webook/backend/pkg/redislock"
 )
 
 func InitWebServer() *App {
@@ -21,18 +24,60 @@ func InitWebServer() *App {
 		ioc.InitLogger,
 
 		dao.NewUserDAO,
-		article.NewGORMArticleDAO,
+		ioc.InitBlobStore,
+		ioc.InitArticleMigrationDAO,
+		ioc.InitArticleDAO,
+		ioc.InitReaderArticleDAO,
+		ioc.InitSensitiveMatcher,
+		ioc.InitSite,
+		article.NewGORMArticleScheduleDAO,
 		dao.NewGORMInteractiveDAO,
+		dao.NewGORMFollowDAO,
+		dao.NewGORMFeedDAO,
+		dao.NewGORMCommentDAO,
+		dao.NewGORMCollectionDAO,
+		dao.NewGORMSeriesDAO,
+		dao.NewGORMUploadDAO,
+		dao.NewGORMExportDAO,
+		dao.NewGORMImportDAO,
+		dao.NewGORMReadingHistoryDAO,
 
 		cache.NewRedisUserCache,
 		cache.NewRedisCodeCache,
 		cache.NewRedisArticleCache,
+		cache.NewRedisArticleTagCache,
+		cache.NewRedisArticleAuthorCache,
+		cache.NewRedisSyndicationCache,
+		cache.NewRedisSitemapCache,
+		cache.NewRedisArticleMigrationCache,
 		cache.NewRedisInteractiveCache,
+		cache.NewRedisRankingCache,
+		cache.NewLocalRankingCache,
+		cache.NewRedisCommentCache,
+
+		search.NewMemoryArticleIndex,
 
 		repository.NewUserRepository,
 		repository.NewCodeRepository,
 		repository.NewArticleRepository,
+		repository.NewArticleScheduleRepository,
+		repository.NewArticleSearchRepository,
 		repository.NewInteractiveRepository,
+		repository.NewCachedRankingRepository,
+		repository.NewFollowRepository,
+		repository.NewFeedRepository,
+		repository.NewCommentRepository,
+		repository.NewCollectionRepository,
+		repository.NewSeriesRepository,
+		repository.NewUploadRepository,
+		repository.NewExportRepository,
+		repository.NewImportRepository,
+		repository.NewCachedSyndicationRepository,
+		repository.NewSitemapRepository,
+		repository.NewArticleMigrationRepository,
+		repository.NewArticleConsistencyRepository,
+		repository.NewReaderArticleRepository,
+		repository.NewReadingHistoryRepository,
 
 		ioc.InitTencentSms,
 		ioc.InitWechatService,
@@ -44,15 +89,60 @@ func InitWebServer() *App {
 		service.NewCodeService,
 		service.NewArticleService,
 		service.NewInteractiveService,
+		service.NewSearchService,
+		service.NewBatchRankingService,
+		service.NewFollowService,
+		service.NewFeedService,
+		service.NewCommentService,
+		service.NewCollectionService,
+		service.NewSeriesService,
+		service.NewUploadService,
+		service.NewExportService,
+		service.NewImportService,
+		service.NewSyndicationService,
+		service.NewSitemapService,
+		service.NewArticleMigrationService,
+		service.NewArticleConsistencyService,
+		service.NewArticleReaderService,
+		service.NewReadingHistoryService,
 
 		article2.NewKafkaProducer,
 		//article2.NewKafkaConsumer,
 		article2.NewBatchKafkaConsumer,
+		article2.NewHistoryConsumer,
 		ioc.NewConsumers,
 
+		job.NewArticleScheduleJob,
+		job.NewSearchIndexJob,
+		job.NewRankingJob,
+		job.NewArticlePurgeJob,
+		job.NewUploadGCJob,
+		job.NewArticleExportJob,
+		job.NewSitemapJob,
+		job.NewArticleMigrationStageJob,
+		job.NewArticleMigrationVerifyJob,
+		ioc.InitArticleConsistencyJob,
+		redislock.NewClient,
+		ioc.NewJobs,
+
 		web.NewUserHandler,
 		web.NewWechatHandler,
 		web.NewArticleHandler,
+		web.NewSearchHandler,
+		web.NewAuthorHandler,
+		web.NewRankingHandler,
+		web.NewFollowHandler,
+		web.NewCommentHandler,
+		web.NewCollectionHandler,
+		web.NewSeriesHandler,
+		web.NewUploadHandler,
+		web.NewExportHandler,
+		web.NewImportHandler,
+		web.NewSyndicationHandler,
+		web.NewSitemapHandler,
+		web.NewReadingHistoryHandler,
+		web.NewAdminHandler,
+		ioc.InitAdminSet,
 		jwt.NewRedisJwtHandler,
 
 		ioc.InitRedisRateLimit,
@@ -8,14 +8,17 @@ package main
 
 import (
 	article2 "github.com/johnwongx/webook/backend/internal/events/article"
+	"github.com/johnwongx/webook/backend/internal/job"
 	"github.com/johnwongx/webook/backend/internal/repository"
 	"github.com/johnwongx/webook/backend/internal/repository/cache"
 	"github.com/johnwongx/webook/backend/internal/repository/dao"
 	"github.com/johnwongx/webook/backend/internal/repository/dao/article"
+	"github.com/johnwongx/webook/backend/internal/repository/search"
 	"github.com/johnwongx/webook/backend/internal/service"
 	"github.com/johnwongx/webook/backend/internal/web"
 	"github.com/johnwongx/webook/backend/internal/web/jwt"
 	"github.com/johnwongx/webook/backend/ioc"
+	"github.com/johnwongx/webook/backend/pkg/redislock"
 )
 
 // Injectors from wire.go:
@@ -39,24 +42,111 @@ func InitWebServer() *App {
 	wechatService := ioc.InitWechatService(logger)
 	wechatHandlerConfig := ioc.NewWechatHandlerConfig()
 	oAuth2WechatHandler := web.NewWechatHandler(wechatService, userService, wechatHandlerConfig)
-	articleDAO := article.NewGORMArticleDAO(db, logger)
+	blobStore := ioc.InitBlobStore()
+	doubleWriteDAO := ioc.InitArticleMigrationDAO(db, logger)
+	articleDAO := ioc.InitArticleDAO(db, blobStore, doubleWriteDAO, logger)
+	readerArticleDAO := ioc.InitReaderArticleDAO(db, logger)
 	articleCache := cache.NewRedisArticleCache(cmdable)
-	articleRepository := repository.NewArticleRepository(articleDAO, userRepository, articleCache, logger)
-	articleService := service.NewArticleService(articleRepository, logger)
+	articleTagCache := cache.NewRedisArticleTagCache(cmdable)
+	articleAuthorCache := cache.NewRedisArticleAuthorCache(cmdable)
+	syndicationCache := cache.NewRedisSyndicationCache(cmdable)
+	sitemapCache := cache.NewRedisSitemapCache(cmdable)
+	articleIndex := search.NewMemoryArticleIndex()
+	articleRepository := repository.NewArticleRepository(articleDAO, readerArticleDAO, userRepository, articleCache, articleTagCache, articleAuthorCache, syndicationCache, sitemapCache, articleIndex, logger)
+	articleScheduleDAO := article.NewGORMArticleScheduleDAO(db)
+	articleScheduleRepository := repository.NewArticleScheduleRepository(articleScheduleDAO)
 	interactiveDAO := dao.NewGORMInteractiveDAO(db, logger)
 	interactiveCache := cache.NewRedisInteractiveCache(cmdable)
 	interactiveRepository := repository.NewInteractiveRepository(interactiveDAO, interactiveCache, logger)
+	followDAO := dao.NewGORMFollowDAO(db)
+	followRepository := repository.NewFollowRepository(followDAO)
+	feedDAO := dao.NewGORMFeedDAO(db)
+	feedRepository := repository.NewFeedRepository(feedDAO)
+	feedService := service.NewFeedService(followRepository, feedRepository, articleRepository, userRepository, logger)
+	uploadDAO := dao.NewGORMUploadDAO(db)
+	uploadRepository := repository.NewUploadRepository(uploadDAO, blobStore)
+	uploadService := service.NewUploadService(uploadRepository, logger)
+	matcher := ioc.InitSensitiveMatcher(logger)
+	articleService := service.NewArticleService(articleRepository, articleScheduleRepository, interactiveRepository, feedService, uploadService, matcher, logger)
 	interactiveService := service.NewInteractiveService(interactiveRepository)
+	seriesDAO := dao.NewGORMSeriesDAO(db)
+	seriesRepository := repository.NewSeriesRepository(seriesDAO)
+	seriesService := service.NewSeriesService(seriesRepository, articleRepository, userRepository, logger)
 	client := ioc.InitKafka()
 	syncProducer := ioc.NewSyncProducer(client)
 	producer := article2.NewKafkaProducer(syncProducer)
-	articleHandler := web.NewArticleHandler(articleService, interactiveService, logger, producer)
-	engine := ioc.InitWebServer(v, userHandler, oAuth2WechatHandler, articleHandler)
+	articleHandler := web.NewArticleHandler(articleService, interactiveService, seriesService, logger, producer)
+	articleSearchRepository := repository.NewArticleSearchRepository(articleDAO, userRepository, articleIndex, logger)
+	searchService := service.NewSearchService(articleSearchRepository)
+	searchHandler := web.NewSearchHandler(searchService, logger)
+	authorHandler := web.NewAuthorHandler(articleService, userService, interactiveService, logger)
+	rankingCache := cache.NewRedisRankingCache(cmdable)
+	localRankingCache := cache.NewLocalRankingCache()
+	rankingRepository := repository.NewCachedRankingRepository(rankingCache, localRankingCache, logger)
+	rankingService := service.NewBatchRankingService(articleRepository, interactiveRepository, userRepository, rankingRepository, logger)
+	rankingHandler := web.NewRankingHandler(rankingService, logger)
+	followService := service.NewFollowService(followRepository, userService, feedService, logger)
+	followHandler := web.NewFollowHandler(followService, feedService, logger)
+	commentDAO := dao.NewGORMCommentDAO(db)
+	commentCache := cache.NewRedisCommentCache(cmdable)
+	commentRepository := repository.NewCommentRepository(commentDAO, commentCache, userRepository, logger)
+	commentService := service.NewCommentService(commentRepository, articleRepository, interactiveRepository, logger)
+	commentHandler := web.NewCommentHandler(commentService, interactiveService, logger)
+	collectionDAO := dao.NewGORMCollectionDAO(db)
+	collectionRepository := repository.NewCollectionRepository(collectionDAO, interactiveCache, logger)
+	collectionService := service.NewCollectionService(collectionRepository, interactiveRepository, articleRepository, logger)
+	collectionHandler := web.NewCollectionHandler(collectionService, logger)
+	seriesHandler := web.NewSeriesHandler(seriesService, logger)
+	uploadHandler := web.NewUploadHandler(uploadService, logger)
+	articleMigrationCache := cache.NewRedisArticleMigrationCache(cmdable)
+	articleMigrationRepository := repository.NewArticleMigrationRepository(doubleWriteDAO, articleMigrationCache)
+	articleMigrationService := service.NewArticleMigrationService(articleMigrationRepository, logger)
+	articleConsistencyRepository := repository.NewArticleConsistencyRepository(blobStore)
+	articleConsistencyService := service.NewArticleConsistencyService(articleRepository, articleConsistencyRepository, logger)
+	readerArticleRepository := repository.NewReaderArticleRepository(readerArticleDAO, articleDAO)
+	articleReaderService := service.NewArticleReaderService(readerArticleRepository, logger)
+	adminSet := ioc.InitAdminSet(logger)
+	adminHandler := web.NewAdminHandler(articleService, articleMigrationService, articleConsistencyService, articleReaderService, adminSet, logger)
+	exportDAO := dao.NewGORMExportDAO(db)
+	exportRepository := repository.NewExportRepository(exportDAO, blobStore)
+	exportService := service.NewExportService(exportRepository, articleRepository, logger)
+	exportHandler := web.NewExportHandler(exportService, logger)
+	importDAO := dao.NewGORMImportDAO(db)
+	importRepository := repository.NewImportRepository(importDAO)
+	importService := service.NewImportService(articleService, importRepository, logger)
+	importHandler := web.NewImportHandler(importService, logger)
+	syndicationRepository := repository.NewCachedSyndicationRepository(syndicationCache)
+	site := ioc.InitSite()
+	syndicationService := service.NewSyndicationService(syndicationRepository, articleRepository, userRepository, site, logger)
+	syndicationHandler := web.NewSyndicationHandler(syndicationService, logger)
+	sitemapRepository := repository.NewSitemapRepository(blobStore, sitemapCache)
+	sitemapService := service.NewSitemapService(sitemapRepository, articleRepository, site, logger)
+	sitemapHandler := web.NewSitemapHandler(sitemapService, logger)
+	readingHistoryDAO := dao.NewGORMReadingHistoryDAO(db)
+	readingHistoryRepository := repository.NewReadingHistoryRepository(readingHistoryDAO)
+	readingHistoryService := service.NewReadingHistoryService(readingHistoryRepository, articleRepository, logger)
+	readingHistoryHandler := web.NewReadingHistoryHandler(readingHistoryService, logger)
+	engine := ioc.InitWebServer(v, userHandler, oAuth2WechatHandler, articleHandler, searchHandler, authorHandler, rankingHandler, followHandler, commentHandler, collectionHandler, seriesHandler, uploadHandler, adminHandler, exportHandler, importHandler, syndicationHandler, sitemapHandler, readingHistoryHandler, blobStore)
 	batchKafkaConsumer := article2.NewBatchKafkaConsumer(client, interactiveRepository, logger)
-	v2 := ioc.NewConsumers(batchKafkaConsumer)
+	historyConsumer := article2.NewHistoryConsumer(client, readingHistoryService, logger)
+	v2 := ioc.NewConsumers(batchKafkaConsumer, historyConsumer)
+	articleScheduleJob := job.NewArticleScheduleJob(articleService)
+	searchIndexJob := job.NewSearchIndexJob(searchService)
+	redislockClient := redislock.NewClient(cmdable)
+	rankingJob := job.NewRankingJob(rankingService, redislockClient, logger)
+	articlePurgeJob := job.NewArticlePurgeJob(articleService)
+	uploadGCJob := job.NewUploadGCJob(uploadService)
+	articleExportJob := job.NewArticleExportJob(exportService)
+	sitemapJob := job.NewSitemapJob(sitemapService)
+	articleMigrationStageJob := job.NewArticleMigrationStageJob(articleMigrationService)
+	articleMigrationVerifyJob := job.NewArticleMigrationVerifyJob(articleMigrationService, redislockClient, logger)
+	articleConsistencyJob := ioc.InitArticleConsistencyJob(articleConsistencyService, redislockClient, logger)
+	v3 := ioc.NewJobs(logger, articleScheduleJob, searchIndexJob, rankingJob, articlePurgeJob, uploadGCJob, articleExportJob, sitemapJob, articleMigrationStageJob, articleMigrationVerifyJob, articleConsistencyJob)
 	app := &App{
 		server:    engine,
 		consumers: v2,
+		jobs:      v3,
+		readerSvc: articleReaderService,
 	}
 	return app
 }